@@ -0,0 +1,84 @@
+package bento
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// API lists the Bento operations provided by Client, so code can depend on
+// the interface and substitute a mock in its own tests
+type API interface {
+	Ping(ctx context.Context, opts ...RequestOption) error
+
+	// Subscribers
+	FindSubscriber(ctx context.Context, email string, opts ...RequestOption) (*SubscriberData, error)
+	FindSubscriberWithOptions(ctx context.Context, email string, opts FindOptions, reqOpts ...RequestOption) (*SubscriberData, error)
+	FindSubscriberByUUID(ctx context.Context, uuid string, opts ...RequestOption) (*SubscriberData, error)
+	FindSubscribers(ctx context.Context, emails []string, opts ...RequestOption) (map[string]*SubscriberData, error)
+	ListSubscribers(ctx context.Context, opts *ListSubscribersOptions, reqOpts ...RequestOption) (*SubscriberPage, error)
+	SearchSubscribers(ctx context.Context, query SubscriberQuery, opts ...RequestOption) (*SubscriberPage, error)
+	CountSubscribers(ctx context.Context, query *SubscriberQuery, opts ...RequestOption) (int, error)
+	ExportSubscribers(ctx context.Context, w io.Writer, opts ExportOptions, reqOpts ...RequestOption) (int, error)
+	CreateSubscriber(ctx context.Context, input *SubscriberInput, opts ...RequestOption) (*SubscriberData, error)
+	UpdateSubscriber(ctx context.Context, email string, input *SubscriberInput, opts ...RequestOption) (*SubscriberData, error)
+	UpsertSubscriber(ctx context.Context, input *SubscriberInput, opts ...RequestOption) (*SubscriberData, error)
+	RemoveSubscriber(ctx context.Context, email string, opts ...RequestOption) error
+	ImportSubscribers(ctx context.Context, subscribers []*SubscriberInput, opts ...RequestOption) error
+	ImportSubscribersWithOptions(ctx context.Context, subscribers []*SubscriberInput, opts *ImportOptions, reqOpts ...RequestOption) (*ImportResult, error)
+	ImportSubscribersStream(ctx context.Context, r io.Reader, opts StreamImportOptions, reqOpts ...RequestOption) (*ImportResult, error)
+	ImportSubscribersCSV(ctx context.Context, r io.Reader, opts CSVOptions, reqOpts ...RequestOption) (*ImportResult, error)
+	SubscriberCommand(ctx context.Context, commands []CommandData, opts ...RequestOption) error
+	ExecuteCommandsSequential(ctx context.Context, cmds []CommandData, opts *SequentialOptions, reqOpts ...RequestOption) (*CommandResult, error)
+	PauseSubscriber(ctx context.Context, email string, opts ...RequestOption) error
+	ResumeSubscriber(ctx context.Context, email string, opts ...RequestOption) error
+	ChangeSubscriberEmail(ctx context.Context, oldEmail, newEmail string, opts ...RequestOption) error
+	AddTagsToSubscriber(ctx context.Context, email string, tags []string, opts ...RequestOption) error
+	RemoveTagsFromSubscriber(ctx context.Context, email string, tags []string, opts ...RequestOption) error
+	RemoveFieldsFromSubscriber(ctx context.Context, email string, keys []string, opts ...RequestOption) error
+
+	// Events and emails
+	TrackEvent(ctx context.Context, events []EventData, opts ...RequestOption) error
+	TrackEventSingle(ctx context.Context, eventType, email string, fields map[string]interface{}, opts ...RequestOption) error
+	TrackPurchase(ctx context.Context, email string, p PurchaseEvent, opts ...RequestOption) error
+	TrackSubscribe(ctx context.Context, email string, details map[string]interface{}, opts ...RequestOption) error
+	TrackUnsubscribe(ctx context.Context, email string, details map[string]interface{}, opts ...RequestOption) error
+	TrackEventStream(ctx context.Context, events <-chan EventData, opts EventStreamOptions, reqOpts ...RequestOption) (*EventResult, error)
+	TrackEventWithResult(ctx context.Context, events []EventData, opts ...RequestOption) (*EventResult, error)
+	CreateEmails(ctx context.Context, emails []EmailData, opts ...RequestOption) (int, error)
+
+	// Broadcasts
+	GetBroadcasts(ctx context.Context, opts ...RequestOption) ([]BroadcastData, error)
+	GetBroadcast(ctx context.Context, id string, opts ...RequestOption) (*BroadcastData, error)
+	CreateBroadcast(ctx context.Context, broadcasts []BroadcastData, opts ...RequestOption) error
+	CloneBroadcast(ctx context.Context, id string, overrides BroadcastData, opts ...RequestOption) (BroadcastData, error)
+	CloneAndCreateBroadcast(ctx context.Context, id string, overrides BroadcastData, opts ...RequestOption) (BroadcastData, error)
+
+	// Tags and fields
+	GetTags(ctx context.Context, opts ...RequestOption) ([]TagData, error)
+	GetSubscriberTags(ctx context.Context, email string, opts ...RequestOption) ([]TagData, error)
+	CreateTag(ctx context.Context, tagName string, opts ...RequestOption) (*TagData, error)
+	EnsureTag(ctx context.Context, tagName string, opts ...RequestOption) (bool, error)
+	GetFields(ctx context.Context, opts ...RequestOption) ([]FieldData, error)
+	CreateField(ctx context.Context, key string, opts ...RequestOption) (*FieldData, error)
+	EnsureField(ctx context.Context, key string, opts ...RequestOption) (bool, error)
+
+	// Statistics
+	GetSiteStats(ctx context.Context, opts ...RequestOption) (map[string]interface{}, error)
+	GetSiteStatsRange(ctx context.Context, r *SiteStatsRange, opts ...RequestOption) ([]SiteStatsPoint, error)
+	CollectSiteStats(ctx context.Context, sink SiteStatsSink, interval time.Duration, opts ...RequestOption) error
+	WatchSiteStats(ctx context.Context, interval time.Duration, opts ...RequestOption) (<-chan SiteStats, error)
+	GetSubscriberSegments(ctx context.Context, email string, opts ...RequestOption) ([]SegmentData, error)
+	GetSegmentStats(ctx context.Context, segmentID string, opts ...RequestOption) (map[string]interface{}, error)
+	GetReportStats(ctx context.Context, reportID string, opts ...RequestOption) (map[string]interface{}, error)
+
+	// Experimental
+	GetBlacklistStatus(ctx context.Context, data *BlacklistData, opts ...RequestOption) (map[string]interface{}, error)
+	ValidateEmail(ctx context.Context, data *ValidationData, opts ...RequestOption) (*ValidationResponse, error)
+	ValidateEmails(ctx context.Context, data []*ValidationData, opts ...RequestOption) ([]*ValidationResponse, error)
+	GetContentModeration(ctx context.Context, content string, opts ...RequestOption) (map[string]interface{}, error)
+	GetGender(ctx context.Context, fullName string, opts ...RequestOption) (map[string]interface{}, error)
+	GeoLocateIP(ctx context.Context, ipAddress string, opts ...RequestOption) (map[string]interface{}, error)
+}
+
+var _ API = (*Client)(nil)
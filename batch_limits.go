@@ -0,0 +1,97 @@
+package bento
+
+import "fmt"
+
+// Default and maximum number of items per request for each batch endpoint.
+// The maximums are the most the API accepts in one request.
+const (
+	defaultEventsPerRequest      = 500
+	defaultSubscribersPerRequest = 500
+	defaultEmailsPerRequest      = 60
+
+	maxEventsPerRequest      = 1000
+	maxSubscribersPerRequest = 1000
+	maxEmailsPerRequest      = 60
+)
+
+// BatchLimits bounds the number of items TrackEvent, ImportSubscribers and
+// CreateEmails send per request. Larger batches of events and subscribers
+// are split into several requests unless DisableChunking is set; larger
+// batches of emails are rejected unless ChunkEmails is set.
+type BatchLimits struct {
+	// MaxEventsPerRequest defaults to 500 and cannot exceed 1000
+	MaxEventsPerRequest int
+
+	// MaxSubscribersPerRequest defaults to 500 and cannot exceed 1000.
+	// ImportOptions.ChunkSize overrides it for a single import.
+	MaxSubscribersPerRequest int
+
+	// MaxEmailsPerRequest defaults to 60, which is also its maximum
+	MaxEmailsPerRequest int
+
+	// DisableChunking rejects batches larger than their limit with
+	// ErrInvalidBatchSize instead of splitting them, so a call never
+	// succeeds for only part of its items. It overrides ChunkEmails.
+	DisableChunking bool
+
+	// ChunkEmails splits email batches larger than MaxEmailsPerRequest into
+	// several requests. Sending an email is not idempotent: when a request
+	// fails, the earlier ones have been sent and retrying the whole batch
+	// sends them again.
+	ChunkEmails bool
+}
+
+// validateBatchLimits rejects negative limits and limits the API would refuse
+func validateBatchLimits(config *Config) error {
+	limits := []struct {
+		field string
+		value int
+		max   int
+	}{
+		{"BatchLimits.MaxEventsPerRequest", config.BatchLimits.MaxEventsPerRequest, maxEventsPerRequest},
+		{"BatchLimits.MaxSubscribersPerRequest", config.BatchLimits.MaxSubscribersPerRequest, maxSubscribersPerRequest},
+		{"BatchLimits.MaxEmailsPerRequest", config.BatchLimits.MaxEmailsPerRequest, maxEmailsPerRequest},
+	}
+	for _, limit := range limits {
+		if limit.value < 0 || limit.value > limit.max {
+			return fmt.Errorf("%w: %s cannot be negative or exceed %d (got %d)", ErrInvalidConfig, limit.field, limit.max, limit.value)
+		}
+	}
+	return nil
+}
+
+// batchLimits returns Config.BatchLimits with defaults filled in
+func (c *Client) batchLimits() BatchLimits {
+	limits := c.config.BatchLimits
+	if limits.MaxEventsPerRequest == 0 {
+		limits.MaxEventsPerRequest = defaultEventsPerRequest
+	}
+	if limits.MaxSubscribersPerRequest == 0 {
+		limits.MaxSubscribersPerRequest = defaultSubscribersPerRequest
+	}
+	if limits.MaxEmailsPerRequest == 0 {
+		limits.MaxEmailsPerRequest = defaultEmailsPerRequest
+	}
+	return limits
+}
+
+// importChunkSize returns the number of subscribers an import sends per
+// request: chunkSize when set, otherwise BatchLimits.MaxSubscribersPerRequest
+func (c *Client) importChunkSize(chunkSize int) (int, error) {
+	if chunkSize > maxSubscribersPerRequest {
+		return 0, fmt.Errorf("%w: chunk size cannot exceed %d subscribers (got %d)", ErrInvalidBatchSize, maxSubscribersPerRequest, chunkSize)
+	}
+	if chunkSize <= 0 {
+		return c.batchLimits().MaxSubscribersPerRequest, nil
+	}
+	return chunkSize, nil
+}
+
+// checkBatchSize rejects a batch of n items larger than limit when chunking
+// is disabled
+func (c *Client) checkBatchSize(n, limit int, items string) error {
+	if n > limit && c.config.BatchLimits.DisableChunking {
+		return fmt.Errorf("%w: %d %s exceed the limit of %d per request", ErrInvalidBatchSize, n, items, limit)
+	}
+	return nil
+}
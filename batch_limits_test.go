@@ -0,0 +1,180 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// batchSizes serves the batch endpoints, recording the number of items in
+// each request and reporting them all as accepted
+func batchSizes(t *testing.T, sizes *[]int) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		var body map[string][]json.RawMessage
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		n := 0
+		for _, items := range body {
+			n += len(items)
+		}
+		*sizes = append(*sizes, n)
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": n, "failed": 0}), nil
+	}
+}
+
+func batchEmails(n int) []bento.EmailData {
+	emails := make([]bento.EmailData, n)
+	for i := range emails {
+		emails[i] = bento.EmailData{
+			To:            fmt.Sprintf("recipient%d@example.com", i),
+			From:          "sender@example.com",
+			Subject:       "Test Subject",
+			HTMLBody:      "<p>Test Content</p>",
+			Transactional: true,
+		}
+	}
+	return emails
+}
+
+func batchSubscribers(n int) []*bento.SubscriberInput {
+	subscribers := make([]*bento.SubscriberInput, n)
+	for i := range subscribers {
+		subscribers[i] = &bento.SubscriberInput{Email: fmt.Sprintf("user%d@example.com", i)}
+	}
+	return subscribers
+}
+
+func TestBatchLimits(t *testing.T) {
+	endpoints := []struct {
+		name  string
+		limit func(*bento.BatchLimits)
+		send  func(*bento.Client) error
+	}{
+		{
+			name:  "events",
+			limit: func(l *bento.BatchLimits) { l.MaxEventsPerRequest = 2 },
+			send: func(c *bento.Client) error {
+				return c.TrackEvent(context.Background(), pageviews(5))
+			},
+		},
+		{
+			name:  "subscribers",
+			limit: func(l *bento.BatchLimits) { l.MaxSubscribersPerRequest = 2 },
+			send: func(c *bento.Client) error {
+				return c.ImportSubscribers(context.Background(), batchSubscribers(5))
+			},
+		},
+		{
+			name:  "emails",
+			limit: func(l *bento.BatchLimits) { l.MaxEmailsPerRequest, l.ChunkEmails = 2, true },
+			send: func(c *bento.Client) error {
+				queued, err := c.CreateEmails(context.Background(), batchEmails(5))
+				if err == nil && queued != 5 {
+					return fmt.Errorf("expected 5 emails queued, got %d", queued)
+				}
+				return err
+			},
+		},
+	}
+
+	for _, endpoint := range endpoints {
+		for _, disableChunking := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s disable chunking %v", endpoint.name, disableChunking), func(t *testing.T) {
+				var sizes []int
+				client, err := setupTestClientWithConfig(func(c *bento.Config) {
+					endpoint.limit(&c.BatchLimits)
+					c.BatchLimits.DisableChunking = disableChunking
+				}, batchSizes(t, &sizes))
+				if err != nil {
+					t.Fatalf("failed to setup test client: %v", err)
+				}
+
+				err = endpoint.send(client)
+				if disableChunking {
+					if !errors.Is(err, bento.ErrInvalidBatchSize) {
+						t.Errorf("expected ErrInvalidBatchSize, got %v", err)
+					}
+					if len(sizes) != 0 {
+						t.Errorf("expected no requests, got %v", sizes)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if want := []int{2, 2, 1}; !reflect.DeepEqual(sizes, want) {
+					t.Errorf("expected requests of %v, got %v", want, sizes)
+				}
+			})
+		}
+	}
+}
+
+func TestBatchLimitsEmailChunkingIsOptIn(t *testing.T) {
+	var sizes []int
+	client, err := setupTestClient(batchSizes(t, &sizes))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	if _, err := client.CreateEmails(context.Background(), batchEmails(61)); !errors.Is(err, bento.ErrInvalidBatchSize) || !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected 61 emails to be rejected by default, got %v", err)
+	}
+	if len(sizes) != 0 {
+		t.Errorf("expected no requests, got %v", sizes)
+	}
+
+	client, err = setupTestClientWithConfig(func(c *bento.Config) {
+		c.BatchLimits.ChunkEmails = true
+	}, batchSizes(t, &sizes))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	queued, err := client.CreateEmails(context.Background(), batchEmails(61))
+	if err != nil || queued != 61 {
+		t.Fatalf("expected 61 emails queued, got %d, %v", queued, err)
+	}
+	if want := []int{60, 1}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("expected requests of %v, got %v", want, sizes)
+	}
+}
+
+func TestBatchLimitsValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits bento.BatchLimits
+	}{
+		{name: "events above API limit", limits: bento.BatchLimits{MaxEventsPerRequest: 1001}},
+		{name: "subscribers above API limit", limits: bento.BatchLimits{MaxSubscribersPerRequest: 1001}},
+		{name: "emails above API limit", limits: bento.BatchLimits{MaxEmailsPerRequest: 61}},
+		{name: "negative limit", limits: bento.BatchLimits{MaxEventsPerRequest: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.BatchLimits = tt.limits
+			}, batchSizes(t, new([]int)))
+			if !errors.Is(err, bento.ErrInvalidConfig) {
+				t.Errorf("expected ErrInvalidConfig, got %v", err)
+			}
+		})
+	}
+
+	t.Run("import chunk size above API limit", func(t *testing.T) {
+		client, err := setupTestClient(batchSizes(t, new([]int)))
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+		_, err = client.ImportSubscribersWithOptions(context.Background(), batchSubscribers(1), &bento.ImportOptions{ChunkSize: 1001})
+		if !errors.Is(err, bento.ErrInvalidBatchSize) {
+			t.Errorf("expected ErrInvalidBatchSize, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,272 @@
+package bento
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBatcherClosed is returned by the Add methods of a Batcher once it is closed
+var ErrBatcherClosed = newError(CodeCanceled, "batcher closed")
+
+// Defaults for BatcherOptions
+const (
+	defaultBatcherFlushSize     = 100
+	defaultBatcherFlushInterval = 5 * time.Second
+)
+
+// BatcherOptions controls a Batcher
+type BatcherOptions struct {
+	// EventFlushSize, SubscriberFlushSize and CommandFlushSize are the number
+	// of pending items of each kind that trigger a flush of that kind. Each
+	// defaults to 100.
+	EventFlushSize      int
+	SubscriberFlushSize int
+	CommandFlushSize    int
+
+	// FlushInterval is the longest an item waits before it is flushed,
+	// shared by every kind. Defaults to 5 seconds.
+	FlushInterval time.Duration
+
+	// OnError is called from the background goroutine for each request of a
+	// flush that failed, with the items it held so they can be added again
+	OnError func(failure BatchFailure)
+}
+
+// BatchFailure holds the items of a failed Batcher request and its error.
+// Only the slice of the request's kind is set. When the API rejects some
+// items of a request, all of them are reported.
+type BatchFailure struct {
+	Events      []EventData
+	Subscribers []*SubscriberInput
+	Commands    []CommandData
+	Err         error
+}
+
+// Batcher collects events, subscribers and commands from any number of
+// goroutines and sends them with TrackEvent, ImportSubscribers and
+// SubscriberCommand in the background. Each kind is flushed once its flush
+// size is reached, and every kind is flushed when FlushInterval has passed.
+// A flush sends subscribers first, then events, then commands, so commands
+// can refer to subscribers imported in the same flush.
+//
+// Items are validated when added and must not be modified afterwards.
+// Pending items are held in memory until flushed; Close flushes what is left.
+type Batcher struct {
+	client *Client
+	opts   BatcherOptions
+
+	mu          sync.Mutex
+	events      []EventData
+	subscribers []*SubscriberInput
+	commands    []CommandData
+	closed      bool
+
+	// flushMu keeps flushes, and so the order of items, sequential
+	flushMu sync.Mutex
+	full    chan struct{}
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewBatcher starts a Batcher that sends its items with client
+func NewBatcher(client *Client, opts BatcherOptions) (*Batcher, error) {
+	if client == nil {
+		return nil, fmt.Errorf("%w: client is required", ErrInvalidRequest)
+	}
+	if opts.EventFlushSize < 0 || opts.SubscriberFlushSize < 0 || opts.CommandFlushSize < 0 || opts.FlushInterval < 0 {
+		return nil, fmt.Errorf("%w: flush sizes and interval cannot be negative", ErrInvalidRequest)
+	}
+	for _, size := range []*int{&opts.EventFlushSize, &opts.SubscriberFlushSize, &opts.CommandFlushSize} {
+		if *size == 0 {
+			*size = defaultBatcherFlushSize
+		}
+	}
+	if opts.FlushInterval == 0 {
+		opts.FlushInterval = defaultBatcherFlushInterval
+	}
+
+	b := &Batcher{
+		client: client,
+		opts:   opts,
+		full:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	b.wg.Add(1)
+	go b.run()
+	return b, nil
+}
+
+// AddEvent validates event and queues it for the next flush
+func (b *Batcher) AddEvent(ctx context.Context, event EventData) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	event = b.client.normalizeEvents([]EventData{event})[0]
+	if err := b.client.validateEventAt(event, b.client.clock().Now()); err != nil {
+		return err
+	}
+	return addPending(b, &b.events, event, b.opts.EventFlushSize)
+}
+
+// AddSubscriber validates subscriber and queues it for the next flush
+func (b *Batcher) AddSubscriber(ctx context.Context, subscriber *SubscriberInput) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	if subscriber == nil {
+		return fmt.Errorf("%w: subscriber is required", ErrInvalidRequest)
+	}
+	normalized := *b.client.normalizeSubscribers([]*SubscriberInput{subscriber})[0]
+	if err := validateSubscriberInput(&normalized); err != nil {
+		return err
+	}
+	return addPending(b, &b.subscribers, &normalized, b.opts.SubscriberFlushSize)
+}
+
+// AddCommand validates command and queues it for the next flush
+func (b *Batcher) AddCommand(ctx context.Context, command CommandData) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	command = b.client.normalizeCommands([]CommandData{command})[0]
+	if err := validateCommand(command); err != nil {
+		return err
+	}
+	return addPending(b, &b.commands, command, b.opts.CommandFlushSize)
+}
+
+// addPending appends item to pending, waking the background goroutine once
+// flushSize items are waiting
+func addPending[T any](b *Batcher, pending *[]T, item T, flushSize int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBatcherClosed
+	}
+	*pending = append(*pending, item)
+	if len(*pending) >= flushSize {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Len returns the number of items of every kind waiting to be flushed
+func (b *Batcher) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.events) + len(b.subscribers) + len(b.commands)
+}
+
+// Flush sends every pending item now and returns the errors of the failed
+// requests, which are not passed to OnError
+func (b *Batcher) Flush(ctx context.Context) error {
+	var errs []error
+	for _, failure := range b.flush(ctx, true) {
+		errs = append(errs, failure.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops the background goroutine and flushes the remaining items with
+// ctx. The Add methods fail with ErrBatcherClosed afterwards. Closing an
+// already closed Batcher returns nil.
+func (b *Batcher) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.done)
+	b.mu.Unlock()
+
+	b.wg.Wait()
+	return b.Flush(ctx)
+}
+
+// run flushes in the background until the Batcher is closed
+func (b *Batcher) run() {
+	defer b.wg.Done()
+
+	// tick is only rearmed when it fires, so flushes of a kind that reached
+	// its flush size do not delay the flush of the others
+	clock := b.client.clock()
+	tick := clock.After(b.opts.FlushInterval)
+	for {
+		all := false
+		select {
+		case <-b.done:
+			return
+		case <-b.full:
+		case <-tick:
+			tick = clock.After(b.opts.FlushInterval)
+			all = true
+		}
+
+		for _, failure := range b.flush(context.Background(), all) {
+			if b.opts.OnError != nil {
+				b.opts.OnError(failure)
+			}
+		}
+	}
+}
+
+// flush takes the pending items of every kind that reached its flush size,
+// or of every kind when all is set, and sends them in requests within
+// Config.BatchLimits
+func (b *Batcher) flush(ctx context.Context, all bool) []BatchFailure {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	subscribers := takePending(&b.subscribers, b.opts.SubscriberFlushSize, all)
+	events := takePending(&b.events, b.opts.EventFlushSize, all)
+	commands := takePending(&b.commands, b.opts.CommandFlushSize, all)
+	b.mu.Unlock()
+
+	limits := b.client.batchLimits()
+	var failures []BatchFailure
+	for _, batch := range splitBatches(subscribers, limits.MaxSubscribersPerRequest) {
+		if err := b.client.ImportSubscribers(ctx, batch); err != nil {
+			failures = append(failures, BatchFailure{Subscribers: batch, Err: err})
+		}
+	}
+	for _, batch := range splitBatches(events, limits.MaxEventsPerRequest) {
+		if err := b.client.TrackEvent(ctx, batch); err != nil {
+			failures = append(failures, BatchFailure{Events: batch, Err: err})
+		}
+	}
+	if len(commands) > 0 {
+		if err := b.client.SubscriberCommand(ctx, commands); err != nil {
+			failures = append(failures, BatchFailure{Commands: commands, Err: err})
+		}
+	}
+	return failures
+}
+
+// splitBatches splits items into batches of at most size
+func splitBatches[T any](items []T, size int) [][]T {
+	var batches [][]T
+	for start := 0; start < len(items); start += size {
+		batches = append(batches, items[start:min(start+size, len(items))])
+	}
+	return batches
+}
+
+// takePending empties pending and returns its items when all is set or it
+// holds at least flushSize items
+func takePending[T any](pending *[]T, flushSize int, all bool) []T {
+	if !all && len(*pending) < flushSize {
+		return nil
+	}
+	items := *pending
+	*pending = nil
+	return items
+}
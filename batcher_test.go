@@ -0,0 +1,261 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// batcherRequest is one request sent by a Batcher: the kind of its items
+// and how many it held
+type batcherRequest struct {
+	kind  string
+	items int
+}
+
+// batcherRequests serves the endpoints a Batcher calls, sending each request
+// on the returned channel and replying with the status set for its kind
+func batcherRequests(t *testing.T, status map[string]int) (chan batcherRequest, func(*http.Request) (*http.Response, error)) {
+	requests := make(chan batcherRequest, 1000)
+	return requests, func(req *http.Request) (*http.Response, error) {
+		var body map[string][]json.RawMessage
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		var kind string
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/batch/events"):
+			kind = "events"
+		case strings.HasSuffix(req.URL.Path, "/batch/subscribers"):
+			kind = "subscribers"
+		case strings.HasSuffix(req.URL.Path, "/fetch/commands"):
+			kind = "command"
+		default:
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		items := len(body[kind])
+		requests <- batcherRequest{kind: kind, items: items}
+		if code, ok := status[kind]; ok {
+			return mockResponse(code, map[string]string{"error": "unavailable"}), nil
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": items, "failed": 0}), nil
+	}
+}
+
+func setupBatcher(t *testing.T, clock *fakeClock, handler func(*http.Request) (*http.Response, error), opts bento.BatcherOptions) *bento.Batcher {
+	t.Helper()
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+	}, handler)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	b, err := bento.NewBatcher(client, opts)
+	if err != nil {
+		t.Fatalf("failed to create batcher: %v", err)
+	}
+	t.Cleanup(func() { _ = b.Close(context.Background()) })
+	return b
+}
+
+// addItems adds one event, subscriber and command for user i
+func addItems(t *testing.T, b *bento.Batcher, i int) {
+	email := fmt.Sprintf("user%d@example.com", i)
+	ctx := context.Background()
+	if err := b.AddSubscriber(ctx, &bento.SubscriberInput{Email: email}); err != nil {
+		t.Errorf("unexpected error adding subscriber %d: %v", i, err)
+	}
+	if err := b.AddEvent(ctx, bento.EventData{Type: "$pageview", Email: email}); err != nil {
+		t.Errorf("unexpected error adding event %d: %v", i, err)
+	}
+	if err := b.AddCommand(ctx, bento.CommandData{Command: bento.CommandAddTag, Email: email, Query: "customer"}); err != nil {
+		t.Errorf("unexpected error adding command %d: %v", i, err)
+	}
+}
+
+func TestBatcherConcurrentProducers(t *testing.T) {
+	requests, handler := batcherRequests(t, nil)
+	b := setupBatcher(t, newFakeClock(), handler, bento.BatcherOptions{
+		EventFlushSize:      7,
+		SubscriberFlushSize: 5,
+		CommandFlushSize:    3,
+		FlushInterval:       time.Hour,
+	})
+
+	const producers, perProducer = 8, 25
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				addItems(t, b, p*perProducer+i)
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("expected no pending items after Close, got %d", b.Len())
+	}
+	close(requests)
+
+	sent := map[string]int{}
+	for req := range requests {
+		sent[req.kind] += req.items
+	}
+	want := map[string]int{"events": producers * perProducer, "subscribers": producers * perProducer, "command": producers * perProducer}
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("expected %v items sent, got %v", want, sent)
+	}
+}
+
+func TestBatcherFlushesOnInterval(t *testing.T) {
+	clock := newFakeClock()
+	requests, handler := batcherRequests(t, nil)
+	b := setupBatcher(t, clock, handler, bento.BatcherOptions{FlushInterval: time.Minute})
+
+	addItems(t, b, 0)
+	clock.BlockUntil(t, 1)
+	clock.Advance(time.Minute)
+
+	// Subscribers go first so commands can refer to them
+	for _, kind := range []string{"subscribers", "events", "command"} {
+		select {
+		case req := <-requests:
+			if req != (batcherRequest{kind: kind, items: 1}) {
+				t.Errorf("expected one %s, got %+v", kind, req)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %s", kind)
+		}
+	}
+}
+
+func TestBatcherFlushesEachKindAtItsThreshold(t *testing.T) {
+	requests, handler := batcherRequests(t, nil)
+	b := setupBatcher(t, newFakeClock(), handler, bento.BatcherOptions{EventFlushSize: 2, FlushInterval: time.Hour})
+
+	addItems(t, b, 0)
+	if err := b.AddEvent(context.Background(), bento.EventData{Type: "$pageview", Email: "user1@example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case req := <-requests:
+		if req != (batcherRequest{kind: "events", items: 2}) {
+			t.Errorf("expected the two events, got %+v", req)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the events")
+	}
+	// flush runs in the background, so wait for it to take the events
+	deadline := time.Now().Add(2 * time.Second)
+	for b.Len() != 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if b.Len() != 2 {
+		t.Errorf("expected the subscriber and command to stay pending, got %d items", b.Len())
+	}
+}
+
+func TestBatcherIntervalNotDelayedByFullFlushes(t *testing.T) {
+	clock := newFakeClock()
+	requests, handler := batcherRequests(t, nil)
+	b := setupBatcher(t, clock, handler, bento.BatcherOptions{EventFlushSize: 1, FlushInterval: time.Minute})
+
+	if err := b.AddCommand(context.Background(), bento.CommandData{Command: bento.CommandAddTag, Email: "user@example.com", Query: "customer"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock.BlockUntil(t, 1)
+
+	// Events keep reaching their flush size while the command waits
+	for i := 0; i < 3; i++ {
+		clock.Advance(15 * time.Second)
+		if err := b.AddEvent(context.Background(), bento.EventData{Type: "$pageview", Email: fmt.Sprintf("user%d@example.com", i)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		select {
+		case req := <-requests:
+			if req != (batcherRequest{kind: "events", items: 1}) {
+				t.Fatalf("expected the event, got %+v", req)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for the event")
+		}
+	}
+
+	clock.Advance(15 * time.Second)
+	select {
+	case req := <-requests:
+		if req != (batcherRequest{kind: "command", items: 1}) {
+			t.Errorf("expected the command, got %+v", req)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the command was not flushed at the interval")
+	}
+}
+
+func TestBatcherOnError(t *testing.T) {
+	clock := newFakeClock()
+	_, handler := batcherRequests(t, map[string]int{"events": http.StatusServiceUnavailable})
+	failures := make(chan bento.BatchFailure, 1)
+	b := setupBatcher(t, clock, handler, bento.BatcherOptions{
+		FlushInterval: time.Minute,
+		OnError:       func(failure bento.BatchFailure) { failures <- failure },
+	})
+
+	addItems(t, b, 0)
+	clock.BlockUntil(t, 1)
+	clock.Advance(time.Minute)
+
+	select {
+	case failure := <-failures:
+		if status, ok := bento.HTTPStatus(failure.Err); !ok || status != http.StatusServiceUnavailable {
+			t.Errorf("expected the 503 to be reported, got %v", failure.Err)
+		}
+		if len(failure.Events) != 1 || failure.Events[0].Email != "user0@example.com" || failure.Subscribers != nil || failure.Commands != nil {
+			t.Errorf("expected only the failed event, got %+v", failure)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for OnError")
+	}
+
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+	if err := b.AddEvent(context.Background(), bento.EventData{Type: "$pageview", Email: "user0@example.com"}); !errors.Is(err, bento.ErrBatcherClosed) {
+		t.Errorf("expected ErrBatcherClosed, got %v", err)
+	}
+}
+
+func TestBatcherValidatesItems(t *testing.T) {
+	_, handler := batcherRequests(t, nil)
+	b := setupBatcher(t, newFakeClock(), handler, bento.BatcherOptions{})
+	ctx := context.Background()
+
+	if err := b.AddEvent(ctx, bento.EventData{Type: "$pageview", Email: "invalid"}); !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Errorf("expected ErrInvalidEmail for the event, got %v", err)
+	}
+	if err := b.AddSubscriber(ctx, &bento.SubscriberInput{Email: "invalid"}); !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Errorf("expected ErrInvalidEmail for the subscriber, got %v", err)
+	}
+	if err := b.AddCommand(ctx, bento.CommandData{Command: bento.CommandAddTag, Email: "user@example.com"}); err == nil {
+		t.Error("expected an error for a command without a query")
+	}
+	if b.Len() != 0 {
+		t.Errorf("expected invalid items not to be queued, got %d", b.Len())
+	}
+}
@@ -0,0 +1,188 @@
+package bento
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitBreakerConfig makes the client fail fast with ErrCircuitOpen after
+// repeated failures instead of waiting on an API that is down. Network
+// errors, 429 and 5xx responses count as failures; other 4xx responses are
+// caller errors and do not.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit. Defaults to 5.
+	FailureThreshold int
+	// OpenDuration is how long the circuit stays open before probing the API
+	// again. Defaults to 30s.
+	OpenDuration time.Duration
+	// HalfOpenProbes is the number of trial requests let through after the
+	// open period; all of them must succeed to close the circuit. Defaults to 1.
+	HalfOpenProbes int
+}
+
+const (
+	defaultBreakerFailureThreshold = 5
+	defaultBreakerOpenDuration     = 30 * time.Second
+	defaultBreakerHalfOpenProbes   = 1
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker tracks consecutive failures across all requests of a client
+type circuitBreaker struct {
+	mu           sync.Mutex
+	threshold    int
+	openDuration time.Duration
+	probes       int
+
+	state     circuitState
+	failures  int
+	openedAt  time.Time
+	inFlight  int
+	successes int
+	// halfOpens counts the half-open periods so a probe from an earlier
+	// one is not mistaken for a current probe
+	halfOpens uint64
+}
+
+// breakerTicket identifies an allowed request when its outcome is recorded
+type breakerTicket struct {
+	probe    bool
+	halfOpen uint64
+}
+
+func newCircuitBreaker(config *CircuitBreakerConfig) *circuitBreaker {
+	b := &circuitBreaker{
+		threshold:    config.FailureThreshold,
+		openDuration: config.OpenDuration,
+		probes:       config.HalfOpenProbes,
+	}
+	if b.threshold <= 0 {
+		b.threshold = defaultBreakerFailureThreshold
+	}
+	if b.openDuration <= 0 {
+		b.openDuration = defaultBreakerOpenDuration
+	}
+	if b.probes <= 0 {
+		b.probes = defaultBreakerHalfOpenProbes
+	}
+	return b
+}
+
+// allow reports ErrCircuitOpen when a request may not be sent now. The
+// ticket of an allowed request is passed back to record or release.
+func (b *circuitBreaker) allow(now time.Time) (breakerTicket, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if now.Sub(b.openedAt) < b.openDuration {
+			return breakerTicket{}, ErrCircuitOpen
+		}
+		b.state = circuitHalfOpen
+		b.halfOpens++
+		b.inFlight = 0
+		b.successes = 0
+		fallthrough
+	case circuitHalfOpen:
+		if b.inFlight >= b.probes {
+			return breakerTicket{}, ErrCircuitOpen
+		}
+		b.inFlight++
+		return breakerTicket{probe: true, halfOpen: b.halfOpens}, nil
+	}
+	return breakerTicket{}, nil
+}
+
+// isProbe reports whether ticket holds one of the current half-open probe slots
+func (b *circuitBreaker) isProbe(ticket breakerTicket) bool {
+	return b.state == circuitHalfOpen && ticket.probe && ticket.halfOpen == b.halfOpens
+}
+
+// record updates the circuit with the outcome of an allowed request. While
+// half-open only the current probes count; requests admitted before the
+// circuit opened say nothing about whether the API has recovered.
+func (b *circuitBreaker) record(ticket breakerTicket, failed bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.threshold {
+			b.trip(now)
+		}
+	case circuitHalfOpen:
+		if !b.isProbe(ticket) {
+			return
+		}
+		b.inFlight--
+		if failed {
+			b.trip(now)
+			return
+		}
+		b.successes++
+		if b.successes >= b.probes {
+			b.state = circuitClosed
+			b.failures = 0
+		}
+	}
+}
+
+// release frees a half-open probe slot without judging the outcome, e.g.
+// when the caller cancelled the request
+func (b *circuitBreaker) release(ticket breakerTicket) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.isProbe(ticket) {
+		b.inFlight--
+	}
+}
+
+func (b *circuitBreaker) trip(now time.Time) {
+	b.state = circuitOpen
+	b.openedAt = now
+}
+
+// guarded performs a single attempt through the circuit breaker, if configured
+func (c *Client) guarded(req *http.Request) (*http.Response, error) {
+	if c.breaker == nil {
+		return c.roundTrip(req)
+	}
+	ticket, err := c.breaker.allow(c.clock().Now())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.roundTrip(req)
+	if err != nil && req.Context().Err() != nil {
+		c.breaker.release(ticket)
+		return resp, err
+	}
+	c.breaker.record(ticket, breakerFailure(responseStatus(resp, err), err), c.clock().Now())
+	return resp, err
+}
+
+// breakerFailure reports whether an attempt's outcome indicates the API is unhealthy
+func breakerFailure(status int, err error) bool {
+	if err == nil {
+		return false
+	}
+	if status == 0 {
+		return true
+	}
+	return status == http.StatusTooManyRequests || status >= 500
+}
@@ -0,0 +1,181 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// switchableHandler answers every request with the current status
+type switchableHandler struct {
+	status   int
+	requests int
+}
+
+func (h *switchableHandler) handle(req *http.Request) (*http.Response, error) {
+	h.requests++
+	return mockResponse(h.status, map[string]interface{}{"data": []interface{}{}}), nil
+}
+
+func setupBreakerClient(t *testing.T, handler *switchableHandler, clock *fakeClock) *bento.Client {
+	t.Helper()
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+		c.CircuitBreaker = &bento.CircuitBreakerConfig{
+			FailureThreshold: 3,
+			OpenDuration:     time.Minute,
+		}
+	}, handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	return client
+}
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	handler := &switchableHandler{status: http.StatusServiceUnavailable}
+	clock := newFakeClock()
+	client := setupBreakerClient(t, handler, clock)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetTags(ctx); errors.Is(err, bento.ErrCircuitOpen) {
+			t.Fatalf("call %d: circuit opened too early", i+1)
+		}
+	}
+
+	// The circuit is open: calls fail without reaching the API
+	_, err := client.GetTags(ctx)
+	if !errors.Is(err, bento.ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if !errors.Is(err, bento.ErrAPIResponse) {
+		t.Errorf("expected ErrCircuitOpen to satisfy ErrAPIResponse")
+	}
+	if bento.CodeOf(err) != bento.CodeCircuitOpen {
+		t.Errorf("expected CodeCircuitOpen, got %s", bento.CodeOf(err))
+	}
+	if handler.requests != 3 {
+		t.Errorf("expected 3 requests, got %d", handler.requests)
+	}
+
+	// A successful half-open probe closes the circuit
+	clock.Advance(time.Minute)
+	handler.status = http.StatusOK
+	if _, err := client.GetTags(ctx); err != nil {
+		t.Fatalf("expected probe to succeed, got %v", err)
+	}
+	if _, err := client.GetTags(ctx); err != nil {
+		t.Errorf("expected closed circuit, got %v", err)
+	}
+	if handler.requests != 5 {
+		t.Errorf("expected 5 requests, got %d", handler.requests)
+	}
+}
+
+func TestCircuitBreakerFailedProbeReopens(t *testing.T) {
+	handler := &switchableHandler{status: http.StatusServiceUnavailable}
+	clock := newFakeClock()
+	client := setupBreakerClient(t, handler, clock)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, _ = client.GetTags(ctx)
+	}
+
+	clock.Advance(time.Minute)
+	if _, err := client.GetTags(ctx); err == nil || errors.Is(err, bento.ErrCircuitOpen) {
+		t.Fatalf("expected probe to reach the API and fail, got %v", err)
+	}
+	if _, err := client.GetTags(ctx); !errors.Is(err, bento.ErrCircuitOpen) {
+		t.Errorf("expected circuit to reopen after failed probe, got %v", err)
+	}
+	if handler.requests != 4 {
+		t.Errorf("expected 4 requests, got %d", handler.requests)
+	}
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	handler := &switchableHandler{status: http.StatusBadRequest}
+	client := setupBreakerClient(t, handler, newFakeClock())
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.GetTags(context.Background()); errors.Is(err, bento.ErrCircuitOpen) {
+			t.Fatalf("call %d: 400 responses must not open the circuit", i+1)
+		}
+	}
+	if handler.requests != 5 {
+		t.Errorf("expected 5 requests, got %d", handler.requests)
+	}
+}
+
+func TestCircuitBreakerIgnoresRequestsAdmittedWhileClosed(t *testing.T) {
+	clock := newFakeClock()
+	started := make(chan string, 2)
+	gates := map[string]chan struct{}{"closed": make(chan struct{}), "probe": make(chan struct{})}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+		c.CircuitBreaker = &bento.CircuitBreakerConfig{
+			FailureThreshold: 3,
+			OpenDuration:     time.Minute,
+		}
+	}, func(req *http.Request) (*http.Response, error) {
+		// Requests tagged with a gate wait for it and then succeed
+		if name := req.Header.Get("X-Gate"); name != "" {
+			started <- name
+			<-gates[name]
+			return mockResponse(http.StatusOK, map[string]interface{}{"data": []interface{}{}}), nil
+		}
+		return mockResponse(http.StatusServiceUnavailable, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	ctx := context.Background()
+
+	gated := func(name string) chan error {
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := client.GetTags(ctx, bento.WithRequestHeader("X-Gate", name))
+			errCh <- err
+		}()
+		if got := <-started; got != name {
+			t.Fatalf("expected %s request to start, got %s", name, got)
+		}
+		return errCh
+	}
+
+	// A request admitted while closed is still in flight when the circuit trips
+	closedErr := gated("closed")
+	for i := 0; i < 3; i++ {
+		_, _ = client.GetTags(ctx)
+	}
+	if _, err := client.GetTags(ctx); !errors.Is(err, bento.ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	// It finishes while the single half-open probe is in flight
+	clock.Advance(time.Minute)
+	probeErr := gated("probe")
+	close(gates["closed"])
+	if err := <-closedErr; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Its success neither closes the circuit nor frees the probe slot
+	if _, err := client.GetTags(ctx); !errors.Is(err, bento.ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen while the probe is in flight, got %v", err)
+	}
+
+	close(gates["probe"])
+	if err := <-probeErr; err != nil {
+		t.Fatalf("expected probe to succeed, got %v", err)
+	}
+	if _, err := client.GetTags(ctx, bento.WithRequestHeader("X-Gate", "probe")); err != nil {
+		t.Errorf("expected closed circuit after the probe, got %v", err)
+	}
+}
@@ -7,10 +7,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/mail"
+	"strconv"
 )
 
 // GetBroadcasts retrieves all broadcasts
-func (c *Client) GetBroadcasts(ctx context.Context) ([]BroadcastData, error) {
+func (c *Client) GetBroadcasts(ctx context.Context, opts ...RequestOption) ([]BroadcastData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
 		fmt.Sprintf("%s/fetch/broadcasts", c.baseURL), nil)
 	if err != nil {
@@ -24,13 +30,13 @@ func (c *Client) GetBroadcasts(ctx context.Context) ([]BroadcastData, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result struct {
 		Broadcasts []BroadcastData `json:"broadcasts"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.decodeJSON(req, resp, &result, "broadcasts"); err != nil {
 		return nil, err
 	}
 
@@ -38,28 +44,19 @@ func (c *Client) GetBroadcasts(ctx context.Context) ([]BroadcastData, error) {
 }
 
 // CreateBroadcast creates a new broadcast
-func (c *Client) CreateBroadcast(ctx context.Context, broadcasts []BroadcastData) error {
+func (c *Client) CreateBroadcast(ctx context.Context, broadcasts []BroadcastData, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
 	if len(broadcasts) == 0 {
 		return ErrInvalidRequest
 	}
 
 	// Validate broadcasts before sending
-	for _, broadcast := range broadcasts {
-		if broadcast.Name == "" {
-			return fmt.Errorf("%w: broadcast name is required", ErrInvalidRequest)
-		}
-		if broadcast.Subject == "" {
-			return fmt.Errorf("%w: broadcast subject is required", ErrInvalidRequest)
-		}
-		if broadcast.Content == "" {
-			return fmt.Errorf("%w: broadcast content is required", ErrInvalidRequest)
-		}
-		if _, err := mail.ParseAddress(broadcast.From.Email); err != nil {
-			return fmt.Errorf("%w: %s", ErrInvalidEmail, broadcast.From.Email)
-		}
-		if broadcast.BatchSizePerHour <= 0 {
-			return fmt.Errorf("%w: batch size must be positive", ErrInvalidBatchSize)
-		}
+	if err := validateEntries(broadcasts, validateBroadcast); err != nil {
+		return err
 	}
 
 	body, err := json.Marshal(map[string]interface{}{
@@ -82,8 +79,134 @@ func (c *Client) CreateBroadcast(ctx context.Context, broadcasts []BroadcastData
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return c.statusError(req, resp)
+	}
+
+	return nil
+}
+
+// GetBroadcast retrieves a single broadcast by ID
+func (c *Client) GetBroadcast(ctx context.Context, id string, opts ...RequestOption) (*BroadcastData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("%w: broadcast ID is required", ErrInvalidRequest)
+	}
+
+	broadcasts, err := c.GetBroadcasts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range broadcasts {
+		if broadcasts[i].ID == id {
+			return &broadcasts[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrBroadcastNotFound, id)
+}
+
+// CloneBroadcast returns a copy of an existing broadcast, ready to be passed to
+// CreateBroadcast. The ID and status of the source are cleared and every
+// non-zero field of overrides replaces the source value; zero values (empty
+// strings, a zero batch size, a nil SendAt) keep the source value, so a field
+// cannot be cleared through overrides.
+func (c *Client) CloneBroadcast(ctx context.Context, id string, overrides BroadcastData, opts ...RequestOption) (BroadcastData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return BroadcastData{}, err
+	}
+
+	source, err := c.GetBroadcast(ctx, id)
+	if err != nil {
+		return BroadcastData{}, err
+	}
+
+	clone := *source
+	clone.ID = ""
+	clone.Status = ""
+	mergeBroadcast(&clone, overrides)
+
+	return clone, nil
+}
+
+// CloneAndCreateBroadcast clones a broadcast like CloneBroadcast and creates the result
+func (c *Client) CloneAndCreateBroadcast(ctx context.Context, id string, overrides BroadcastData, opts ...RequestOption) (BroadcastData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return BroadcastData{}, err
+	}
+
+	clone, err := c.CloneBroadcast(ctx, id, overrides)
+	if err != nil {
+		return BroadcastData{}, err
+	}
+
+	if err := c.CreateBroadcast(ctx, []BroadcastData{clone}); err != nil {
+		return clone, err
+	}
+
+	return clone, nil
+}
+
+// mergeBroadcast copies every non-zero field of overrides onto dst
+func mergeBroadcast(dst *BroadcastData, overrides BroadcastData) {
+	if overrides.Name != "" {
+		dst.Name = overrides.Name
 	}
+	if overrides.Subject != "" {
+		dst.Subject = overrides.Subject
+	}
+	if overrides.Content != "" {
+		dst.Content = overrides.Content
+	}
+	if overrides.Type != "" {
+		dst.Type = overrides.Type
+	}
+	if overrides.From.Name != "" {
+		dst.From.Name = overrides.From.Name
+	}
+	if overrides.From.Email != "" {
+		dst.From.Email = overrides.From.Email
+	}
+	if overrides.InclusiveTags != "" {
+		dst.InclusiveTags = overrides.InclusiveTags
+	}
+	if overrides.ExclusiveTags != "" {
+		dst.ExclusiveTags = overrides.ExclusiveTags
+	}
+	if overrides.SegmentID != "" {
+		dst.SegmentID = overrides.SegmentID
+	}
+	if overrides.BatchSizePerHour != 0 {
+		dst.BatchSizePerHour = overrides.BatchSizePerHour
+	}
+	if overrides.SendAt != nil {
+		sendAt := *overrides.SendAt
+		dst.SendAt = &sendAt
+	}
+}
 
+// validateBroadcast checks a single broadcast before it is created
+func validateBroadcast(broadcast BroadcastData) error {
+	if broadcast.Name == "" {
+		return invalidField(ErrInvalidRequest, "name", "", "broadcast name is required")
+	}
+	if broadcast.Subject == "" {
+		return invalidField(ErrInvalidRequest, "subject", "", "broadcast subject is required")
+	}
+	if broadcast.Content == "" {
+		return invalidField(ErrInvalidRequest, "content", "", "broadcast content is required")
+	}
+	if _, err := mail.ParseAddress(broadcast.From.Email); err != nil {
+		return invalidField(ErrInvalidEmail, "from", broadcast.From.Email, "invalid sender email")
+	}
+	if broadcast.BatchSizePerHour <= 0 {
+		return invalidField(ErrInvalidBatchSize, "batch_size_per_hour", strconv.Itoa(broadcast.BatchSizePerHour), "batch size must be positive")
+	}
 	return nil
 }
@@ -3,10 +3,13 @@ package bento_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	bento "github.com/bentonow/bento-golang-sdk"
 )
@@ -369,3 +372,123 @@ func TestBroadcastContextCancellation(t *testing.T) {
 		t.Errorf("expected context.Canceled error, got %v", err)
 	}
 }
+
+// cloneBroadcastHandler serves a single stored broadcast and records created ones
+type cloneBroadcastHandler struct {
+	created []bento.BroadcastData
+}
+
+func (h *cloneBroadcastHandler) handle(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost {
+		var body struct {
+			Broadcasts []bento.BroadcastData `json:"broadcasts"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		h.created = append(h.created, body.Broadcasts...)
+		return mockResponse(http.StatusCreated, nil), nil
+	}
+
+	lastWeek := time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+	return mockResponse(http.StatusOK, map[string]interface{}{
+		"broadcasts": []bento.BroadcastData{
+			{
+				ID:               "bc_weekly_19",
+				Status:           "sent",
+				Name:             "Weekly #19",
+				Subject:          "This week at Acme",
+				Content:          "<p>Last week's news</p>",
+				Type:             bento.BroadcastTypePlain,
+				From:             bento.ContactData{Name: "Acme", Email: "news@acme.com"},
+				InclusiveTags:    "newsletter",
+				ExclusiveTags:    "churned",
+				SegmentID:        "seg_active",
+				BatchSizePerHour: 1000,
+				SendAt:           &lastWeek,
+			},
+		},
+	}), nil
+}
+
+func TestCloneBroadcast(t *testing.T) {
+	handler := &cloneBroadcastHandler{}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	nextWeek := time.Date(2024, 5, 13, 9, 0, 0, 0, time.UTC)
+	clone, err := client.CloneBroadcast(context.Background(), "bc_weekly_19", bento.BroadcastData{
+		Name:    "Weekly #20",
+		Content: "<p>This week's news</p>",
+		SendAt:  &nextWeek,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := bento.BroadcastData{
+		Name:             "Weekly #20",
+		Subject:          "This week at Acme",
+		Content:          "<p>This week's news</p>",
+		Type:             bento.BroadcastTypePlain,
+		From:             bento.ContactData{Name: "Acme", Email: "news@acme.com"},
+		InclusiveTags:    "newsletter",
+		ExclusiveTags:    "churned",
+		SegmentID:        "seg_active",
+		BatchSizePerHour: 1000,
+		SendAt:           &nextWeek,
+	}
+	if !reflect.DeepEqual(clone, want) {
+		t.Errorf("unexpected clone:\n got %+v\nwant %+v", clone, want)
+	}
+	if len(handler.created) != 0 {
+		t.Errorf("expected CloneBroadcast not to create anything, got %d", len(handler.created))
+	}
+}
+
+func TestCloneBroadcastNotFound(t *testing.T) {
+	handler := &cloneBroadcastHandler{}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, err = client.CloneBroadcast(context.Background(), "bc_missing", bento.BroadcastData{})
+	if !errors.Is(err, bento.ErrBroadcastNotFound) {
+		t.Errorf("expected ErrBroadcastNotFound, got %v", err)
+	}
+	if bento.CodeOf(err) != bento.CodeNotFound {
+		t.Errorf("expected CodeNotFound, got %s", bento.CodeOf(err))
+	}
+}
+
+func TestCloneAndCreateBroadcast(t *testing.T) {
+	handler := &cloneBroadcastHandler{}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	clone, err := client.CloneAndCreateBroadcast(context.Background(), "bc_weekly_19", bento.BroadcastData{
+		Subject: "Next week at Acme",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(handler.created) != 1 {
+		t.Fatalf("expected 1 created broadcast, got %d", len(handler.created))
+	}
+	created := handler.created[0]
+	if created.ID != "" || created.Status != "" {
+		t.Errorf("expected identifiers to be cleared, got id=%q status=%q", created.ID, created.Status)
+	}
+	if created.Subject != "Next week at Acme" || created.Name != "Weekly #19" {
+		t.Errorf("unexpected created broadcast: %+v", created)
+	}
+	if clone.Subject != created.Subject {
+		t.Errorf("expected returned clone to match the created broadcast")
+	}
+}
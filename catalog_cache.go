@@ -0,0 +1,57 @@
+package bento
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultCatalogCacheTTL is how long the known field keys and tag names are trusted
+const defaultCatalogCacheTTL = 5 * time.Minute
+
+// catalogCache remembers which field keys or tag names exist on the site so
+// Ensure* helpers do not refetch the full list for every call
+type catalogCache struct {
+	mu       sync.Mutex
+	names    map[string]struct{}
+	loadedAt time.Time
+	loaded   bool
+}
+
+// replace stores the complete list of names returned by the API
+func (cc *catalogCache) replace(names []string, now time.Time) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.names = make(map[string]struct{}, len(names))
+	for _, name := range names {
+		cc.names[name] = struct{}{}
+	}
+	cc.loadedAt = now
+	cc.loaded = true
+}
+
+// add records a single name, e.g. after it was created
+func (cc *catalogCache) add(name string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.names == nil {
+		cc.names = make(map[string]struct{})
+	}
+	cc.names[name] = struct{}{}
+}
+
+// lookup reports whether name is known, and whether the cache is fresh enough to trust a miss
+func (cc *catalogCache) lookup(name string, now time.Time, ttl time.Duration) (found, fresh bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	_, found = cc.names[name]
+	fresh = cc.loaded && now.Sub(cc.loadedAt) < ttl
+	return found, fresh
+}
+
+// catalogTTL returns the configured catalog cache TTL
+func (c *Client) catalogTTL() time.Duration {
+	if c.config.CatalogCacheTTL > 0 {
+		return c.config.CatalogCacheTTL
+	}
+	return defaultCatalogCacheTTL
+}
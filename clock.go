@@ -0,0 +1,24 @@
+package bento
+
+import "time"
+
+// Clock abstracts time so background helpers can be driven by a fake clock in tests
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the default Clock backed by the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// clock returns the configured Clock or the real clock when none is set
+func (c *Client) clock() Clock {
+	if c.config.Clock != nil {
+		return c.config.Clock
+	}
+	return realClock{}
+}
@@ -4,28 +4,28 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/mail"
+	"strings"
 )
 
 // SubscriberCommand executes a command on a subscriber
-func (c *Client) SubscriberCommand(ctx context.Context, commands []CommandData) error {
+func (c *Client) SubscriberCommand(ctx context.Context, commands []CommandData, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
 	if len(commands) == 0 {
 		return ErrInvalidRequest
 	}
 
 	// Validate all commands before sending
-	for _, cmd := range commands {
-		if _, err := mail.ParseAddress(cmd.Email); err != nil {
-			return fmt.Errorf("%w: %s", ErrInvalidEmail, cmd.Email)
-		}
-		if cmd.Query == "" {
-			return fmt.Errorf("%w: command query is required", ErrInvalidRequest)
-		}
-		if err := validateCommandType(cmd.Command); err != nil {
-			return err
-		}
+	commands = c.normalizeCommands(commands)
+	if err := validateEntries(commands, validateCommand); err != nil {
+		return err
 	}
 
 	body, err := json.Marshal(map[string]interface{}{
@@ -48,25 +48,47 @@ func (c *Client) SubscriberCommand(ctx context.Context, commands []CommandData)
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return c.statusError(req, resp)
 	}
 
 	var result struct {
 		Results int `json:"results"`
 		Failed  int `json:"failed"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.decodeJSON(req, resp, &result, "results"); err != nil {
 		return err
 	}
 
 	if result.Failed > 0 {
-		return fmt.Errorf("command execution partially failed: %d succeeded, %d failed",
-			result.Results, result.Failed)
+		return &PartialFailureError{Operation: "command execution", Succeeded: result.Results, Failed: result.Failed}
 	}
 
 	return nil
 }
 
+// validateCommand checks a single command before it is sent
+func validateCommand(cmd CommandData) error {
+	if _, err := mail.ParseAddress(cmd.Email); err != nil {
+		return invalidField(ErrInvalidEmail, "email", cmd.Email, "invalid email")
+	}
+	if cmd.Query == "" {
+		return invalidField(ErrInvalidRequest, "query", "", "command query is required")
+	}
+	if cmd.Command == CommandChangeEmail {
+		if _, err := mail.ParseAddress(cmd.Query); err != nil {
+			return invalidField(ErrInvalidEmail, "query", cmd.Query, "invalid new email")
+		}
+	}
+	if cmd.Command == CommandChangeState {
+		switch SubscriberState(cmd.Query) {
+		case SubscriberActive, SubscriberPaused:
+		default:
+			return invalidField(ErrInvalidRequest, "query", cmd.Query, "unknown subscriber state")
+		}
+	}
+	return validateCommandType(cmd.Command)
+}
+
 // validateCommandType ensures the command type is valid
 func validateCommandType(cmd CommandType) error {
 	valid := map[CommandType]bool{
@@ -78,10 +100,259 @@ func validateCommandType(cmd CommandType) error {
 		CommandSubscribe:      true,
 		CommandUnsubscribe:    true,
 		CommandChangeEmail:    true,
+		CommandChangeState:    true,
 	}
 
 	if !valid[cmd] {
-		return fmt.Errorf("%w: invalid command type: %s", ErrInvalidRequest, cmd)
+		return invalidField(ErrInvalidRequest, "command", string(cmd), "invalid command type")
 	}
 	return nil
 }
+
+// SequentialOptions controls ExecuteCommandsSequential
+type SequentialOptions struct {
+	// GroupSize is the number of consecutive commands sent per request.
+	// Defaults to 1, so every command is acknowledged before the next is sent.
+	GroupSize int
+
+	// ContinueOnError keeps sending the remaining groups after a failure
+	// instead of stopping at the first one
+	ContinueOnError bool
+}
+
+// CommandResult reports how far ExecuteCommandsSequential got
+type CommandResult struct {
+	// Completed is the number of commands, from the start of the slice, that
+	// were submitted before execution stopped
+	Completed int
+	// Succeeded is the number of commands in groups that were accepted
+	Succeeded int
+	// Failures lists every failed group, in order
+	Failures []*CommandError
+}
+
+// CommandError describes a failed group of commands in a sequential execution
+type CommandError struct {
+	// Index is the position in the input slice of the first command of the group
+	Index    int
+	Commands []CommandData
+	Err      error
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("command %d (%s %s): %v", e.Index, e.Commands[0].Command, e.Commands[0].Email, e.Err)
+}
+
+func (e *CommandError) Unwrap() error { return e.Err }
+
+// ExecuteCommandsSequential submits commands one group at a time in slice
+// order, waiting for each request to complete before sending the next, for
+// changes that depend on each other such as change_email followed by tagging
+// the new address. All commands are validated before anything is sent.
+//
+// By default it stops at the first failed group and returns that group's
+// *CommandError; result.Completed tells how many commands were submitted. With
+// ContinueOnError every group is attempted and the failures are joined.
+func (c *Client) ExecuteCommandsSequential(ctx context.Context, cmds []CommandData, opts *SequentialOptions, reqOpts ...RequestOption) (*CommandResult, error) {
+	ctx = withRequestOptions(ctx, reqOpts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(cmds) == 0 {
+		return nil, ErrInvalidRequest
+	}
+	if opts == nil {
+		opts = &SequentialOptions{}
+	}
+
+	cmds = c.normalizeCommands(cmds)
+	if err := validateEntries(cmds, validateCommand); err != nil {
+		return nil, err
+	}
+
+	groupSize := opts.GroupSize
+	if groupSize <= 0 {
+		groupSize = 1
+	}
+
+	result := &CommandResult{}
+	for start := 0; start < len(cmds); start += groupSize {
+		end := start + groupSize
+		if end > len(cmds) {
+			end = len(cmds)
+		}
+		group := cmds[start:end]
+
+		err := c.SubscriberCommand(ctx, group)
+		result.Completed = end
+		if err == nil {
+			result.Succeeded += len(group)
+			continue
+		}
+
+		cmdErr := &CommandError{Index: start, Commands: group, Err: err}
+		result.Failures = append(result.Failures, cmdErr)
+		if !opts.ContinueOnError || ctx.Err() != nil {
+			return result, cmdErr
+		}
+	}
+
+	if len(result.Failures) > 0 {
+		errs := make([]error, len(result.Failures))
+		for i, failure := range result.Failures {
+			errs[i] = failure
+		}
+		return result, errors.Join(errs...)
+	}
+
+	return result, nil
+}
+
+// ChangeSubscriberEmail moves the subscriber with oldEmail to newEmail. Both
+// addresses are validated and must differ. An unknown subscriber fails with
+// ErrSubscriberNotFound, and a change the API rejects, for example because
+// newEmail belongs to another subscriber, with ErrAPIResponse.
+func (c *Client) ChangeSubscriberEmail(ctx context.Context, oldEmail, newEmail string, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	oldEmail, newEmail = c.normalizeEmail(oldEmail), c.normalizeEmail(newEmail)
+	if _, err := mail.ParseAddress(oldEmail); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidEmail, oldEmail)
+	}
+	if _, err := mail.ParseAddress(newEmail); err != nil {
+		return fmt.Errorf("%w: new email %s", ErrInvalidEmail, newEmail)
+	}
+	if oldEmail == newEmail {
+		return fmt.Errorf("%w: new email is the same as the current one", ErrInvalidRequest)
+	}
+
+	err := c.SubscriberCommand(ctx, []CommandData{{Command: CommandChangeEmail, Email: oldEmail, Query: newEmail}})
+	var partial *PartialFailureError
+	switch {
+	case errors.As(err, &partial):
+		return withCode(CodeAPIError, fmt.Errorf("%w: changing email from %s to %s was rejected", ErrAPIResponse, oldEmail, newEmail))
+	case errors.Is(err, ErrNotFound):
+		return fmt.Errorf("%w: %s: %w", ErrSubscriberNotFound, oldEmail, err)
+	}
+	return err
+}
+
+// PauseSubscriber stops broadcasts to the subscriber with the given email
+// without unsubscribing them, so transactional emails are still delivered.
+// Pausing a subscriber that is already paused returns nil.
+func (c *Client) PauseSubscriber(ctx context.Context, email string, opts ...RequestOption) error {
+	return c.changeSubscriberState(ctx, email, SubscriberPaused, opts)
+}
+
+// ResumeSubscriber restarts broadcasts to a subscriber paused with
+// PauseSubscriber. Resuming an active subscriber returns nil.
+func (c *Client) ResumeSubscriber(ctx context.Context, email string, opts ...RequestOption) error {
+	return c.changeSubscriberState(ctx, email, SubscriberActive, opts)
+}
+
+// changeSubscriberState sends a change_state command, treating the conflict
+// the API reports for a subscriber already in that state as success
+func (c *Client) changeSubscriberState(ctx context.Context, email string, state SubscriberState, opts []RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	email = c.normalizeEmail(email)
+	if _, err := mail.ParseAddress(email); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidEmail, email)
+	}
+
+	err := c.SubscriberCommand(ctx, []CommandData{{Command: CommandChangeState, Email: email, Query: string(state)}})
+	if status, ok := HTTPStatus(err); ok && status == http.StatusConflict {
+		return nil
+	}
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("%w: %s: %w", ErrSubscriberNotFound, email, err)
+	}
+	return err
+}
+
+// AddTagsToSubscriber adds tags to the subscriber with the given email in a
+// single request. Blank tag names are skipped and duplicates, ignoring case,
+// are sent once. When the API rejects some of the tags, it returns a
+// *PartialFailureError listing the tags that were sent in Items.
+func (c *Client) AddTagsToSubscriber(ctx context.Context, email string, tags []string, opts ...RequestOption) error {
+	return c.tagCommands(ctx, CommandAddTag, "adding tags", email, tags, opts)
+}
+
+// RemoveTagsFromSubscriber removes tags from the subscriber with the given
+// email in a single request, in the same way as AddTagsToSubscriber
+func (c *Client) RemoveTagsFromSubscriber(ctx context.Context, email string, tags []string, opts ...RequestOption) error {
+	return c.tagCommands(ctx, CommandRemoveTag, "removing tags", email, tags, opts)
+}
+
+// tagCommands sends one command of the given type per distinct tag,
+// skipping blank names
+func (c *Client) tagCommands(ctx context.Context, command CommandType, operation, email string, tags []string, opts []RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	var names []string
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) != "" {
+			names = append(names, tag)
+		}
+	}
+	return c.expandCommand(ctx, command, operation, email, "tags", dedupeTags(names))
+}
+
+// RemoveFieldsFromSubscriber clears the custom fields with the given keys
+// from the subscriber with the given email in a single request. Field keys
+// are case-sensitive, so only exact duplicates are sent once, and blank keys
+// are rejected. When the API rejects some of the removals, it returns a
+// *PartialFailureError listing the keys that were sent in Items.
+func (c *Client) RemoveFieldsFromSubscriber(ctx context.Context, email string, keys []string, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	if err := validateEntries(keys, func(key string) error {
+		if strings.TrimSpace(key) == "" {
+			return invalidField(ErrInvalidRequest, "key", key, "field key is required")
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return c.expandCommand(ctx, CommandRemoveField, "removing fields", email, "field keys", keys)
+}
+
+// expandCommand sends one command of the given type per distinct query and
+// names the batch operation and its queries in any *PartialFailureError
+func (c *Client) expandCommand(ctx context.Context, command CommandType, operation, email, what string, queries []string) error {
+	seen := make(map[string]struct{}, len(queries))
+	var commands []CommandData
+	var sent []string
+	for _, query := range queries {
+		if _, ok := seen[query]; ok {
+			continue
+		}
+		seen[query] = struct{}{}
+		commands = append(commands, CommandData{Command: command, Email: email, Query: query})
+		sent = append(sent, query)
+	}
+	if len(commands) == 0 {
+		return fmt.Errorf("%w: no %s provided", ErrInvalidRequest, what)
+	}
+
+	err := c.SubscriberCommand(ctx, commands)
+	var partial *PartialFailureError
+	if errors.As(err, &partial) {
+		partial.Operation = operation
+		partial.Items = sent
+	}
+	return err
+}
@@ -3,8 +3,10 @@ package bento_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -188,6 +190,11 @@ func TestValidateCommandType(t *testing.T) {
 			commandType: bento.CommandChangeEmail,
 			expectError: false,
 		},
+		{
+			name:        "valid change state command",
+			commandType: bento.CommandChangeState,
+			expectError: false,
+		},
 		{
 			name:        "invalid command type",
 			commandType: "invalid_command",
@@ -213,6 +220,14 @@ func TestValidateCommandType(t *testing.T) {
 				Email:   "test@example.com",
 				Query:   "test-query",
 			}
+			// change_email takes the new address as its query
+			if tt.commandType == bento.CommandChangeEmail {
+				cmd.Query = "new@example.com"
+			}
+			// change_state takes a subscriber state as its query
+			if tt.commandType == bento.CommandChangeState {
+				cmd.Query = string(bento.SubscriberPaused)
+			}
 
 			err = client.SubscriberCommand(context.Background(), []bento.CommandData{cmd})
 
@@ -231,3 +246,526 @@ func TestValidateCommandType(t *testing.T) {
 		})
 	}
 }
+
+// sequentialCommandHandler records each command request and fails the ones whose email is listed
+type sequentialCommandHandler struct {
+	failEmails map[string]bool
+	requests   [][]bento.CommandData
+}
+
+func (h *sequentialCommandHandler) handle(req *http.Request) (*http.Response, error) {
+	var body struct {
+		Command []bento.CommandData `json:"command"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	h.requests = append(h.requests, body.Command)
+
+	for _, cmd := range body.Command {
+		if h.failEmails[cmd.Email] {
+			return mockResponse(http.StatusInternalServerError, nil), nil
+		}
+	}
+	return mockResponse(http.StatusOK, map[string]interface{}{"results": len(body.Command), "failed": 0}), nil
+}
+
+func sequentialCommands() []bento.CommandData {
+	return []bento.CommandData{
+		{Command: bento.CommandChangeEmail, Email: "old@example.com", Query: "new@example.com"},
+		{Command: bento.CommandAddTag, Email: "new@example.com", Query: "migrated"},
+		{Command: bento.CommandAddField, Email: "bad@example.com", Query: "plan"},
+		{Command: bento.CommandAddTag, Email: "other@example.com", Query: "vip"},
+	}
+}
+
+func TestExecuteCommandsSequentialOrdering(t *testing.T) {
+	handler := &sequentialCommandHandler{}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	cmds := sequentialCommands()
+	result, err := client.ExecuteCommandsSequential(context.Background(), cmds, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(handler.requests) != len(cmds) {
+		t.Fatalf("expected %d requests, got %d", len(cmds), len(handler.requests))
+	}
+	for i, sent := range handler.requests {
+		if len(sent) != 1 || sent[0] != cmds[i] {
+			t.Errorf("request %d: expected %+v, got %+v", i, cmds[i], sent)
+		}
+	}
+	if result.Completed != 4 || result.Succeeded != 4 || len(result.Failures) != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestExecuteCommandsSequentialGroups(t *testing.T) {
+	handler := &sequentialCommandHandler{}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	cmds := append(sequentialCommands(), bento.CommandData{Command: bento.CommandRemoveTag, Email: "other@example.com", Query: "trial"})
+	if _, err := client.ExecuteCommandsSequential(context.Background(), cmds, &bento.SequentialOptions{GroupSize: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sizes := make([]int, len(handler.requests))
+	for i, sent := range handler.requests {
+		sizes[i] = len(sent)
+	}
+	if !reflect.DeepEqual(sizes, []int{2, 2, 1}) {
+		t.Errorf("expected group sizes [2 2 1], got %v", sizes)
+	}
+}
+
+func TestExecuteCommandsSequentialStopOnError(t *testing.T) {
+	handler := &sequentialCommandHandler{failEmails: map[string]bool{"bad@example.com": true}}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	result, err := client.ExecuteCommandsSequential(context.Background(), sequentialCommands(), nil)
+
+	var cmdErr *bento.CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("expected CommandError, got %v", err)
+	}
+	if cmdErr.Index != 2 {
+		t.Errorf("expected failure at index 2, got %d", cmdErr.Index)
+	}
+	if !errors.Is(err, bento.ErrAPIResponse) {
+		t.Errorf("expected error to wrap ErrAPIResponse, got %v", err)
+	}
+	if result.Completed != 3 || result.Succeeded != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(handler.requests) != 3 {
+		t.Errorf("expected execution to stop after 3 requests, got %d", len(handler.requests))
+	}
+}
+
+func TestExecuteCommandsSequentialContinueOnError(t *testing.T) {
+	handler := &sequentialCommandHandler{failEmails: map[string]bool{
+		"old@example.com": true,
+		"bad@example.com": true,
+	}}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	result, err := client.ExecuteCommandsSequential(context.Background(), sequentialCommands(), &bento.SequentialOptions{
+		ContinueOnError: true,
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if result.Completed != 4 || result.Succeeded != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(result.Failures) != 2 || result.Failures[0].Index != 0 || result.Failures[1].Index != 2 {
+		t.Errorf("unexpected failures: %+v", result.Failures)
+	}
+	if len(handler.requests) != 4 {
+		t.Errorf("expected all 4 commands to be sent, got %d", len(handler.requests))
+	}
+}
+
+func TestExecuteCommandsSequentialValidation(t *testing.T) {
+	handler := &sequentialCommandHandler{}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	cmds := sequentialCommands()
+	cmds[3].Email = "not-an-email"
+
+	if _, err := client.ExecuteCommandsSequential(context.Background(), cmds, nil); !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Errorf("expected ErrInvalidEmail, got %v", err)
+	}
+	if len(handler.requests) != 0 {
+		t.Errorf("expected no requests before validation passes, got %d", len(handler.requests))
+	}
+}
+
+func TestTagHelpers(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    func(*bento.Client, []string) error
+		command bento.CommandType
+	}{
+		{
+			name: "add",
+			call: func(c *bento.Client, tags []string) error {
+				return c.AddTagsToSubscriber(context.Background(), "test@example.com", tags)
+			},
+			command: bento.CommandAddTag,
+		},
+		{
+			name: "remove",
+			call: func(c *bento.Client, tags []string) error {
+				return c.RemoveTagsFromSubscriber(context.Background(), "test@example.com", tags)
+			},
+			command: bento.CommandRemoveTag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &sequentialCommandHandler{}
+			client, err := setupTestClient(handler.handle)
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			if err := tt.call(client, []string{"customer", "", "vip", "customer", "  ", "beta"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := []bento.CommandData{
+				{Command: tt.command, Email: "test@example.com", Query: "customer"},
+				{Command: tt.command, Email: "test@example.com", Query: "vip"},
+				{Command: tt.command, Email: "test@example.com", Query: "beta"},
+			}
+			if len(handler.requests) != 1 || !reflect.DeepEqual(handler.requests[0], want) {
+				t.Errorf("expected one request with %+v, got %+v", want, handler.requests)
+			}
+		})
+	}
+}
+
+func TestTagHelpersValidation(t *testing.T) {
+	handler := &sequentialCommandHandler{}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if err := client.AddTagsToSubscriber(context.Background(), "invalid-email", []string{"vip"}); !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Errorf("expected ErrInvalidEmail, got %v", err)
+	}
+	if err := client.RemoveTagsFromSubscriber(context.Background(), "test@example.com", []string{"", " "}); !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+	if len(handler.requests) != 0 {
+		t.Errorf("expected no requests, got %d", len(handler.requests))
+	}
+}
+
+func TestTagHelpersPartialFailure(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 2, "failed": 1}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.AddTagsToSubscriber(context.Background(), "test@example.com", []string{"customer", "vip", "beta"})
+	var partial *bento.PartialFailureError
+	if !errors.As(err, &partial) {
+		t.Fatalf("expected PartialFailureError, got %v", err)
+	}
+	if partial.Operation != "adding tags" || partial.Succeeded != 2 || partial.Failed != 1 {
+		t.Errorf("unexpected partial failure: %+v", partial)
+	}
+}
+
+func TestRemoveFieldsFromSubscriber(t *testing.T) {
+	handler := &sequentialCommandHandler{}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if err := client.RemoveFieldsFromSubscriber(context.Background(), "test@example.com", []string{"plan", "company", "plan"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []bento.CommandData{
+		{Command: bento.CommandRemoveField, Email: "test@example.com", Query: "plan"},
+		{Command: bento.CommandRemoveField, Email: "test@example.com", Query: "company"},
+	}
+	if len(handler.requests) != 1 || !reflect.DeepEqual(handler.requests[0], want) {
+		t.Errorf("expected one request with %+v, got %+v", want, handler.requests)
+	}
+}
+
+func TestRemoveFieldsFromSubscriberValidation(t *testing.T) {
+	handler := &sequentialCommandHandler{}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.RemoveFieldsFromSubscriber(context.Background(), "test@example.com", []string{"plan", " "})
+	var validationErr *bento.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Index != 1 || !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected key 1 to be rejected, got %v", err)
+	}
+	if err := client.RemoveFieldsFromSubscriber(context.Background(), "test@example.com", nil); !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+	if err := client.RemoveFieldsFromSubscriber(context.Background(), "invalid-email", []string{"plan"}); !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Errorf("expected ErrInvalidEmail, got %v", err)
+	}
+	if len(handler.requests) != 0 {
+		t.Errorf("expected no requests, got %d", len(handler.requests))
+	}
+}
+
+func TestRemoveFieldsFromSubscriberPartialFailure(t *testing.T) {
+	// The API rejects removals of company and seats
+	rejected := map[string]bool{"company": true, "seats": true}
+	var requests [][]bento.CommandData
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Command []bento.CommandData `json:"command"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		requests = append(requests, body.Command)
+		failed := 0
+		for _, cmd := range body.Command {
+			if rejected[cmd.Query] {
+				failed++
+			}
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": len(body.Command) - failed, "failed": failed}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.RemoveFieldsFromSubscriber(context.Background(), "test@example.com", []string{"plan", "company", "Plan", "plan", "seats"})
+	var partial *bento.PartialFailureError
+	if !errors.As(err, &partial) {
+		t.Fatalf("expected PartialFailureError, got %v", err)
+	}
+	if partial.Operation != "removing fields" || partial.Succeeded != 2 || partial.Failed != 2 {
+		t.Errorf("unexpected partial failure: %+v", partial)
+	}
+	// Field keys are case-sensitive, so only the repeated plan is dropped
+	want := []string{"plan", "company", "Plan", "seats"}
+	if !reflect.DeepEqual(partial.Items, want) {
+		t.Errorf("expected the keys %v to be reported, got %v", want, partial.Items)
+	}
+	if !strings.Contains(err.Error(), "plan, company, Plan, seats") {
+		t.Errorf("expected the error to list the keys, got %q", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected the failure to be reported without resending, got %d requests", len(requests))
+	}
+	if !errors.Is(err, bento.ErrPartialFailure) {
+		t.Errorf("expected ErrPartialFailure, got %v", err)
+	}
+}
+
+func TestChangeSubscriberEmail(t *testing.T) {
+	var body map[string]interface{}
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/fetch/commands") {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if err := client.ChangeSubscriberEmail(context.Background(), "old@example.com", "new@example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"command": []interface{}{map[string]interface{}{
+			"command": "change_email",
+			"email":   "old@example.com",
+			"query":   "new@example.com",
+		}},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("unexpected request body:\n got %v\nwant %v", body, want)
+	}
+}
+
+func TestChangeSubscriberEmailErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldEmail string
+		newEmail string
+		reply    *http.Response
+		wantErr  error
+	}{
+		{name: "invalid new email", oldEmail: "old@example.com", newEmail: "new.example.com", wantErr: bento.ErrInvalidEmail},
+		{name: "empty new email", oldEmail: "old@example.com", newEmail: "", wantErr: bento.ErrInvalidEmail},
+		{name: "invalid old email", oldEmail: "old", newEmail: "new@example.com", wantErr: bento.ErrInvalidEmail},
+		{name: "identical emails", oldEmail: "same@example.com", newEmail: "same@example.com", wantErr: bento.ErrInvalidRequest},
+		{
+			name:     "rejected",
+			oldEmail: "old@example.com",
+			newEmail: "taken@example.com",
+			reply:    mockResponse(http.StatusOK, map[string]interface{}{"results": 0, "failed": 1}),
+			wantErr:  bento.ErrAPIResponse,
+		},
+		{
+			name:     "unknown subscriber",
+			oldEmail: "old@example.com",
+			newEmail: "new@example.com",
+			reply:    mockResponse(http.StatusNotFound, map[string]string{"error": "Not found"}),
+			wantErr:  bento.ErrSubscriberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				if tt.reply == nil {
+					t.Errorf("unexpected request: %s", req.URL)
+					return mockResponse(http.StatusOK, nil), nil
+				}
+				return tt.reply, nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			if err := client.ChangeSubscriberEmail(context.Background(), tt.oldEmail, tt.newEmail); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSubscriberCommandValidatesNewEmail(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request: %s", req.URL)
+		return mockResponse(http.StatusOK, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.SubscriberCommand(context.Background(), []bento.CommandData{
+		{Command: bento.CommandChangeEmail, Email: "old@example.com", Query: "new@@example.com"},
+	})
+	var validationErr *bento.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "query" || !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Errorf("expected the new email to be rejected, got %v", err)
+	}
+}
+
+func TestPauseAndResumeSubscriber(t *testing.T) {
+	tests := []struct {
+		name  string
+		call  func(*bento.Client) error
+		state string
+		reply *http.Response
+	}{
+		{
+			name:  "pause",
+			call:  func(c *bento.Client) error { return c.PauseSubscriber(context.Background(), "test@example.com") },
+			state: "paused",
+			reply: mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}),
+		},
+		{
+			name:  "resume",
+			call:  func(c *bento.Client) error { return c.ResumeSubscriber(context.Background(), "test@example.com") },
+			state: "active",
+			reply: mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}),
+		},
+		{
+			name:  "already paused",
+			call:  func(c *bento.Client) error { return c.PauseSubscriber(context.Background(), "test@example.com") },
+			state: "paused",
+			reply: mockResponse(http.StatusConflict, map[string]string{"error": "Subscriber is already paused"}),
+		},
+		{
+			name:  "already active",
+			call:  func(c *bento.Client) error { return c.ResumeSubscriber(context.Background(), "test@example.com") },
+			state: "active",
+			reply: mockResponse(http.StatusConflict, map[string]string{"error": "Subscriber is already active"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				if !strings.HasSuffix(req.URL.Path, "/fetch/commands") {
+					t.Errorf("unexpected path: %s", req.URL.Path)
+				}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				return tt.reply, nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			if err := tt.call(client); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := map[string]interface{}{
+				"command": []interface{}{map[string]interface{}{
+					"command": "change_state",
+					"email":   "test@example.com",
+					"query":   tt.state,
+				}},
+			}
+			if !reflect.DeepEqual(body, want) {
+				t.Errorf("unexpected request body:\n got %v\nwant %v", body, want)
+			}
+		})
+	}
+}
+
+func TestPauseSubscriberErrors(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusNotFound, map[string]string{"error": "Not found"}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if err := client.PauseSubscriber(context.Background(), "invalid-email"); !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Errorf("expected ErrInvalidEmail, got %v", err)
+	}
+	if err := client.PauseSubscriber(context.Background(), "missing@example.com"); !errors.Is(err, bento.ErrSubscriberNotFound) {
+		t.Errorf("expected ErrSubscriberNotFound, got %v", err)
+	}
+}
+
+func TestSubscriberCommandRejectsUnknownState(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request: %s", req.URL)
+		return mockResponse(http.StatusOK, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.SubscriberCommand(context.Background(), []bento.CommandData{
+		{Command: bento.CommandChangeState, Email: "test@example.com", Query: "suspended"},
+	})
+	var verr *bento.ValidationError
+	if !errors.As(err, &verr) || verr.Field != "query" || !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected a query validation error, got %v", err)
+	}
+}
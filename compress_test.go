@@ -0,0 +1,113 @@
+package bento_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// readRequestBody returns the request body, gunzipping it when it is compressed
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Header.Get("Content-Encoding") != "gzip" {
+		return io.ReadAll(req.Body)
+	}
+	zr, err := gzip.NewReader(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = zr.Close() }()
+	return io.ReadAll(zr)
+}
+
+func largeImport(n int) []*bento.SubscriberInput {
+	subscribers := make([]*bento.SubscriberInput, n)
+	for i := range subscribers {
+		subscribers[i] = &bento.SubscriberInput{
+			Email:     fmt.Sprintf("user%d@example.com", i),
+			FirstName: "Test",
+			LastName:  "User",
+		}
+	}
+	return subscribers
+}
+
+func TestCompressRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		compress bool
+		count    int
+		wantGzip bool
+	}{
+		{name: "large body is compressed", compress: true, count: 100, wantGzip: true},
+		{name: "small body is sent as is", compress: true, count: 1, wantGzip: false},
+		{name: "disabled", compress: false, count: 100, wantGzip: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.CompressRequests = tt.compress
+			}, func(req *http.Request) (*http.Response, error) {
+				if got := req.Header.Get("Content-Encoding") == "gzip"; got != tt.wantGzip {
+					t.Errorf("expected gzip=%v, got Content-Encoding %q", tt.wantGzip, req.Header.Get("Content-Encoding"))
+				}
+				body, err := readRequestBody(req)
+				if err != nil {
+					t.Fatalf("failed to read body: %v", err)
+				}
+				var payload struct {
+					Subscribers []bento.SubscriberInput `json:"subscribers"`
+				}
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Fatalf("failed to decode body: %v", err)
+				}
+				if len(payload.Subscribers) != tt.count {
+					t.Errorf("expected %d subscribers, got %d", tt.count, len(payload.Subscribers))
+				}
+				return mockResponse(http.StatusOK, map[string]interface{}{"results": tt.count, "failed": 0}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			if err := client.ImportSubscribers(context.Background(), largeImport(tt.count)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCompressRequestsWithRetry(t *testing.T) {
+	var bodies []string
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.CompressRequests = true
+		c.Retry = &bento.RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, RetryNonIdempotent: true}
+	}, func(req *http.Request) (*http.Response, error) {
+		body, err := readRequestBody(req)
+		if err != nil {
+			t.Fatalf("attempt %d: failed to read body: %v", len(bodies)+1, err)
+		}
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			return mockResponse(http.StatusServiceUnavailable, nil), nil
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 100, "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if err := client.ImportSubscribers(context.Background(), largeImport(100)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Errorf("expected the compressed body to be resent unchanged")
+	}
+}
@@ -0,0 +1,196 @@
+package bento
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// utf8BOM is the byte order mark some tools write at the start of a CSV file
+const utf8BOM = "\ufeff"
+
+// CSVOptions controls how ParseSubscribersCSV reads a file
+type CSVOptions struct {
+	// Delimiter separates columns. Defaults to ','.
+	Delimiter rune
+
+	// Headers maps CSV column headers to "email", "first_name", "last_name",
+	// "tags" or a custom field key, for files whose headers differ, such as
+	// {"E-mail Address": "email"}. Headers are matched case-insensitively.
+	// Unmapped columns are read as those names.
+	Headers map[string]string
+
+	// TagSeparator splits the tags column. Defaults to ",".
+	TagSeparator string
+
+	// Import is passed to ImportSubscribersWithOptions by ImportSubscribersCSV
+	Import *ImportOptions
+}
+
+// CSVRowError describes a CSV row that could not be read as a subscriber
+type CSVRowError struct {
+	// Row is the line number of the row in the file, counting the header as 1
+	Row int
+	Err error
+}
+
+func (e *CSVRowError) Error() string { return fmt.Sprintf("row %d: %v", e.Row, e.Err) }
+
+func (e *CSVRowError) Unwrap() error { return e.Err }
+
+// Code classifies the error by its cause
+func (e *CSVRowError) Code() ErrorCode { return CodeOf(e.Err) }
+
+// ParseSubscribersCSV reads subscribers from a CSV file with a header row.
+// The email, first_name, last_name and tags columns fill the matching
+// SubscriberInput fields and every other non-empty column becomes a custom
+// field. A leading byte order mark is ignored.
+//
+// Rows with an invalid email do not stop parsing: the valid subscribers are
+// returned together with a joined *CSVRowError for each rejected row. A file
+// without an email column, or that is not valid CSV, fails with
+// ErrInvalidRequest and no subscribers.
+func ParseSubscribersCSV(r io.Reader, opts CSVOptions) ([]*SubscriberInput, error) {
+	// Spreadsheet exports often start with a UTF-8 byte order mark
+	buffered := bufio.NewReader(r)
+	if bom, err := buffered.Peek(len(utf8BOM)); err == nil && string(bom) == utf8BOM {
+		_, _ = buffered.Discard(len(utf8BOM))
+	}
+
+	reader := csv.NewReader(buffered)
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV header: %v", ErrInvalidRequest, err)
+	}
+	columns := csvColumns(header, opts.Headers)
+
+	emailColumn := -1
+	for i, column := range columns {
+		if column == "email" {
+			emailColumn = i
+		}
+	}
+	if emailColumn < 0 {
+		return nil, fmt.Errorf("%w: CSV has no email column", ErrInvalidRequest)
+	}
+
+	separator := opts.TagSeparator
+	if separator == "" {
+		separator = ","
+	}
+
+	var subscribers []*SubscriberInput
+	var errs []error
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading CSV: %v", ErrInvalidRequest, err)
+		}
+		row, _ := reader.FieldPos(0)
+
+		sub := csvSubscriber(columns, record, separator)
+		if err := validateSubscriberInput(sub); err != nil {
+			errs = append(errs, &CSVRowError{Row: row, Err: err})
+			continue
+		}
+		subscribers = append(subscribers, sub)
+	}
+
+	return subscribers, errors.Join(errs...)
+}
+
+// ImportSubscribersCSV parses a CSV file with ParseSubscribersCSV and imports
+// the valid rows. Rejected rows are reported in the error alongside any
+// import failure, while the result counts the subscribers that were sent.
+func (c *Client) ImportSubscribersCSV(ctx context.Context, r io.Reader, opts CSVOptions, reqOpts ...RequestOption) (*ImportResult, error) {
+	ctx = withRequestOptions(ctx, reqOpts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	subscribers, rowErr := ParseSubscribersCSV(r, opts)
+	if len(subscribers) == 0 {
+		if rowErr == nil {
+			rowErr = fmt.Errorf("%w: CSV has no subscribers", ErrInvalidRequest)
+		}
+		return nil, rowErr
+	}
+
+	result, err := c.ImportSubscribersWithOptions(ctx, subscribers, opts.Import)
+	return result, errors.Join(rowErr, err)
+}
+
+// csvColumns resolves each header to the key its column fills
+func csvColumns(header []string, overrides map[string]string) []string {
+	mapping := make(map[string]string, len(overrides))
+	for from, to := range overrides {
+		mapping[strings.ToLower(strings.TrimSpace(from))] = to
+	}
+
+	columns := make([]string, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if to, ok := mapping[strings.ToLower(name)]; ok {
+			name = to
+		} else if key := strings.ToLower(name); csvKnownColumns[key] {
+			name = key
+		}
+		columns[i] = name
+	}
+	return columns
+}
+
+// csvKnownColumns are the headers that fill SubscriberInput fields
+var csvKnownColumns = map[string]bool{
+	"email":      true,
+	"first_name": true,
+	"last_name":  true,
+	"tags":       true,
+}
+
+// csvSubscriber builds a subscriber from one CSV record
+func csvSubscriber(columns, record []string, tagSeparator string) *SubscriberInput {
+	sub := &SubscriberInput{}
+	for i, value := range record {
+		if i >= len(columns) || columns[i] == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch columns[i] {
+		case "email":
+			sub.Email = value
+		case "first_name":
+			sub.FirstName = value
+		case "last_name":
+			sub.LastName = value
+		case "tags":
+			for _, tag := range strings.Split(value, tagSeparator) {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					sub.Tags = append(sub.Tags, tag)
+				}
+			}
+		default:
+			if value == "" {
+				continue
+			}
+			if sub.Fields == nil {
+				sub.Fields = make(map[string]interface{})
+			}
+			sub.Fields[columns[i]] = value
+		}
+	}
+	return sub
+}
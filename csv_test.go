@@ -0,0 +1,172 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestParseSubscribersCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		opts    bento.CSVOptions
+		want    []*bento.SubscriberInput
+		wantErr error
+	}{
+		{
+			name: "quoted fields",
+			csv: "email,first_name,last_name,tags,company\n" +
+				`jesse@example.com,Jesse,"Pinkman, Jr.","customer, vip","Says ""yo"""` + "\n" +
+				"walter@example.com,Walter,White,,\n",
+			want: []*bento.SubscriberInput{
+				{
+					Email:     "jesse@example.com",
+					FirstName: "Jesse",
+					LastName:  "Pinkman, Jr.",
+					Tags:      []string{"customer", "vip"},
+					Fields:    map[string]interface{}{"company": `Says "yo"`},
+				},
+				{Email: "walter@example.com", FirstName: "Walter", LastName: "White"},
+			},
+		},
+		{
+			name: "byte order mark",
+			csv:  "\ufeff\"Email\",\"Plan\"\r\njesse@example.com,pro\r\n",
+			want: []*bento.SubscriberInput{
+				{Email: "jesse@example.com", Fields: map[string]interface{}{"Plan": "pro"}},
+			},
+		},
+		{
+			name: "delimiter, header mapping and tag separator",
+			csv:  "E-mail Address;Given Name;Labels;Plan\njesse@example.com;Jesse;a|b;pro\n",
+			opts: bento.CSVOptions{
+				Delimiter:    ';',
+				Headers:      map[string]string{"e-mail address": "email", "Given Name": "first_name", "LABELS": "tags", "Plan": "plan_name"},
+				TagSeparator: "|",
+			},
+			want: []*bento.SubscriberInput{
+				{Email: "jesse@example.com", FirstName: "Jesse", Tags: []string{"a", "b"}, Fields: map[string]interface{}{"plan_name": "pro"}},
+			},
+		},
+		{
+			name:    "missing email column",
+			csv:     "name,company\nJesse,Vamonos Pest\n",
+			wantErr: bento.ErrInvalidRequest,
+		},
+		{
+			name:    "empty file",
+			csv:     "",
+			wantErr: bento.ErrInvalidRequest,
+		},
+		{
+			name:    "malformed csv",
+			csv:     "email,notes\njesse@example.com,\"unterminated\n",
+			wantErr: bento.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscribers, err := bento.ParseSubscribersCSV(strings.NewReader(tt.csv), tt.opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+				if subscribers != nil {
+					t.Errorf("expected no subscribers, got %v", subscribers)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(subscribers, tt.want) {
+				got, _ := json.Marshal(subscribers)
+				want, _ := json.Marshal(tt.want)
+				t.Errorf("expected %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestParseSubscribersCSVInvalidRows(t *testing.T) {
+	csv := "email,first_name\n" +
+		"jesse@example.com,Jesse\n" +
+		"not-an-email,Walter\n" +
+		"\"multi\nline\",Skyler\n" +
+		"hank@example.com,Hank\n" +
+		",Marie\n"
+
+	subscribers, err := bento.ParseSubscribersCSV(strings.NewReader(csv), bento.CSVOptions{})
+	if len(subscribers) != 2 || subscribers[0].Email != "jesse@example.com" || subscribers[1].Email != "hank@example.com" {
+		t.Errorf("expected the two valid rows, got %v", subscribers)
+	}
+
+	var rows []int
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var rowErr *bento.CSVRowError
+		if !errors.As(e, &rowErr) {
+			t.Fatalf("expected CSVRowError, got %v", e)
+		}
+		rows = append(rows, rowErr.Row)
+	}
+	if want := []int{3, 4, 7}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("expected rows %v to be rejected, got %v", want, rows)
+	}
+	if !errors.Is(err, bento.ErrInvalidEmail) || bento.CodeOf(err) != bento.CodeInvalidEmail {
+		t.Errorf("expected ErrInvalidEmail, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "row 3: ") {
+		t.Errorf("expected the row number in the message, got %v", err)
+	}
+}
+
+func TestImportSubscribersCSV(t *testing.T) {
+	var file strings.Builder
+	file.WriteString("email,first_name,plan\n")
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&file, "user%d@example.com,User %d,pro\n", i, i)
+	}
+	file.WriteString("broken,Nobody,free\n")
+
+	var chunks []int
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/batch/subscribers") {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		var body struct {
+			Subscribers []bento.SubscriberInput `json:"subscribers"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if body.Subscribers[0].Fields["plan"] != "pro" {
+			t.Errorf("expected the plan field, got %v", body.Subscribers[0].Fields)
+		}
+		chunks = append(chunks, len(body.Subscribers))
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": len(body.Subscribers), "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	result, err := client.ImportSubscribersCSV(context.Background(), strings.NewReader(file.String()), bento.CSVOptions{})
+	if want := []int{500, 500}; !reflect.DeepEqual(chunks, want) {
+		t.Errorf("expected chunks %v, got %v", want, chunks)
+	}
+	if result == nil || result.Queued != 1000 {
+		t.Fatalf("expected 1000 queued, got %+v", result)
+	}
+	var rowErr *bento.CSVRowError
+	if !errors.As(err, &rowErr) || rowErr.Row != 1002 {
+		t.Errorf("expected row 1002 to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package bento
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"sync"
+)
+
+// DebugConfig dumps every request and response, e.g. to see the JSON the SDK
+// sends. The Authorization header and site UUID are redacted.
+type DebugConfig struct {
+	// Output receives the dumps. Defaults to os.Stderr.
+	Output io.Writer
+	// MaxBodySize truncates dumped bodies longer than this many bytes.
+	// Defaults to 4096.
+	MaxBodySize int
+}
+
+const defaultDebugMaxBodySize = 4096
+
+// debugDumper writes redacted request and response dumps
+type debugDumper struct {
+	mu          sync.Mutex
+	out         io.Writer
+	maxBodySize int
+}
+
+func newDebugDumper(config *DebugConfig) *debugDumper {
+	d := &debugDumper{out: config.Output, maxBodySize: config.MaxBodySize}
+	if d.out == nil {
+		d.out = os.Stderr
+	}
+	if d.maxBodySize <= 0 {
+		d.maxBodySize = defaultDebugMaxBodySize
+	}
+	return d
+}
+
+// dumpRequest writes req with credentials redacted, leaving its body readable
+func (d *debugDumper) dumpRequest(req *http.Request) error {
+	body, err := drainBody(&req.Body)
+	if err != nil {
+		return err
+	}
+
+	redacted := req.Clone(req.Context())
+	redacted.Body = http.NoBody
+	if body != nil {
+		redacted.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	redacted.URL = redactedURL(req.URL)
+	if redacted.Header.Get("Authorization") != "" {
+		redacted.Header.Set("Authorization", "Basic [redacted]")
+	}
+	head, err := httputil.DumpRequestOut(redacted, false)
+	if err != nil {
+		return err
+	}
+	d.write(head, body)
+	return nil
+}
+
+// dumpResponse writes resp, leaving its body readable
+func (d *debugDumper) dumpResponse(resp *http.Response) error {
+	body, err := drainBody(&resp.Body)
+	if err != nil {
+		return err
+	}
+	head, err := httputil.DumpResponse(resp, false)
+	if err != nil {
+		return err
+	}
+	d.write(head, body)
+	return nil
+}
+
+func (d *debugDumper) write(head, body []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, _ = d.out.Write(head)
+	if len(body) > d.maxBodySize {
+		_, _ = d.out.Write(body[:d.maxBodySize])
+		_, _ = fmt.Fprintf(d.out, "\n[truncated %d bytes]", len(body)-d.maxBodySize)
+	} else {
+		_, _ = d.out.Write(body)
+	}
+	_, _ = io.WriteString(d.out, "\n\n")
+}
+
+// drainBody reads *body fully and replaces it with an identical reader
+func drainBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
@@ -0,0 +1,92 @@
+package bento_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestDebugRedactsAndPreservesBodies(t *testing.T) {
+	var out bytes.Buffer
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Debug = &bento.DebugConfig{Output: &out}
+	}, func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("request body was consumed by the dump: %q", body)
+		}
+		if !validateAuthHeaders(req) {
+			t.Error("expected the real auth header to be sent")
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.TrackEvent(context.Background(), []bento.EventData{
+		{Type: "$pageview", Email: "test@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dump := out.String()
+	for _, want := range []string{
+		"POST /api/v1/batch/events?site_uuid=REDACTED",
+		"Authorization: Basic [redacted]",
+		`"type":"$pageview"`,
+		" 200 OK",
+		`"results":1`,
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("expected dump to contain %q, got:\n%s", want, dump)
+		}
+	}
+	for _, secret := range []string{
+		"pc422f7e69255a4bf9c9fafcaac64b14",
+		"s1803b8d410fd4ca3a7d1d1f5be6d3b6",
+		"2103f23614d9877a6b4ee73d28a5c610",
+	} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("dump leaks credential %q", secret)
+		}
+	}
+}
+
+func TestDebugPreservesResponseBody(t *testing.T) {
+	var out bytes.Buffer
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Debug = &bento.DebugConfig{Output: &out, MaxBodySize: 10}
+	}, func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusOK, map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"id": "tag_1", "attributes": map[string]interface{}{"name": "customer"}},
+			},
+		}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	tags, err := client.GetTags(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tags) != 1 || tags[0].Attributes.Name != "customer" {
+		t.Errorf("response body was not preserved: %+v", tags)
+	}
+	if !strings.Contains(out.String(), "[truncated ") {
+		t.Errorf("expected truncation marker, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,200 @@
+package bento
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// decodeSnippetSize is how much of an undecodable body is kept on DecodeError
+const decodeSnippetSize = 256
+
+// DecodeError reports a response body that could not be decoded, such as
+// an HTML page served by a captive proxy in place of JSON
+type DecodeError struct {
+	// Method and Endpoint identify the call, e.g. GET /fetch/tags
+	Method   string
+	Endpoint string
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Snippet holds the start of the body, with control characters removed
+	Snippet string
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	msg := fmt.Sprintf("failed to parse response: %s %s returned %d: %v", e.Method, e.Endpoint, e.StatusCode, e.Err)
+	if e.Snippet != "" {
+		msg += fmt.Sprintf(" (body %q)", e.Snippet)
+	}
+	return msg
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// Code classifies the error as CodeDecode
+func (e *DecodeError) Code() ErrorCode { return CodeDecode }
+
+// decodeJSON decodes the JSON body of resp, the response to req, into out,
+// reporting oversized bodies as ErrResponseTooLarge and anything else as a
+// *DecodeError. With Config.StrictDecoding, unknown fields are rejected and
+// every envelope key must be present at the top level of the response.
+func (c *Client) decodeJSON(req *http.Request, resp *http.Response, out any, envelope ...string) error {
+	head := &headBuffer{limit: decodeSnippetSize}
+	body := io.TeeReader(resp.Body, head)
+
+	if !c.config.StrictDecoding {
+		return c.decodeError(req, resp, head.buf, json.NewDecoder(body).Decode(out))
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return c.decodeError(req, resp, head.buf, err)
+	}
+	if len(envelope) > 0 {
+		var keys map[string]json.RawMessage
+		if err := json.Unmarshal(data, &keys); err != nil {
+			return c.decodeError(req, resp, head.buf, err)
+		}
+		for _, key := range envelope {
+			if _, ok := keys[key]; !ok {
+				return c.decodeError(req, resp, head.buf, withCode(CodeDecode, fmt.Errorf("missing %q", key)))
+			}
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(out); err != nil {
+		return c.decodeError(req, resp, head.buf, err)
+	}
+	return c.decodeError(req, resp, head.buf, checkUnknownFields(data, reflect.TypeOf(out)))
+}
+
+// ownFieldUnmarshalers are the types whose UnmarshalJSON decodes their own
+// fields, which DisallowUnknownFields cannot see into
+var ownFieldUnmarshalers = map[reflect.Type]bool{
+	reflect.TypeOf(SubscriberAttributes{}): true,
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// checkUnknownFields reports a key in data that t does not declare inside the
+// ownFieldUnmarshalers, so strict decoding covers them like any other type.
+// Values that do not match t are left to the decoder to report.
+func checkUnknownFields(data []byte, t reflect.Type) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if t.Elem().Kind() == reflect.Uint8 || json.Unmarshal(data, &items) != nil {
+			return nil
+		}
+		for _, item := range items {
+			if err := checkUnknownFields(item, t.Elem()); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		var values map[string]json.RawMessage
+		if json.Unmarshal(data, &values) != nil {
+			return nil
+		}
+		for _, value := range values {
+			if err := checkUnknownFields(value, t.Elem()); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if reflect.PointerTo(t).Implements(jsonUnmarshalerType) && !ownFieldUnmarshalers[t] {
+			return nil
+		}
+		var values map[string]json.RawMessage
+		if json.Unmarshal(data, &values) != nil {
+			return nil
+		}
+		fields := jsonFields(t, nil)
+		for key, value := range values {
+			field, ok := fields[strings.ToLower(key)]
+			if !ok {
+				if ownFieldUnmarshalers[t] {
+					return withCode(CodeDecode, fmt.Errorf("json: unknown field %q", key))
+				}
+				continue
+			}
+			if err := checkUnknownFields(value, field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonFields adds the JSON keys of t's fields to fields, lowercased as the
+// decoder matches them, including the fields of embedded structs
+func jsonFields(t reflect.Type, fields map[string]reflect.Type) map[string]reflect.Type {
+	if fields == nil {
+		fields = make(map[string]reflect.Type)
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				jsonFields(embedded, fields)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	return fields
+}
+
+// decodeError describes an error from reading or decoding a response body,
+// keeping the start of the body that was read
+func (c *Client) decodeError(req *http.Request, resp *http.Response, head []byte, err error) error {
+	if err == nil || errors.Is(err, ErrResponseTooLarge) {
+		return err
+	}
+	return &DecodeError{
+		Method:     req.Method,
+		Endpoint:   c.endpoint(req),
+		StatusCode: resp.StatusCode,
+		Snippet:    sanitizeBody(head),
+		Err:        err,
+	}
+}
+
+// headBuffer keeps the first limit bytes written to it and discards the rest
+type headBuffer struct {
+	buf   []byte
+	limit int
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - len(b.buf); room > 0 {
+		b.buf = append(b.buf, p[:min(room, len(p))]...)
+	}
+	return len(p), nil
+}
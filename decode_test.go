@@ -0,0 +1,238 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    interface{}
+		strictError bool
+	}{
+		{
+			name: "expected response",
+			response: map[string]interface{}{
+				"data": []map[string]interface{}{{"id": "field_1", "type": "visitors-fields"}},
+			},
+		},
+		{
+			name: "unexpected key",
+			response: map[string]interface{}{
+				"data": []map[string]interface{}{{"id": "field_1", "type": "visitors-fields", "renamed": true}},
+			},
+			strictError: true,
+		},
+		{
+			name:        "missing envelope",
+			response:    map[string]interface{}{"fields": []interface{}{}},
+			strictError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, strict := range []bool{false, true} {
+			name := tt.name + "/lenient"
+			if strict {
+				name = tt.name + "/strict"
+			}
+			t.Run(name, func(t *testing.T) {
+				client, err := setupTestClientWithConfig(func(c *bento.Config) {
+					c.StrictDecoding = strict
+				}, func(req *http.Request) (*http.Response, error) {
+					return mockResponse(http.StatusOK, tt.response), nil
+				})
+				if err != nil {
+					t.Fatalf("failed to setup test client: %v", err)
+				}
+
+				_, err = client.GetFields(context.Background())
+				if strict && tt.strictError {
+					if bento.CodeOf(err) != bento.CodeDecode {
+						t.Errorf("expected decode error, got %v", err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestStrictDecodingEnvelopes(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(*bento.Client) error
+	}{
+		{
+			name: "tags",
+			call: func(c *bento.Client) error {
+				_, err := c.GetTags(context.Background())
+				return err
+			},
+		},
+		{
+			name: "subscribers",
+			call: func(c *bento.Client) error {
+				_, err := c.FindSubscriber(context.Background(), "test@example.com")
+				return err
+			},
+		},
+		{
+			name: "broadcasts",
+			call: func(c *bento.Client) error {
+				_, err := c.GetBroadcasts(context.Background())
+				return err
+			},
+		},
+		{
+			name: "events",
+			call: func(c *bento.Client) error {
+				return c.TrackEvent(context.Background(), []bento.EventData{{Type: "$pageview", Email: "test@example.com"}})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.StrictDecoding = true
+			}, func(req *http.Request) (*http.Response, error) {
+				return mockResponse(http.StatusOK, map[string]interface{}{}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			if err := tt.call(client); bento.CodeOf(err) != bento.CodeDecode {
+				t.Errorf("expected decode error for a missing envelope, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	page := "<html>\x1b[0m<head><title>Sign in to Wi-Fi</title></head><body>" + strings.Repeat("<p>Accept the terms</p>", 100) + "</body></html>"
+
+	tests := []struct {
+		name     string
+		endpoint string
+		call     func(*bento.Client) error
+	}{
+		{
+			name:     "tags",
+			endpoint: "/fetch/tags",
+			call: func(c *bento.Client) error {
+				_, err := c.GetTags(context.Background())
+				return err
+			},
+		},
+		{
+			name:     "site stats",
+			endpoint: "/stats/site",
+			call: func(c *bento.Client) error {
+				_, err := c.GetSiteStats(context.Background())
+				return err
+			},
+		},
+	}
+
+	var messages []string
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"text/html"}},
+					Body:       io.NopCloser(strings.NewReader(page)),
+				}, nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			err = tt.call(client)
+			var decodeErr *bento.DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("expected DecodeError, got %v", err)
+			}
+			if decodeErr.Method != http.MethodGet || decodeErr.Endpoint != tt.endpoint {
+				t.Errorf("expected GET %s, got %s %s", tt.endpoint, decodeErr.Method, decodeErr.Endpoint)
+			}
+			if decodeErr.StatusCode != http.StatusOK {
+				t.Errorf("expected status 200, got %d", decodeErr.StatusCode)
+			}
+			if len(decodeErr.Snippet) > 256 || !strings.HasPrefix(decodeErr.Snippet, "<html>[0m<head>") {
+				t.Errorf("expected a capped snippet without control characters, got %q", decodeErr.Snippet)
+			}
+			if strings.ContainsAny(err.Error(), "\x1b\n") {
+				t.Errorf("expected a single-line message safe to log, got %q", err.Error())
+			}
+			if bento.CodeOf(err) != bento.CodeDecode {
+				t.Errorf("expected CodeDecode, got %s", bento.CodeOf(err))
+			}
+			messages = append(messages, err.Error())
+		})
+	}
+
+	if len(messages) == 2 && messages[0] == messages[1] {
+		t.Errorf("expected the endpoints to tell the errors apart, got %q twice", messages[0])
+	}
+	for i, tt := range tests {
+		if i < len(messages) && !strings.Contains(messages[i], "GET "+tt.endpoint) {
+			t.Errorf("expected %s in %q", tt.endpoint, messages[i])
+		}
+	}
+}
+
+func TestStrictDecodingSubscriberAttributes(t *testing.T) {
+	subscriber := func(attributes map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"data": map[string]interface{}{"id": "sub_123", "type": "subscriber", "attributes": attributes},
+		}
+	}
+	tests := []struct {
+		name       string
+		attributes map[string]interface{}
+		wantError  bool
+	}{
+		{name: "unsubscribed", attributes: map[string]interface{}{"email": "test@example.com", "unsubscribed_at": "2024-03-15 10:30:45 UTC"}},
+		{name: "unexpected key", attributes: map[string]interface{}{"email": "test@example.com", "renamed": true}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.StrictDecoding = true
+			}, func(req *http.Request) (*http.Response, error) {
+				return mockResponse(http.StatusOK, subscriber(tt.attributes)), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			found, err := client.FindSubscriber(context.Background(), "test@example.com")
+			if tt.wantError {
+				if bento.CodeOf(err) != bento.CodeDecode {
+					t.Errorf("expected decode error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if found.Attributes.UnsubscribedAt == nil {
+				t.Errorf("expected UnsubscribedAt to be decoded, got %v", found.Attributes.UnsubscribedAt)
+			}
+		})
+	}
+}
@@ -9,32 +9,54 @@ import (
 	"net/mail"
 )
 
-// CreateEmails sends one or more emails through Bento
-func (c *Client) CreateEmails(ctx context.Context, emails []EmailData) (int, error) {
+// CreateEmails sends one or more emails through Bento and returns the number
+// queued. Batches larger than BatchLimits.MaxEmailsPerRequest are rejected,
+// unless BatchLimits.ChunkEmails is set: they are then sent as sequential
+// requests and, if one fails, the emails queued by the earlier requests are
+// counted and the rest are not sent.
+func (c *Client) CreateEmails(ctx context.Context, emails []EmailData, opts ...RequestOption) (int, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
 	if len(emails) == 0 {
 		return 0, fmt.Errorf("%w: no emails provided", ErrInvalidRequest)
 	}
 
-	if len(emails) > 60 {
-		return 0, fmt.Errorf("%w: maximum of 60 emails allowed per request", ErrInvalidRequest)
+	limits := c.batchLimits()
+	limit := limits.MaxEmailsPerRequest
+	if len(emails) > limit && (!limits.ChunkEmails || limits.DisableChunking) {
+		return 0, fmt.Errorf("%w: %w: maximum of %d emails allowed per request", ErrInvalidRequest, ErrInvalidBatchSize, limit)
 	}
 
 	// Validate all emails before sending
-	for _, email := range emails {
-		if _, err := mail.ParseAddress(email.To); err != nil {
-			return 0, fmt.Errorf("%w: invalid recipient email: %s", ErrInvalidEmail, email.To)
-		}
-		if _, err := mail.ParseAddress(email.From); err != nil {
-			return 0, fmt.Errorf("%w: invalid sender email: %s", ErrInvalidEmail, email.From)
-		}
-		if email.Subject == "" {
-			return 0, fmt.Errorf("%w: subject is required", ErrInvalidRequest)
+	emails = c.normalizeEmails(emails)
+	if err := validateEntries(emails, validateEmailData); err != nil {
+		return 0, err
+	}
+
+	if len(emails) <= limit {
+		return c.sendEmails(ctx, emails)
+	}
+
+	queued := 0
+	for start := 0; start < len(emails); start += limit {
+		if err := ctx.Err(); err != nil {
+			return queued, err
 		}
-		if email.HTMLBody == "" {
-			return 0, fmt.Errorf("%w: html_body is required", ErrInvalidRequest)
+		end := min(start+limit, len(emails))
+		results, err := c.sendEmails(ctx, emails[start:end])
+		if err != nil {
+			return queued, withCode(CodeOf(err), fmt.Errorf("sending emails %d-%d: %w", start, end-1, err))
 		}
+		queued += results
 	}
+	return queued, nil
+}
 
+// sendEmails sends one request of emails and returns the number queued
+func (c *Client) sendEmails(ctx context.Context, emails []EmailData) (int, error) {
 	body, err := json.Marshal(map[string]interface{}{
 		"emails": emails,
 	})
@@ -55,15 +77,32 @@ func (c *Client) CreateEmails(ctx context.Context, emails []EmailData) (int, err
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return 0, c.statusError(req, resp)
 	}
 
 	var result struct {
 		Results int `json:"results"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.decodeJSON(req, resp, &result, "results"); err != nil {
 		return 0, err
 	}
 
 	return result.Results, nil
 }
+
+// validateEmailData checks a single email before it is sent
+func validateEmailData(email EmailData) error {
+	if _, err := mail.ParseAddress(email.To); err != nil {
+		return invalidField(ErrInvalidEmail, "to", email.To, "invalid recipient email")
+	}
+	if _, err := mail.ParseAddress(email.From); err != nil {
+		return invalidField(ErrInvalidEmail, "from", email.From, "invalid sender email")
+	}
+	if email.Subject == "" {
+		return invalidField(ErrInvalidRequest, "subject", "", "subject is required")
+	}
+	if email.HTMLBody == "" {
+		return invalidField(ErrInvalidRequest, "html_body", "", "html_body is required")
+	}
+	return nil
+}
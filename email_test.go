@@ -3,6 +3,8 @@ package bento_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -277,3 +279,115 @@ func TestCreateEmailsRequestValidation(t *testing.T) {
         t.Error("expected error with invalid personalizations, got nil")
     }
 }
+
+func TestCreateEmailsChunking(t *testing.T) {
+	var sizes []int
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.BatchLimits.ChunkEmails = true
+	}, func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Emails []bento.EmailData `json:"emails"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("invalid request body JSON: %v", err)
+		}
+		sizes = append(sizes, len(body.Emails))
+		// Each request reports its own emails as queued
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": len(body.Emails)}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	emails := make([]bento.EmailData, 61) // one more than a request allows
+	for i := range emails {
+		emails[i] = bento.EmailData{
+			To:            fmt.Sprintf("recipient%d@example.com", i),
+			From:          "sender@example.com",
+			Subject:       "Test Subject",
+			HTMLBody:      "<p>Test Content</p>",
+			Transactional: true,
+		}
+	}
+
+	results, err := client.CreateEmails(context.Background(), emails)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results != 61 {
+		t.Errorf("expected 61 emails queued, got %d", results)
+	}
+	if len(sizes) != 2 || sizes[0] != 60 || sizes[1] != 1 {
+		t.Errorf("expected requests of 60 and 1 emails, got %v", sizes)
+	}
+}
+
+func TestCreateEmailsValidationErrorIndex(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Error("expected no request for an invalid batch")
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 5}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	var batch []bento.EmailData
+	for i := 0; i < 5; i++ {
+		batch = append(batch, bento.EmailData{
+			To:       fmt.Sprintf("user%d@example.com", i),
+			From:     "sender@example.com",
+			Subject:  "Receipt",
+			HTMLBody: "<p>Thanks</p>",
+		})
+	}
+	batch[4].To = "not-an-email"
+
+	_, err = client.CreateEmails(context.Background(), batch)
+	if !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Fatalf("expected ErrInvalidEmail, got %v", err)
+	}
+
+	var validationErr *bento.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if validationErr.Index != 4 || validationErr.Field != "to" || validationErr.Value != "not-an-email" {
+		t.Errorf("unexpected validation error: %+v", validationErr)
+	}
+
+	// Dropping the bad item lets the rest be resent
+	remaining := append(batch[:validationErr.Index:validationErr.Index], batch[validationErr.Index+1:]...)
+	if len(remaining) != 4 {
+		t.Errorf("expected 4 remaining emails, got %d", len(remaining))
+	}
+}
+
+func TestValidationErrorsJoined(t *testing.T) {
+	client, err := setupTestClient(nil)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, err = client.CreateEmails(context.Background(), []bento.EmailData{
+		{To: "bad", From: "sender@example.com", Subject: "Hi", HTMLBody: "<p>Hi</p>"},
+		{To: "user@example.com", From: "sender@example.com", HTMLBody: "<p>Hi</p>"},
+	})
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected joined errors, got %T", err)
+	}
+	var fields []string
+	for _, e := range joined.Unwrap() {
+		var validationErr *bento.ValidationError
+		if errors.As(e, &validationErr) {
+			fields = append(fields, fmt.Sprintf("%d:%s", validationErr.Index, validationErr.Field))
+		}
+	}
+	if strings.Join(fields, ",") != "0:to,1:subject" {
+		t.Errorf("unexpected validation errors: %v", fields)
+	}
+	if !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected the missing subject to match ErrInvalidRequest, got %v", err)
+	}
+}
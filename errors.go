@@ -1,16 +1,467 @@
 package bento
 
-import "errors"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrorCode is a stable, machine-readable classification of an SDK error
+type ErrorCode string
+
+const (
+	CodeUnknown        ErrorCode = "unknown"
+	CodeInvalidConfig  ErrorCode = "invalid_config"
+	CodeInvalidEmail   ErrorCode = "invalid_email"
+	CodeValidation     ErrorCode = "validation"
+	CodeUnauthorized   ErrorCode = "unauthorized"
+	CodeForbidden      ErrorCode = "forbidden"
+	CodeNotFound       ErrorCode = "not_found"
+	CodeRateLimited    ErrorCode = "rate_limited"
+	CodeServerError    ErrorCode = "server_error"
+	CodeAPIError       ErrorCode = "api_error"
+	CodePartialFailure ErrorCode = "partial_failure"
+	CodeNetwork        ErrorCode = "network"
+	CodeDecode         ErrorCode = "decode"
+	CodeCanceled       ErrorCode = "canceled"
+	CodeCircuitOpen    ErrorCode = "circuit_open"
+)
 
 // Define package-level errors
-var ErrInvalidConfig = errors.New("invalid configuration: missing required fields")
-var ErrInvalidEmail = errors.New("invalid email address")
-var ErrInvalidIPAddress = errors.New("invalid IP address")
-var ErrInvalidRequest = errors.New("invalid request parameters")
-var ErrAPIResponse = errors.New("unexpected API response")
-var ErrInvalidName = errors.New("invalid name format")
-var ErrInvalidSegmentID = errors.New("invalid segment ID")
-var ErrInvalidContent = errors.New("invalid content")
-var ErrInvalidTags = errors.New("invalid tags format")
-var ErrInvalidBatchSize = errors.New("invalid batch size")
-var ErrInvalidKeyLength = errors.New("invalid key length")
+var ErrInvalidConfig = newError(CodeInvalidConfig, "invalid configuration: missing required fields")
+var ErrInvalidEmail = newError(CodeInvalidEmail, "invalid email address")
+var ErrInvalidIPAddress = newError(CodeValidation, "invalid IP address")
+var ErrInvalidRequest = newError(CodeValidation, "invalid request parameters")
+var ErrAPIResponse = newError(CodeAPIError, "unexpected API response")
+var ErrInvalidName = newError(CodeValidation, "invalid name format")
+var ErrInvalidSegmentID = newError(CodeValidation, "invalid segment ID")
+var ErrInvalidContent = newError(CodeValidation, "invalid content")
+var ErrInvalidTags = newError(CodeValidation, "invalid tags format")
+var ErrInvalidBatchSize = newError(CodeValidation, "invalid batch size")
+var ErrInvalidKeyLength = newError(CodeInvalidConfig, "invalid key length")
+var ErrInvalidMapping = newError(CodeValidation, "invalid struct mapping")
+var ErrBroadcastNotFound = newError(CodeNotFound, "broadcast not found")
+var ErrSubscriberNotFound = newError(CodeNotFound, "subscriber not found")
+var ErrPartialFailure = newError(CodePartialFailure, "batch partially failed")
+var ErrUnauthorized = newError(CodeUnauthorized, "credentials rejected")
+var ErrForbidden = newError(CodeForbidden, "access forbidden")
+var ErrNotFound = newError(CodeNotFound, "resource not found")
+var ErrRateLimited = newError(CodeRateLimited, "rate limit exceeded")
+
+// ErrCircuitOpen is returned without contacting the API while the circuit
+// breaker is open. It satisfies errors.Is(err, ErrAPIResponse).
+var ErrCircuitOpen = withCode(CodeCircuitOpen, fmt.Errorf("%w: circuit breaker open", ErrAPIResponse))
+
+// ErrResponseTooLarge is returned when a response body exceeds
+// Config.MaxResponseBytes. It satisfies errors.Is(err, ErrAPIResponse).
+var ErrResponseTooLarge = fmt.Errorf("%w: response too large", ErrAPIResponse)
+
+// coder is implemented by every error the SDK constructs
+type coder interface {
+	Code() ErrorCode
+}
+
+// CodeOf returns the ErrorCode of the first coded error in err's chain.
+// Context cancellation and deadline errors report CodeCanceled, any other
+// foreign error reports CodeUnknown, and a nil error reports an empty code.
+func CodeOf(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	var c coder
+	if errors.As(err, &c) {
+		return c.Code()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return CodeCanceled
+	}
+	return CodeUnknown
+}
+
+// StatusCoder is implemented by errors that describe a non-2xx API response
+type StatusCoder interface {
+	HTTPStatus() int
+}
+
+// HTTPStatus returns the HTTP status of the API response err describes,
+// looking through wrapping such as retries and sequential command errors. It
+// reports false for errors that did not come from a non-2xx response, such as
+// validation, transport and decode failures.
+func HTTPStatus(err error) (int, bool) {
+	var sc StatusCoder
+	if errors.As(err, &sc) {
+		return sc.HTTPStatus(), true
+	}
+	return 0, false
+}
+
+// IsRetryable reports whether a failed call may succeed if made again later:
+// rate limiting, server errors (500, 502 and 503), transport timeouts such as
+// Config.Timeout expiring and temporary failures such as a reset connection,
+// temporary DNS failures and an open circuit breaker. Config.Retry does not
+// retry 502 itself, since a gateway error may come after the API acted on the
+// request, but a caller redriving the call later may. Validation failures,
+// other API responses and the caller's own cancelled or expired context are
+// not retryable, matching CodeOf's CodeCanceled.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError,
+			http.StatusBadGateway, http.StatusServiceUnavailable:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// A transport timeout can also match context.DeadlineExceeded, so it is
+	// told apart from the caller's context before the context errors
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		return transportErr.Timeout || transportErr.Temporary
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// TransportError reports a request that failed before any response was
+// received, such as a connection reset or Config.Timeout expiring. A
+// cancelled or expired context is returned as the context error instead, so
+// the caller's own deadline can be told apart from a network problem.
+type TransportError struct {
+	// Timeout reports that the transport gave up waiting for the server
+	Timeout bool
+	// Temporary reports a transient failure, such as a reset connection,
+	// that may succeed when the request is sent again
+	Temporary bool
+	Err       error
+}
+
+func (e *TransportError) Error() string { return "request failed: " + e.Err.Error() }
+
+func (e *TransportError) Unwrap() error { return e.Err }
+
+// Code classifies the error as CodeNetwork
+func (e *TransportError) Code() ErrorCode { return CodeNetwork }
+
+// redactURLError strips the query string, which holds the site UUID, from
+// the URL reported by a *url.Error in err, as APIError.Endpoint does
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL, _, _ = strings.Cut(urlErr.URL, "?")
+	}
+	return err
+}
+
+// transportError classifies an error returned by HTTPDoer.Do, looking
+// through *url.Error and *net.OpError to the underlying cause
+func transportError(err error) *TransportError {
+	e := &TransportError{Err: err}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		e.Timeout = netErr.Timeout()
+	}
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) {
+		e.Temporary = temporary.Temporary()
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		e.Temporary = true
+	}
+	return e
+}
+
+// PartialFailureError is returned when the API accepted a batch but
+// rejected some of its items. It satisfies errors.Is(err, ErrPartialFailure).
+type PartialFailureError struct {
+	// Operation names the batch, e.g. "import" or "event tracking"
+	Operation string
+	Succeeded int
+	Failed    int
+	// Items lists what the batch held when it is known, such as the tags or
+	// field keys of AddTagsToSubscriber and RemoveFieldsFromSubscriber. The
+	// API only counts the failures, so any of them may have been rejected.
+	Items []string
+}
+
+func (e *PartialFailureError) Error() string {
+	msg := fmt.Sprintf("%s partially failed: %d succeeded, %d failed", e.Operation, e.Succeeded, e.Failed)
+	if len(e.Items) > 0 {
+		msg += " (of " + strings.Join(e.Items, ", ") + ")"
+	}
+	return msg
+}
+
+func (e *PartialFailureError) Unwrap() error { return ErrPartialFailure }
+
+// Code classifies the error as CodePartialFailure
+func (e *PartialFailureError) Code() ErrorCode { return CodePartialFailure }
+
+// ConfigFieldError names the Config field NewClient rejected, so callers can
+// tell which credential is misconfigured. It unwraps to ErrInvalidConfig for
+// a missing field and to ErrInvalidKeyLength for a value of the wrong length.
+type ConfigFieldError struct {
+	// Field is the Config field name, e.g. "SiteUUID"
+	Field string
+	// Length is the length of the rejected value, 0 when it is missing
+	Length int
+	Err    error
+}
+
+func (e *ConfigFieldError) Error() string {
+	if errors.Is(e.Err, ErrInvalidKeyLength) {
+		return fmt.Sprintf("%s: %s must be between 28 and 36 characters (got %d)", e.Err, e.Field, e.Length)
+	}
+	return fmt.Sprintf("%s: %s", e.Err, e.Field)
+}
+
+func (e *ConfigFieldError) Unwrap() error { return e.Err }
+
+// Code classifies the error as CodeInvalidConfig
+func (e *ConfigFieldError) Code() ErrorCode { return CodeOf(e.Err) }
+
+// ValidationError describes an input rejected before anything was sent.
+// Batch methods join one per invalid item. It unwraps to the matching
+// sentinel, such as ErrInvalidEmail or ErrInvalidRequest.
+type ValidationError struct {
+	// Index is the position of the item in its batch, or -1 outside a batch
+	Index int
+	// Field is the JSON name of the invalid field, e.g. "email" or "subject"
+	Field string
+	// Value is the rejected value, empty when the field is missing
+	Value string
+	// Reason explains why the value was rejected
+	Reason string
+	// Err is the sentinel the error matches with errors.Is
+	Err error
+}
+
+// invalidField returns a ValidationError for an item outside a batch
+func invalidField(sentinel error, field, value, reason string) *ValidationError {
+	return &ValidationError{Index: -1, Field: field, Value: value, Reason: reason, Err: sentinel}
+}
+
+func (e *ValidationError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Err, e.Reason)
+	if e.Value != "" {
+		msg += fmt.Sprintf(" (%q)", e.Value)
+	}
+	if e.Index >= 0 {
+		msg = fmt.Sprintf("entry %d: %s", e.Index, msg)
+	}
+	return msg
+}
+
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// Code classifies the error by its sentinel
+func (e *ValidationError) Code() ErrorCode { return CodeOf(e.Err) }
+
+// validateEntries runs validate on every entry of a batch and joins all
+// failures, each tagged with its index, rather than stopping at the first
+func validateEntries[T any](entries []T, validate func(T) error) error {
+	var errs []error
+	for i, entry := range entries {
+		err := validate(entry)
+		if err == nil {
+			continue
+		}
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			validationErr.Index = i
+			errs = append(errs, validationErr)
+			continue
+		}
+		errs = append(errs, withCode(CodeOf(err), fmt.Errorf("entry %d: %w", i, err)))
+	}
+	return errors.Join(errs...)
+}
+
+// sdkError is a sentinel error carrying an ErrorCode
+type sdkError struct {
+	code ErrorCode
+	msg  string
+}
+
+func newError(code ErrorCode, msg string) error {
+	return &sdkError{code: code, msg: msg}
+}
+
+func (e *sdkError) Error() string   { return e.msg }
+func (e *sdkError) Code() ErrorCode { return e.code }
+
+// codedError attaches an ErrorCode to an error that does not wrap a sentinel
+type codedError struct {
+	code ErrorCode
+	err  error
+}
+
+func withCode(code ErrorCode, err error) error {
+	return &codedError{code: code, err: err}
+}
+
+func (e *codedError) Error() string   { return e.err.Error() }
+func (e *codedError) Unwrap() error   { return e.err }
+func (e *codedError) Code() ErrorCode { return e.code }
+
+// APIError is returned when the Bento API responds with a non-success status.
+// It satisfies errors.Is(err, ErrAPIResponse).
+type APIError struct {
+	StatusCode int
+	Message    string
+	// Method and Endpoint identify the failed request, e.g. "POST" and
+	// "/batch/events". Endpoint never includes the query string.
+	Method   string
+	Endpoint string
+	// Body holds the start of the response body, up to
+	// Config.MaxErrorBodyBytes, with control characters removed
+	Body string
+	// Truncated reports whether Body was cut short
+	Truncated bool
+	// ContentType is the Content-Type of the response, e.g. "text/html"
+	ContentType string
+	// RetryAfter is the wait requested by the server's Retry-After header, or zero
+	RetryAfter time.Duration
+	// RequestID identifies the request for Bento support, when the response carried one
+	RequestID string
+	// Detail is the server's explanation: the "error" or "message" field of a
+	// JSON body, or the start of any other body
+	Detail string
+}
+
+func (e *APIError) Error() string {
+	var msg string
+	if e.Method != "" {
+		msg = fmt.Sprintf("%s: %s %s returned %d (%s", ErrAPIResponse, e.Method, e.Endpoint, e.StatusCode, e.Message)
+	} else {
+		msg = fmt.Sprintf("%s: %s (%d", ErrAPIResponse, e.Message, e.StatusCode)
+	}
+	if e.RequestID != "" {
+		msg += ", request ID " + e.RequestID
+	}
+	msg += ")"
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+// sanitizeBody makes a captured error body safe to log by dropping control
+// characters other than whitespace and any bytes that are not valid UTF-8,
+// such as a rune split by truncation
+func sanitizeBody(body []byte) string {
+	return strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || (unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t') {
+			return -1
+		}
+		return r
+	}, string(body))
+}
+
+// maxDetailSize bounds the snippet of a non-JSON error body kept as Detail
+const maxDetailSize = 200
+
+// errorDetail extracts the server's explanation from an error response body
+func errorDetail(body string) string {
+	if json.Valid([]byte(body)) {
+		var payload struct {
+			Error   any `json:"error"`
+			Message any `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(body), &payload); err != nil {
+			return ""
+		}
+		for _, field := range []any{payload.Error, payload.Message} {
+			if text, ok := field.(string); ok && strings.TrimSpace(text) != "" {
+				return strings.TrimSpace(text)
+			}
+		}
+		return ""
+	}
+
+	snippet := strings.Join(strings.Fields(body), " ")
+	if len(snippet) > maxDetailSize {
+		cut := maxDetailSize
+		for cut > 0 && !utf8.RuneStart(snippet[cut]) {
+			cut--
+		}
+		snippet = snippet[:cut] + "..."
+	}
+	return snippet
+}
+
+// Unwrap lets errors.Is match ErrAPIResponse and, for 401, 403, 404 and 429
+// responses, ErrUnauthorized, ErrForbidden, ErrNotFound or ErrRateLimited
+func (e *APIError) Unwrap() []error {
+	if sentinel := e.sentinel(); sentinel != nil {
+		return []error{sentinel, ErrAPIResponse}
+	}
+	return []error{ErrAPIResponse}
+}
+
+// sentinel returns the category error matching the status, if any
+func (e *APIError) sentinel() error {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// HTTPStatus returns the status code of the response
+func (e *APIError) HTTPStatus() int { return e.StatusCode }
+
+// Code classifies the error by its HTTP status
+func (e *APIError) Code() ErrorCode {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		return CodeUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return CodeForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return CodeNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return CodeRateLimited
+	case e.StatusCode == http.StatusBadRequest, e.StatusCode == http.StatusUnprocessableEntity:
+		return CodeValidation
+	case e.StatusCode >= 500:
+		return CodeServerError
+	default:
+		return CodeAPIError
+	}
+}
@@ -0,0 +1,1033 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+	"unicode"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestErrorCodes(t *testing.T) {
+	ctx := context.Background()
+
+	statusClient := func(status int) *bento.Client {
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			return mockResponse(status, map[string]interface{}{
+				"data":    map[string]interface{}{},
+				"results": 1,
+				"failed":  1,
+			}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+		return client
+	}
+
+	tests := []struct {
+		name string
+		err  func() error
+		want bento.ErrorCode
+	}{
+		{
+			name: "invalid config",
+			err: func() error {
+				_, err := bento.NewClient(&bento.Config{})
+				return err
+			},
+			want: bento.CodeInvalidConfig,
+		},
+		{
+			name: "invalid email",
+			err: func() error {
+				_, err := statusClient(http.StatusOK).FindSubscriber(ctx, "not-an-email")
+				return err
+			},
+			want: bento.CodeInvalidEmail,
+		},
+		{
+			name: "validation",
+			err: func() error {
+				_, err := statusClient(http.StatusOK).CreateTag(ctx, "")
+				return err
+			},
+			want: bento.CodeValidation,
+		},
+		{
+			name: "unauthorized",
+			err: func() error {
+				_, err := statusClient(http.StatusUnauthorized).GetTags(ctx)
+				return err
+			},
+			want: bento.CodeUnauthorized,
+		},
+		{
+			name: "not found response",
+			err: func() error {
+				_, err := statusClient(http.StatusNotFound).GetSegmentStats(ctx, "segment123")
+				return err
+			},
+			want: bento.CodeNotFound,
+		},
+		{
+			name: "subscriber not found",
+			err: func() error {
+				_, err := statusClient(http.StatusOK).FindSubscriber(ctx, "test@example.com")
+				return err
+			},
+			want: bento.CodeNotFound,
+		},
+		{
+			name: "rate limited",
+			err: func() error {
+				return statusClient(http.StatusTooManyRequests).TrackEvent(ctx, []bento.EventData{
+					{Type: "$test", Email: "test@example.com"},
+				})
+			},
+			want: bento.CodeRateLimited,
+		},
+		{
+			name: "server error",
+			err: func() error {
+				_, err := statusClient(http.StatusServiceUnavailable).GetFields(ctx)
+				return err
+			},
+			want: bento.CodeServerError,
+		},
+		{
+			name: "partial failure",
+			err: func() error {
+				return statusClient(http.StatusOK).ImportSubscribers(ctx, []*bento.SubscriberInput{
+					{Email: "test@example.com"},
+				})
+			},
+			want: bento.CodePartialFailure,
+		},
+		{
+			name: "network",
+			err: func() error {
+				client, _ := setupTestClient(func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("connection refused")
+				})
+				_, err := client.GetTags(ctx)
+				return err
+			},
+			want: bento.CodeNetwork,
+		},
+		{
+			name: "canceled",
+			err: func() error {
+				cancelled, cancel := context.WithCancel(ctx)
+				cancel()
+				_, err := statusClient(http.StatusOK).GetTags(cancelled)
+				return err
+			},
+			want: bento.CodeCanceled,
+		},
+		{
+			name: "wrapped by caller",
+			err: func() error {
+				_, err := statusClient(http.StatusForbidden).GetBroadcasts(ctx)
+				return fmt.Errorf("syncing broadcasts: %w", err)
+			},
+			want: bento.CodeForbidden,
+		},
+		{
+			name: "foreign error",
+			err: func() error {
+				return errors.New("not from the SDK")
+			},
+			want: bento.CodeUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if got := bento.CodeOf(err); got != tt.want {
+				t.Errorf("expected code %q, got %q (%v)", tt.want, got, err)
+			}
+		})
+	}
+
+	if got := bento.CodeOf(nil); got != "" {
+		t.Errorf("expected empty code for nil error, got %q", got)
+	}
+}
+
+func TestAPIErrorSatisfiesSentinel(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusTooManyRequests, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, err = client.GetTags(context.Background())
+	if !errors.Is(err, bento.ErrAPIResponse) {
+		t.Errorf("expected ErrAPIResponse, got %v", err)
+	}
+
+	var apiErr *bento.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected APIError with status 429, got %v", err)
+	}
+	if err.Error() != "unexpected API response: GET /fetch/tags returned 429 (rate limit exceeded)" {
+		t.Errorf("unexpected message: %s", err.Error())
+	}
+}
+
+func TestAPIErrorRequestID(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		configured string
+		requestID  string
+		message    string
+	}{
+		{
+			name:      "default header",
+			header:    "X-Request-Id",
+			requestID: "req_123",
+			message:   "unexpected API response: GET /fetch/tags returned 500 (server error, request ID req_123)",
+		},
+		{
+			name:       "configured header",
+			header:     "X-Bento-Trace",
+			configured: "X-Bento-Trace",
+			requestID:  "req_456",
+			message:    "unexpected API response: GET /fetch/tags returned 500 (server error, request ID req_456)",
+		},
+		{
+			name:    "header absent",
+			message: "unexpected API response: GET /fetch/tags returned 500 (server error)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.RequestIDHeader = tt.configured
+			}, func(req *http.Request) (*http.Response, error) {
+				resp := mockResponse(http.StatusInternalServerError, nil)
+				if tt.header != "" {
+					resp.Header.Set(tt.header, tt.requestID)
+				}
+				return resp, nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			_, err = client.GetTags(context.Background())
+			var apiErr *bento.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected APIError, got %v", err)
+			}
+			if apiErr.RequestID != tt.requestID {
+				t.Errorf("expected request ID %q, got %q", tt.requestID, apiErr.RequestID)
+			}
+			if err.Error() != tt.message {
+				t.Errorf("unexpected message: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestAPIErrorDetail(t *testing.T) {
+	htmlPage := "<html>\n  <body>\n    <h1>502 Bad Gateway</h1>\n" + strings.Repeat("<p>upstream</p>", 50) + "\n  </body>\n</html>"
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		detail     string
+	}{
+		{
+			name:       "json error field",
+			statusCode: http.StatusConflict,
+			body:       `{"error":"Tag already exists"}`,
+			detail:     "Tag already exists",
+		},
+		{
+			name:       "json message field",
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"message":"Subject can't be blank"}`,
+			detail:     "Subject can't be blank",
+		},
+		{
+			name:       "json without explanation",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"status":500}`,
+		},
+		{
+			name:       "html error page",
+			statusCode: http.StatusBadGateway,
+			body:       htmlPage,
+			detail:     strings.Join(strings.Fields(htmlPage), " ")[:200] + "...",
+		},
+		{
+			name:       "empty body",
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	calls := []struct {
+		name string
+		call func(*bento.Client) error
+	}{
+		{
+			name: "CreateTag",
+			call: func(c *bento.Client) error {
+				_, err := c.CreateTag(context.Background(), "vip")
+				return err
+			},
+		},
+		{
+			name: "CreateBroadcast",
+			call: func(c *bento.Client) error {
+				return c.CreateBroadcast(context.Background(), []bento.BroadcastData{{
+					Name:             "Launch",
+					Subject:          "We're live",
+					Content:          "<p>Hello</p>",
+					Type:             bento.BroadcastTypePlain,
+					From:             bento.ContactData{Name: "Sender", Email: "sender@example.com"},
+					BatchSizePerHour: 1000,
+				}})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, call := range calls {
+			t.Run(tt.name+"/"+call.name, func(t *testing.T) {
+				client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: tt.statusCode,
+						Header:     make(http.Header),
+						Body:       io.NopCloser(strings.NewReader(tt.body)),
+					}, nil
+				})
+				if err != nil {
+					t.Fatalf("failed to setup test client: %v", err)
+				}
+
+				err = call.call(client)
+				var apiErr *bento.APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("expected APIError, got %v", err)
+				}
+				if apiErr.Detail != tt.detail {
+					t.Errorf("expected detail %q, got %q", tt.detail, apiErr.Detail)
+				}
+				if tt.detail != "" && !strings.HasSuffix(err.Error(), ": "+tt.detail) {
+					t.Errorf("expected detail in error message, got %s", err.Error())
+				}
+				if tt.detail == "" && strings.Contains(err.Error(), "): ") {
+					t.Errorf("expected no detail in error message, got %s", err.Error())
+				}
+			})
+		}
+	}
+}
+
+func TestAPIErrorBodyCapture(t *testing.T) {
+	htmlPage := "<html>\x1b[31m\x00<body>" + strings.Repeat("<p>upstream</p>", 1000) + "</body></html>"
+
+	tests := []struct {
+		name        string
+		maxBytes    int64
+		contentType string
+		body        string
+		wantBody    string
+		truncated   bool
+	}{
+		{
+			name:        "small json body is kept whole",
+			contentType: "application/json",
+			body:        `{"error":"Tag already exists"}`,
+			wantBody:    `{"error":"Tag already exists"}`,
+		},
+		{
+			name:        "oversized html body is truncated",
+			contentType: "text/html; charset=utf-8",
+			body:        htmlPage,
+			wantBody:    strings.Map(dropControl, htmlPage[:4<<10]),
+			truncated:   true,
+		},
+		{
+			name:        "configured limit",
+			maxBytes:    16,
+			contentType: "text/plain",
+			body:        "upstream timed out after 30s",
+			wantBody:    "upstream timed o",
+			truncated:   true,
+		},
+		{
+			name:        "body exactly at the limit",
+			maxBytes:    8,
+			contentType: "text/plain",
+			body:        "too busy",
+			wantBody:    "too busy",
+		},
+		{
+			name:        "split rune is dropped",
+			maxBytes:    4,
+			contentType: "text/plain",
+			body:        "épée",
+			wantBody:    "ép",
+			truncated:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.MaxErrorBodyBytes = tt.maxBytes
+			}, func(req *http.Request) (*http.Response, error) {
+				header := make(http.Header)
+				header.Set("Content-Type", tt.contentType)
+				return &http.Response{
+					StatusCode: http.StatusBadGateway,
+					Header:     header,
+					Body:       io.NopCloser(strings.NewReader(tt.body)),
+				}, nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			_, err = client.GetTags(context.Background())
+			var apiErr *bento.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected APIError, got %v", err)
+			}
+			if apiErr.Body != tt.wantBody {
+				t.Errorf("expected body %q, got %q", tt.wantBody, apiErr.Body)
+			}
+			if apiErr.Truncated != tt.truncated {
+				t.Errorf("expected Truncated %v, got %v", tt.truncated, apiErr.Truncated)
+			}
+			if apiErr.ContentType != tt.contentType {
+				t.Errorf("expected content type %q, got %q", tt.contentType, apiErr.ContentType)
+			}
+			if strings.ContainsAny(err.Error(), "\x00\x1b") {
+				t.Errorf("expected no control characters in error message, got %q", err.Error())
+			}
+		})
+	}
+}
+
+// dropControl removes the control characters a captured body should not keep
+func dropControl(r rune) rune {
+	if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+		return -1
+	}
+	return r
+}
+
+func TestAPIErrorCategories(t *testing.T) {
+	sentinels := []error{bento.ErrUnauthorized, bento.ErrForbidden, bento.ErrNotFound, bento.ErrRateLimited}
+
+	tests := []struct {
+		statusCode int
+		want       error
+	}{
+		{statusCode: http.StatusUnauthorized, want: bento.ErrUnauthorized},
+		{statusCode: http.StatusForbidden, want: bento.ErrForbidden},
+		{statusCode: http.StatusNotFound, want: bento.ErrNotFound},
+		{statusCode: http.StatusTooManyRequests, want: bento.ErrRateLimited},
+		{statusCode: http.StatusInternalServerError},
+	}
+
+	calls := []struct {
+		name string
+		call func(*bento.Client) error
+	}{
+		{
+			name: "FindSubscriber",
+			call: func(c *bento.Client) error {
+				_, err := c.FindSubscriber(context.Background(), "test@example.com")
+				return err
+			},
+		},
+		{
+			name: "GetSegmentStats",
+			call: func(c *bento.Client) error {
+				_, err := c.GetSegmentStats(context.Background(), "segment_123")
+				return err
+			},
+		},
+		{
+			name: "CreateTag",
+			call: func(c *bento.Client) error {
+				_, err := c.CreateTag(context.Background(), "vip")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, call := range calls {
+			t.Run(fmt.Sprintf("%d/%s", tt.statusCode, call.name), func(t *testing.T) {
+				client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+					return mockResponse(tt.statusCode, nil), nil
+				})
+				if err != nil {
+					t.Fatalf("failed to setup test client: %v", err)
+				}
+
+				err = call.call(client)
+				if !errors.Is(err, bento.ErrAPIResponse) {
+					t.Errorf("expected ErrAPIResponse, got %v", err)
+				}
+				for _, sentinel := range sentinels {
+					if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+						t.Errorf("errors.Is(err, %v) = %v (%v)", sentinel, got, err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestAPIErrorIdentifiesRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    func(*bento.Client) error
+		message string
+	}{
+		{
+			name: "TrackEvent",
+			call: func(c *bento.Client) error {
+				return c.TrackEvent(context.Background(), []bento.EventData{{Type: "$pageview", Email: "test@example.com"}})
+			},
+			message: "unexpected API response: POST /batch/events returned 500 (server error)",
+		},
+		{
+			name: "FindSubscriber",
+			call: func(c *bento.Client) error {
+				_, err := c.FindSubscriber(context.Background(), "test@example.com")
+				return err
+			},
+			message: "unexpected API response: GET /fetch/subscribers returned 500 (server error)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				return mockResponse(http.StatusInternalServerError, nil), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			err = tt.call(client)
+			if err == nil || err.Error() != tt.message {
+				t.Errorf("expected %q, got %v", tt.message, err)
+			}
+			// The query string carries the site UUID and must stay out of errors
+			if err != nil && strings.Contains(err.Error(), "2103f23614d9877a6b4ee73d28a5c610") {
+				t.Errorf("expected site UUID to be left out, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	apiErr := &bento.APIError{StatusCode: http.StatusConflict, Message: "conflict"}
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		ok     bool
+	}{
+		{name: "api error", err: apiErr, status: http.StatusConflict, ok: true},
+		{name: "wrapped", err: fmt.Errorf("creating tag: %w", apiErr), status: http.StatusConflict, ok: true},
+		{name: "rate limit", err: &bento.RateLimitError{Err: &bento.APIError{StatusCode: http.StatusTooManyRequests}}, status: http.StatusTooManyRequests, ok: true},
+		{
+			name:   "sequential command",
+			err:    errors.Join(&bento.CommandError{Index: 2, Commands: []bento.CommandData{{Command: bento.CommandAddTag}}, Err: apiErr}),
+			status: http.StatusConflict,
+			ok:     true,
+		},
+		{name: "nil", err: nil},
+		{name: "validation", err: fmt.Errorf("%w: nope", bento.ErrInvalidEmail)},
+		{name: "partial failure", err: &bento.PartialFailureError{Operation: "import", Succeeded: 1, Failed: 1}},
+		{name: "transport", err: &bento.TransportError{Timeout: true, Err: &timeoutError{}}},
+		{name: "decode", err: &bento.DecodeError{StatusCode: http.StatusOK, Err: io.ErrUnexpectedEOF}},
+		{name: "circuit open", err: bento.ErrCircuitOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, ok := bento.HTTPStatus(tt.err)
+			if status != tt.status || ok != tt.ok {
+				t.Errorf("HTTPStatus(%v) = %d, %v; want %d, %v", tt.err, status, ok, tt.status, tt.ok)
+			}
+		})
+	}
+
+	t.Run("after retries", func(t *testing.T) {
+		client, err := setupTestClientWithConfig(fastRetry(2), func(req *http.Request) (*http.Response, error) {
+			return mockResponse(http.StatusServiceUnavailable, map[string]interface{}{}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		_, err = client.GetTags(context.Background())
+		if !strings.Contains(err.Error(), "after 3 attempts") {
+			t.Fatalf("expected the retry wrapper, got %v", err)
+		}
+		if status, ok := bento.HTTPStatus(err); status != http.StatusServiceUnavailable || !ok {
+			t.Errorf("expected 503, got %d, %v", status, ok)
+		}
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "rate limited", err: &bento.APIError{StatusCode: http.StatusTooManyRequests}, want: true},
+		{name: "server error", err: &bento.APIError{StatusCode: http.StatusInternalServerError}, want: true},
+		{name: "bad gateway", err: &bento.APIError{StatusCode: http.StatusBadGateway}, want: true},
+		{name: "service unavailable", err: &bento.APIError{StatusCode: http.StatusServiceUnavailable}, want: true},
+		{name: "wrapped after retries", err: fmt.Errorf("after 3 attempts: %w", &bento.APIError{StatusCode: http.StatusServiceUnavailable}), want: true},
+		{name: "not implemented", err: &bento.APIError{StatusCode: http.StatusNotImplemented}, want: false},
+		{name: "bad request", err: &bento.APIError{StatusCode: http.StatusBadRequest}, want: false},
+		{name: "unprocessable", err: &bento.APIError{StatusCode: http.StatusUnprocessableEntity}, want: false},
+		{name: "not found", err: &bento.APIError{StatusCode: http.StatusNotFound}, want: false},
+		{name: "circuit open", err: bento.ErrCircuitOpen, want: true},
+		{
+			name: "url error with timeout",
+			err:  fmt.Errorf("request failed: %w", &url.Error{Op: "Post", URL: "https://app.bentonow.com", Err: &timeoutError{}}),
+			want: true,
+		},
+		{
+			name: "url error without timeout",
+			err:  &url.Error{Op: "Post", URL: "https://app.bentonow.com", Err: errors.New("connection refused")},
+			want: false,
+		},
+		{name: "temporary dns failure", err: &net.DNSError{Err: "server misbehaving", Name: "app.bentonow.com", IsTemporary: true}, want: true},
+		{name: "dns timeout", err: &net.DNSError{Err: "i/o timeout", Name: "app.bentonow.com", IsTimeout: true}, want: true},
+		{name: "unknown host", err: &net.DNSError{Err: "no such host", Name: "app.bentonow.com", IsNotFound: true}, want: false},
+		{name: "transport timeout", err: &bento.TransportError{Timeout: true, Err: &timeoutError{}}, want: true},
+		{name: "transport reset", err: &bento.TransportError{Temporary: true, Err: syscall.ECONNRESET}, want: true},
+		{name: "transport failure", err: &bento.TransportError{Err: errors.New("tls: bad certificate")}, want: false},
+		{name: "caller's deadline exceeded", err: context.DeadlineExceeded, want: false},
+		{name: "caller's deadline after retries", err: fmt.Errorf("after 2 attempts: %w", context.DeadlineExceeded), want: false},
+		{name: "client timeout matching deadline exceeded", err: &bento.TransportError{Timeout: true, Err: fmt.Errorf("%w (Client.Timeout exceeded)", context.DeadlineExceeded)}, want: true},
+		{name: "context canceled", err: fmt.Errorf("after 2 attempts: %w", context.Canceled), want: false},
+		{name: "invalid email", err: fmt.Errorf("%w: nope", bento.ErrInvalidEmail), want: false},
+		{name: "invalid request", err: bento.ErrInvalidRequest, want: false},
+		{name: "invalid config", err: bento.ErrInvalidConfig, want: false},
+		{name: "response too large", err: bento.ErrResponseTooLarge, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bento.IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	target := "https://app.bentonow.com/api/v1/fetch/tags"
+
+	tests := []struct {
+		name      string
+		err       error
+		cancel    bool
+		transport bool
+		timeout   bool
+		temporary bool
+		canceled  bool
+	}{
+		{
+			name:      "client timeout",
+			err:       &url.Error{Op: "Get", URL: target, Err: &timeoutError{}},
+			transport: true,
+			timeout:   true,
+			temporary: true,
+		},
+		{
+			name: "connection reset",
+			err: &url.Error{Op: "Get", URL: target, Err: &net.OpError{
+				Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET),
+			}},
+			transport: true,
+			temporary: true,
+		},
+		{
+			name:      "certificate failure",
+			err:       &url.Error{Op: "Get", URL: target, Err: errors.New("tls: bad certificate")},
+			transport: true,
+		},
+		{
+			name:     "context canceled",
+			err:      &url.Error{Op: "Get", URL: target, Err: context.Canceled},
+			cancel:   true,
+			canceled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				if tt.cancel {
+					cancel()
+				}
+				return nil, tt.err
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			_, err = client.GetTags(ctx)
+			var transportErr *bento.TransportError
+			if got := errors.As(err, &transportErr); got != tt.transport {
+				t.Fatalf("expected TransportError %v, got %v", tt.transport, err)
+			}
+			if tt.transport {
+				if transportErr.Timeout != tt.timeout || transportErr.Temporary != tt.temporary {
+					t.Errorf("expected timeout %v and temporary %v, got %+v", tt.timeout, tt.temporary, transportErr)
+				}
+				if bento.CodeOf(err) != bento.CodeNetwork {
+					t.Errorf("expected CodeNetwork, got %s", bento.CodeOf(err))
+				}
+			}
+			if got := errors.Is(err, context.Canceled); got != tt.canceled {
+				t.Errorf("expected context.Canceled %v, got %v", tt.canceled, err)
+			}
+			if want := tt.timeout || tt.temporary; bento.IsRetryable(err) != want {
+				t.Errorf("expected IsRetryable %v for %v", want, err)
+			}
+		})
+	}
+}
+
+func TestTransportErrorRedactsSiteUUID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client, err := bento.NewClient(&bento.Config{
+		PublishableKey: "pc422f7e69255a4bf9c9fafcaac64b14",
+		SecretKey:      "s1803b8d410fd4ca3a7d1d1f5be6d3b6",
+		SiteUUID:       "2103f23614d9877a6b4ee73d28a5c610",
+		BaseURL:        server.URL,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.GetTags(context.Background())
+	var transportErr *bento.TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("expected a TransportError, got %v", err)
+	}
+	if msg := err.Error(); strings.Contains(msg, "2103f23614d9877a6b4ee73d28a5c610") || !strings.Contains(msg, "/fetch/tags") {
+		t.Errorf("expected the endpoint without the site UUID, got %q", msg)
+	}
+}
+
+func TestIsRetryableClientTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := bento.NewClient(&bento.Config{
+		PublishableKey: "pc422f7e69255a4bf9c9fafcaac64b14",
+		SecretKey:      "s1803b8d410fd4ca3a7d1d1f5be6d3b6",
+		SiteUUID:       "2103f23614d9877a6b4ee73d28a5c610",
+		BaseURL:        server.URL,
+		Timeout:        20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.GetTags(context.Background())
+	var transportErr *bento.TransportError
+	if !errors.As(err, &transportErr) || !transportErr.Timeout {
+		t.Fatalf("expected a transport timeout, got %v", err)
+	}
+	if !bento.IsRetryable(err) {
+		t.Errorf("expected Config.Timeout expiring to be retryable, got %v", err)
+	}
+}
+
+// timeoutError is a net.Error that reports a timeout
+type timeoutError struct{}
+
+func (*timeoutError) Error() string   { return "i/o timeout" }
+func (*timeoutError) Timeout() bool   { return true }
+func (*timeoutError) Temporary() bool { return true }
+
+func TestBatchValidationReportsEveryEntry(t *testing.T) {
+	// Entries 1, 3 and 4 are invalid in every batch
+	emails := []string{"a@example.com", "not-an-email", "b@example.com", "also bad", "@example.com"}
+	badIndices := []int{1, 3, 4}
+
+	tests := []struct {
+		name string
+		call func(c *bento.Client, emails []string) error
+	}{
+		{
+			name: "ImportSubscribers",
+			call: func(c *bento.Client, emails []string) error {
+				var subscribers []*bento.SubscriberInput
+				for _, email := range emails {
+					subscribers = append(subscribers, &bento.SubscriberInput{Email: email})
+				}
+				return c.ImportSubscribers(context.Background(), subscribers)
+			},
+		},
+		{
+			name: "TrackEvent",
+			call: func(c *bento.Client, emails []string) error {
+				var events []bento.EventData
+				for _, email := range emails {
+					events = append(events, bento.EventData{Type: "$pageview", Email: email})
+				}
+				return c.TrackEvent(context.Background(), events)
+			},
+		},
+		{
+			name: "CreateEmails",
+			call: func(c *bento.Client, emails []string) error {
+				var batch []bento.EmailData
+				for _, email := range emails {
+					batch = append(batch, bento.EmailData{To: email, From: "sender@example.com", Subject: "Hi", HTMLBody: "<p>Hi</p>"})
+				}
+				_, err := c.CreateEmails(context.Background(), batch)
+				return err
+			},
+		},
+		{
+			name: "SubscriberCommand",
+			call: func(c *bento.Client, emails []string) error {
+				var commands []bento.CommandData
+				for _, email := range emails {
+					commands = append(commands, bento.CommandData{Command: bento.CommandAddTag, Email: email, Query: "vip"})
+				}
+				return c.SubscriberCommand(context.Background(), commands)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				requests++
+				return mockResponse(http.StatusOK, map[string]interface{}{"results": 2, "failed": 0}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			err = tt.call(client, emails)
+			if !errors.Is(err, bento.ErrInvalidEmail) {
+				t.Fatalf("expected ErrInvalidEmail, got %v", err)
+			}
+			if bento.CodeOf(err) != bento.CodeInvalidEmail {
+				t.Errorf("expected CodeInvalidEmail, got %s", bento.CodeOf(err))
+			}
+			for _, i := range badIndices {
+				if want := fmt.Sprintf("entry %d: ", i); !strings.Contains(err.Error(), want) || !strings.Contains(err.Error(), emails[i]) {
+					t.Errorf("expected entry %d (%q) in error, got %v", i, emails[i], err)
+				}
+			}
+			if strings.Contains(err.Error(), "entry 0:") || strings.Contains(err.Error(), "entry 2:") {
+				t.Errorf("expected valid entries to be left out, got %v", err)
+			}
+			if requests != 0 {
+				t.Errorf("expected no request for an invalid batch, got %d", requests)
+			}
+
+			if err := tt.call(client, []string{emails[0], emails[2]}); err != nil {
+				t.Errorf("unexpected error for a valid batch: %v", err)
+			}
+			if requests != 1 {
+				t.Errorf("expected the valid batch to be sent, got %d requests", requests)
+			}
+		})
+	}
+}
+
+func TestPartialFailureError(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		call      func(*bento.Client) error
+	}{
+		{
+			name:      "ImportSubscribers",
+			operation: "import",
+			call: func(c *bento.Client) error {
+				return c.ImportSubscribers(context.Background(), []*bento.SubscriberInput{{Email: "test@example.com"}})
+			},
+		},
+		{
+			name:      "TrackEvent",
+			operation: "event tracking",
+			call: func(c *bento.Client) error {
+				return c.TrackEvent(context.Background(), []bento.EventData{{Type: "$pageview", Email: "test@example.com"}})
+			},
+		},
+		{
+			name:      "SubscriberCommand",
+			operation: "command execution",
+			call: func(c *bento.Client) error {
+				return c.SubscriberCommand(context.Background(), []bento.CommandData{
+					{Command: bento.CommandAddTag, Email: "test@example.com", Query: "vip"},
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				return mockResponse(http.StatusOK, map[string]interface{}{"results": 8, "failed": 2}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			err = tt.call(client)
+			var partialErr *bento.PartialFailureError
+			if !errors.As(err, &partialErr) {
+				t.Fatalf("expected PartialFailureError, got %v", err)
+			}
+			if partialErr.Operation != tt.operation || partialErr.Succeeded != 8 || partialErr.Failed != 2 {
+				t.Errorf("unexpected partial failure: %+v", partialErr)
+			}
+			if !errors.Is(err, bento.ErrPartialFailure) {
+				t.Errorf("expected ErrPartialFailure, got %v", err)
+			}
+			if bento.CodeOf(err) != bento.CodePartialFailure {
+				t.Errorf("expected CodePartialFailure, got %s", bento.CodeOf(err))
+			}
+			if want := tt.operation + " partially failed: 8 succeeded, 2 failed"; err.Error() != want {
+				t.Errorf("expected %q, got %q", want, err.Error())
+			}
+		})
+	}
+}
+
+// TestErrorConstructorsCarryCodes checks the package sources so that every
+// constructed error either wraps a coded sentinel or is given a code explicitly.
+func TestErrorConstructorsCarryCodes(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", file, err)
+		}
+
+		coded := make(map[ast.Expr]bool)
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			if isIdentCall(call, "withCode") || isIdentCall(call, "newError") {
+				for _, arg := range call.Args {
+					coded[arg] = true
+				}
+				return true
+			}
+
+			switch {
+			case isSelectorCall(call, "errors", "New"):
+				if !coded[call] {
+					t.Errorf("%s: errors.New without an error code; use newError or withCode", fset.Position(call.Pos()))
+				}
+			case isSelectorCall(call, "fmt", "Errorf"):
+				if !coded[call] && !wrapsSentinel(call) {
+					t.Errorf("%s: fmt.Errorf neither wraps an Err sentinel nor uses withCode", fset.Position(call.Pos()))
+				}
+			}
+			return true
+		})
+	}
+}
+
+func isIdentCall(call *ast.CallExpr, name string) bool {
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+func isSelectorCall(call *ast.CallExpr, pkg, name string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+// wrapsSentinel reports whether an fmt.Errorf call wraps a package Err sentinel with %w
+func wrapsSentinel(call *ast.CallExpr) bool {
+	if len(call.Args) < 2 {
+		return false
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok {
+		return false
+	}
+	format, err := strconv.Unquote(lit.Value)
+	if err != nil || !strings.Contains(format, "%w") {
+		return false
+	}
+	for _, arg := range call.Args[1:] {
+		if ident, ok := arg.(*ast.Ident); ok && strings.HasPrefix(ident.Name, "Err") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,302 @@
+package bento
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBufferClosed is returned by EventBuffer.Add once the buffer is closed
+var ErrBufferClosed = newError(CodeCanceled, "event buffer closed")
+
+// Defaults for EventBufferOptions
+const (
+	defaultBufferFlushSize     = 100
+	defaultBufferFlushInterval = 5 * time.Second
+	defaultBufferMaxEvents     = 10000
+)
+
+// EventBufferOptions controls an EventBuffer
+type EventBufferOptions struct {
+	// FlushSize is the number of buffered events that triggers a flush.
+	// Defaults to 100.
+	FlushSize int
+
+	// FlushInterval is the longest an event waits before it is flushed.
+	// Defaults to 5 seconds.
+	FlushInterval time.Duration
+
+	// MaxEvents bounds the number of events held in memory. Defaults to
+	// 10000, and is raised to FlushSize if smaller.
+	MaxEvents int
+
+	// DropOldest discards the oldest buffered event to make room when the
+	// buffer is full, instead of blocking Add until a flush frees space
+	DropOldest bool
+
+	// OnError is called from the background goroutine with the events of the
+	// batches of a flush that failed and its error. Events are not retried beyond the
+	// client's own retries.
+	OnError func(events []EventData, err error)
+
+	// Spool, when set, keeps batches that fail with an error IsRetryable
+	// accepts and sends them again before the next flush. Batches that fail
+	// permanently, such as with a 401 or a validation error, are dropped and
+	// reported like any other failure. Call Recover on startup to replay
+	// batches spooled by a previous process.
+	Spool EventSpool
+}
+
+// EventBuffer collects events and sends them with TrackEvent in the
+// background, so callers do not wait on the API. Events are flushed when
+// FlushSize are buffered or FlushInterval has passed, whichever comes first.
+// It is safe for concurrent use. Close flushes what is left.
+type EventBuffer struct {
+	client *Client
+	opts   EventBufferOptions
+
+	mu      sync.Mutex
+	events  []EventData
+	dropped int
+	closed  bool
+	// space is closed and replaced whenever a flush frees room, waking
+	// blocked Add calls
+	space chan struct{}
+
+	// flushMu keeps flushes, and so the order of events, sequential
+	flushMu sync.Mutex
+	full    chan struct{}
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewEventBuffer starts an EventBuffer that tracks events with client
+func NewEventBuffer(client *Client, opts EventBufferOptions) (*EventBuffer, error) {
+	if client == nil {
+		return nil, fmt.Errorf("%w: client is required", ErrInvalidRequest)
+	}
+	if opts.FlushSize < 0 || opts.FlushInterval < 0 || opts.MaxEvents < 0 {
+		return nil, fmt.Errorf("%w: buffer sizes and interval cannot be negative", ErrInvalidRequest)
+	}
+	if opts.FlushSize == 0 {
+		opts.FlushSize = defaultBufferFlushSize
+	}
+	if opts.FlushInterval == 0 {
+		opts.FlushInterval = defaultBufferFlushInterval
+	}
+	if opts.MaxEvents == 0 {
+		opts.MaxEvents = defaultBufferMaxEvents
+	}
+	opts.MaxEvents = max(opts.MaxEvents, opts.FlushSize)
+
+	b := &EventBuffer{
+		client: client,
+		opts:   opts,
+		space:  make(chan struct{}),
+		full:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	b.wg.Add(1)
+	go b.run()
+	return b, nil
+}
+
+// Add validates event and queues it for the next flush. When the buffer is
+// full it blocks until a flush frees space, ctx is done or the buffer is
+// closed, unless DropOldest is set.
+func (b *EventBuffer) Add(ctx context.Context, event EventData) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	event = b.client.normalizeEvents([]EventData{event})[0]
+	if err := b.client.validateEventAt(event, b.client.clock().Now()); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	for !b.closed && len(b.events) >= b.opts.MaxEvents && !b.opts.DropOldest {
+		space := b.space
+		b.mu.Unlock()
+		select {
+		case <-space:
+		case <-b.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		b.mu.Lock()
+	}
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBufferClosed
+	}
+	if len(b.events) >= b.opts.MaxEvents {
+		b.events = b.events[1:]
+		b.dropped++
+	}
+	b.events = append(b.events, event)
+	if len(b.events) >= b.opts.FlushSize {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Len returns the number of events waiting to be flushed
+func (b *EventBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.events)
+}
+
+// Dropped returns the number of events discarded by DropOldest
+func (b *EventBuffer) Dropped() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// Flush sends every buffered event now, in requests of at most
+// BatchLimits.MaxEventsPerRequest, and returns the errors from TrackEvent,
+// which are not passed to OnError
+func (b *EventBuffer) Flush(ctx context.Context) error {
+	_, err := b.flush(ctx)
+	return err
+}
+
+// Recover sends the batches in the spool, such as those left by a previous
+// process, and persists those that fail again. It does nothing without a
+// Spool.
+func (b *EventBuffer) Recover(ctx context.Context) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	if b.opts.Spool == nil {
+		return nil
+	}
+
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+	_, err := b.send(ctx, nil)
+	return err
+}
+
+// Close stops the background goroutine and flushes the remaining events
+// with ctx. Add fails with ErrBufferClosed afterwards. Closing an already
+// closed buffer returns nil.
+func (b *EventBuffer) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.done)
+	b.mu.Unlock()
+
+	b.wg.Wait()
+	return b.Flush(ctx)
+}
+
+// run flushes in the background until the buffer is closed
+func (b *EventBuffer) run() {
+	defer b.wg.Done()
+
+	clock := b.client.clock()
+	tick := clock.After(b.opts.FlushInterval)
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-b.full:
+		case <-tick:
+			tick = clock.After(b.opts.FlushInterval)
+		}
+
+		events, err := b.flush(context.Background())
+		if err != nil && b.opts.OnError != nil {
+			b.opts.OnError(events, err)
+		}
+	}
+}
+
+// flush takes every buffered event and sends it, returning the events of
+// the batches that failed
+func (b *EventBuffer) flush(ctx context.Context) ([]EventData, error) {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	events := b.events
+	b.events = nil
+	close(b.space)
+	b.space = make(chan struct{})
+	b.mu.Unlock()
+
+	return b.send(ctx, events)
+}
+
+// send sends the spooled batches followed by events, one request of at most
+// BatchLimits.MaxEventsPerRequest per batch. With a Spool, the batches that
+// fail are kept unless their events were rejected by the API or by
+// validation, and the spool is only rewritten once every batch has been
+// tried, so a crash during the replay leaves it intact. It returns the
+// events of the failed batches. flushMu must be held.
+func (b *EventBuffer) send(ctx context.Context, events []EventData) ([]EventData, error) {
+	var (
+		spooled [][]EventData
+		loadErr error
+		errs    []error
+	)
+	if b.opts.Spool != nil {
+		spooled, loadErr = b.opts.Spool.Load()
+		if loadErr != nil {
+			errs = append(errs, loadErr)
+		}
+	}
+	batches := append(spooled, splitBatches(events, b.client.eventChunkSize())...)
+
+	var failed []EventData
+	var keep [][]EventData
+	for _, batch := range batches {
+		err := b.client.TrackEvent(ctx, batch)
+		if err == nil {
+			continue
+		}
+		failed = append(failed, batch...)
+		errs = append(errs, err)
+		if IsRetryable(err) {
+			keep = append(keep, batch)
+		}
+	}
+
+	if b.opts.Spool != nil {
+		if err := b.updateSpool(keep, loadErr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 1 {
+		return failed, errs[0]
+	}
+	return failed, errors.Join(errs...)
+}
+
+// updateSpool leaves only the batches in keep in the spool. When the spool
+// could not be loaded its contents are unknown, so keep is appended instead.
+func (b *EventBuffer) updateSpool(keep [][]EventData, loadErr error) error {
+	if loadErr == nil {
+		return b.opts.Spool.Replace(keep)
+	}
+	var errs []error
+	for _, batch := range keep {
+		if err := b.opts.Spool.Persist(batch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
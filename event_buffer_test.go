@@ -0,0 +1,202 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// bufferedBatches serves event requests, sending the emails of each batch on
+// the returned channel and replying with status
+func bufferedBatches(t *testing.T, status int) (chan []string, func(*http.Request) (*http.Response, error)) {
+	batches := make(chan []string, 10)
+	return batches, func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Events []bento.EventData `json:"events"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		var emails []string
+		for _, event := range body.Events {
+			emails = append(emails, event.Email)
+		}
+		batches <- emails
+		if status != http.StatusOK {
+			return mockResponse(status, map[string]string{"error": "boom"}), nil
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": len(emails), "failed": 0}), nil
+	}
+}
+
+func setupEventBuffer(t *testing.T, clock *fakeClock, handler func(*http.Request) (*http.Response, error), opts bento.EventBufferOptions) *bento.EventBuffer {
+	t.Helper()
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+	}, handler)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	buf, err := bento.NewEventBuffer(client, opts)
+	if err != nil {
+		t.Fatalf("failed to create event buffer: %v", err)
+	}
+	t.Cleanup(func() { _ = buf.Close(context.Background()) })
+	return buf
+}
+
+func addEvents(t *testing.T, buf *bento.EventBuffer, from, to int) {
+	t.Helper()
+	for i := from; i < to; i++ {
+		if err := buf.Add(context.Background(), bento.EventData{Type: "$pageview", Email: fmt.Sprintf("user%d@example.com", i)}); err != nil {
+			t.Fatalf("unexpected error adding event %d: %v", i, err)
+		}
+	}
+}
+
+func receiveBatch(t *testing.T, batches <-chan []string) []string {
+	t.Helper()
+	select {
+	case batch := <-batches:
+		return batch
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a flush")
+		return nil
+	}
+}
+
+func TestEventBufferFlushesAtThreshold(t *testing.T) {
+	batches, handler := bufferedBatches(t, http.StatusOK)
+	buf := setupEventBuffer(t, newFakeClock(), handler, bento.EventBufferOptions{FlushSize: 3, FlushInterval: time.Hour})
+
+	addEvents(t, buf, 0, 2)
+	select {
+	case batch := <-batches:
+		t.Fatalf("unexpected flush below the threshold: %v", batch)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	addEvents(t, buf, 2, 3)
+	want := []string{"user0@example.com", "user1@example.com", "user2@example.com"}
+	if got := receiveBatch(t, batches); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestEventBufferFlushesOnInterval(t *testing.T) {
+	clock := newFakeClock()
+	batches, handler := bufferedBatches(t, http.StatusOK)
+	buf := setupEventBuffer(t, clock, handler, bento.EventBufferOptions{FlushSize: 100, FlushInterval: time.Minute})
+
+	addEvents(t, buf, 0, 1)
+	clock.BlockUntil(t, 1)
+	clock.Advance(time.Minute)
+
+	if got := receiveBatch(t, batches); !reflect.DeepEqual(got, []string{"user0@example.com"}) {
+		t.Errorf("expected the buffered event, got %v", got)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected an empty buffer, got %d events", buf.Len())
+	}
+}
+
+func TestEventBufferCloseDrains(t *testing.T) {
+	batches, handler := bufferedBatches(t, http.StatusOK)
+	buf := setupEventBuffer(t, newFakeClock(), handler, bento.EventBufferOptions{FlushSize: 100, FlushInterval: time.Hour})
+
+	addEvents(t, buf, 0, 2)
+	if err := buf.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := receiveBatch(t, batches); len(got) != 2 {
+		t.Errorf("expected both events to be flushed on Close, got %v", got)
+	}
+
+	err := buf.Add(context.Background(), bento.EventData{Type: "$pageview", Email: "late@example.com"})
+	if !errors.Is(err, bento.ErrBufferClosed) {
+		t.Errorf("expected ErrBufferClosed, got %v", err)
+	}
+	if err := buf.Close(context.Background()); err != nil {
+		t.Errorf("expected a second Close to return nil, got %v", err)
+	}
+}
+
+func TestEventBufferOnError(t *testing.T) {
+	_, handler := bufferedBatches(t, http.StatusInternalServerError)
+	failures := make(chan error, 1)
+	var failed []bento.EventData
+	buf := setupEventBuffer(t, newFakeClock(), handler, bento.EventBufferOptions{
+		FlushSize:     1,
+		FlushInterval: time.Hour,
+		OnError: func(events []bento.EventData, err error) {
+			failed = events
+			failures <- err
+		},
+	})
+
+	addEvents(t, buf, 0, 1)
+	select {
+	case err := <-failures:
+		if status, ok := bento.HTTPStatus(err); !ok || status != http.StatusInternalServerError {
+			t.Errorf("expected the 500 to be reported, got %v", err)
+		}
+		if len(failed) != 1 || failed[0].Email != "user0@example.com" {
+			t.Errorf("expected the failed event, got %v", failed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for OnError")
+	}
+}
+
+func TestEventBufferBounded(t *testing.T) {
+	for _, dropOldest := range []bool{true, false} {
+		t.Run(fmt.Sprintf("drop oldest %v", dropOldest), func(t *testing.T) {
+			batches, handler := bufferedBatches(t, http.StatusOK)
+			release := make(chan struct{})
+			blocking := func(req *http.Request) (*http.Response, error) {
+				resp, err := handler(req)
+				<-release
+				return resp, err
+			}
+			buf := setupEventBuffer(t, newFakeClock(), blocking, bento.EventBufferOptions{
+				FlushSize:     2,
+				MaxEvents:     2,
+				FlushInterval: time.Hour,
+				DropOldest:    dropOldest,
+			})
+			defer close(release)
+
+			// The first flush holds two events in flight while the buffer refills
+			addEvents(t, buf, 0, 2)
+			receiveBatch(t, batches)
+			addEvents(t, buf, 2, 4)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			err := buf.Add(ctx, bento.EventData{Type: "$pageview", Email: "user4@example.com"})
+
+			if dropOldest {
+				if err != nil || buf.Dropped() != 1 || buf.Len() != 2 {
+					t.Errorf("expected the oldest event to be dropped, got %v with %d dropped", err, buf.Dropped())
+				}
+				return
+			}
+			if !errors.Is(err, context.DeadlineExceeded) || buf.Len() != 2 {
+				t.Errorf("expected Add to block until the deadline, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewEventBufferValidation(t *testing.T) {
+	if _, err := bento.NewEventBuffer(nil, bento.EventBufferOptions{}); !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest for a nil client, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package bento
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EventBuilder assembles an EventData step by step. Every method returns a
+// new builder and leaves the receiver unchanged, so a partly built event can
+// be reused as a template.
+type EventBuilder struct {
+	event EventData
+}
+
+// NewEvent starts building an event of the given type, such as
+// "$completed_onboarding"
+func NewEvent(eventType string) EventBuilder {
+	return EventBuilder{event: EventData{Type: eventType}}
+}
+
+// Email sets the subscriber the event is tracked for
+func (b EventBuilder) Email(email string) EventBuilder {
+	b.event.Email = email
+	return b
+}
+
+// VisitorUUID attributes the event to an anonymous Bento.js visitor, for
+// events without an email
+func (b EventBuilder) VisitorUUID(uuid string) EventBuilder {
+	b.event.VisitorUUID = uuid
+	return b
+}
+
+// Field sets a custom field on the subscriber
+func (b EventBuilder) Field(key string, value interface{}) EventBuilder {
+	b.event.Fields = withValue(b.event.Fields, key, value)
+	return b
+}
+
+// Detail sets a value in the event's details
+func (b EventBuilder) Detail(key string, value interface{}) EventBuilder {
+	b.event.Details = withValue(b.event.Details, key, value)
+	return b
+}
+
+// At backdates the event to t. A zero t clears the date.
+func (b EventBuilder) At(t time.Time) EventBuilder {
+	if t.IsZero() {
+		b.event.Date = nil
+		return b
+	}
+	b.event.Date = &t
+	return b
+}
+
+// DedupeKey sets the key used by WithEventDedupe and Config.EventDedupe
+func (b EventBuilder) DedupeKey(key string) EventBuilder {
+	b.event.DedupeKey = key
+	return b
+}
+
+// Build returns the event, or every problem found with it joined into one
+// error of *ValidationError values. The type is checked as by TrackEvent,
+// the event needs a valid email or a visitor UUID, and field and detail keys
+// must be set to values that can be encoded as JSON. Checks that depend on
+// the client, Config.StrictEventTypes and the limit on future dates, are
+// made when the event is tracked.
+func (b EventBuilder) Build() (EventData, error) {
+	var errs []error
+	if err := validateEventType(b.event.Type); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateEventIdentity(b.event); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, builtValueErrors("fields", b.event.Fields)...)
+	errs = append(errs, builtValueErrors("details", b.event.Details)...)
+
+	if err := errors.Join(errs...); err != nil {
+		return EventData{}, err
+	}
+	return b.event, nil
+}
+
+// withValue returns a copy of values with key set, leaving values unchanged
+func withValue(values map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(values)+1)
+	for k, v := range values {
+		copied[k] = v
+	}
+	copied[key] = value
+	return copied
+}
+
+// builtValueErrors reports every blank key and every value JSON cannot
+// encode in values, in key order
+func builtValueErrors(name string, values map[string]interface{}) []error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		if strings.TrimSpace(key) == "" {
+			errs = append(errs, invalidField(ErrInvalidRequest, name, key, "key is required"))
+			continue
+		}
+		if path, reason := encodingProblem(reflect.ValueOf(values[key]), name+"."+key, 0); reason != "" {
+			errs = append(errs, invalidField(ErrInvalidRequest, path, "", reason))
+		}
+	}
+	return errs
+}
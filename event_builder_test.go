@@ -0,0 +1,129 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestEventBuilder(t *testing.T) {
+	at := time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)
+	base := bento.NewEvent("$completed_onboarding").Email("user@example.com")
+
+	event, err := base.Field("plan", "pro").Detail("source", "api").At(at).DedupeKey("msg_1").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := bento.EventData{
+		Type:      "$completed_onboarding",
+		Email:     "user@example.com",
+		Fields:    map[string]interface{}{"plan": "pro"},
+		Details:   map[string]interface{}{"source": "api"},
+		Date:      &at,
+		DedupeKey: "msg_1",
+	}
+	if !reflect.DeepEqual(event, want) {
+		t.Errorf("expected %+v, got %+v", want, event)
+	}
+
+	// Chained calls leave the builders they started from unchanged
+	other, err := base.Field("plan", "free").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other.Fields["plan"] != "free" || other.Details != nil || other.Date != nil {
+		t.Errorf("expected the template to be reused unchanged, got %+v", other)
+	}
+	if event.Fields["plan"] != "pro" {
+		t.Errorf("expected the first event to keep its field, got %v", event.Fields)
+	}
+}
+
+func TestEventBuilderValidation(t *testing.T) {
+	_, err := bento.NewEvent("completed onboarding").
+		Email("not-an-email").
+		Field("updates", make(chan int)).
+		Detail("", "blank").
+		Build()
+
+	var fields []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var verr *bento.ValidationError
+		if !errors.As(e, &verr) {
+			t.Fatalf("expected only validation errors, got %v", e)
+		}
+		fields = append(fields, verr.Field)
+	}
+	if want := []string{"type", "email", "fields.updates", "details"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("expected errors for %v, got %v", want, fields)
+	}
+	if !errors.Is(err, bento.ErrInvalidEmail) || !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected the sentinels to match, got %v", err)
+	}
+
+	if _, err := bento.NewEvent("").Email("user@example.com").Build(); !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected a missing type to be rejected, got %v", err)
+	}
+	if _, err := bento.NewEvent(strings.Repeat("a", 101)).Email("user@example.com").Build(); !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected an overlong type to be rejected, got %v", err)
+	}
+	if _, err := bento.NewEvent("completed_onboarding").Email("user@example.com").Build(); err != nil {
+		t.Errorf("expected a custom event type without \"$\" to build, got %v", err)
+	}
+}
+
+func TestEventBuilderRoundTrip(t *testing.T) {
+	var bodies []string
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		bodies = append(bodies, string(body))
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	at := time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)
+	built, err := bento.NewEvent("$purchase").Email("user@example.com").Field("plan", "pro").Detail("total", 4200).At(at).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	literal := bento.EventData{
+		Type:    "$purchase",
+		Email:   "user@example.com",
+		Fields:  map[string]interface{}{"plan": "pro"},
+		Details: map[string]interface{}{"total": 4200},
+		Date:    &at,
+	}
+
+	for _, event := range []bento.EventData{built, literal} {
+		if err := client.TrackEvent(context.Background(), []bento.EventData{event}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if bodies[0] != bodies[1] {
+		t.Errorf("expected identical request bodies:\n%s\n%s", bodies[0], bodies[1])
+	}
+
+	var decoded struct {
+		Events []bento.EventData `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(bodies[0]), &decoded); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+	if got := decoded.Events[0]; got.Type != built.Type || got.Email != built.Email || !got.Date.Equal(at) {
+		t.Errorf("expected the built event to be sent unchanged, got %+v", got)
+	}
+}
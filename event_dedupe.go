@@ -0,0 +1,150 @@
+package bento
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// EventDedupeConfig enables suppression of events whose DedupeKey was sent
+// recently by the same client
+type EventDedupeConfig struct {
+	// TTL is how long a key is remembered after its event is accepted.
+	// Defaults to ten minutes.
+	TTL time.Duration
+	// MaxEntries bounds the number of keys remembered; the least recently
+	// seen key is forgotten when it is full. Defaults to 10000.
+	MaxEntries int
+}
+
+const (
+	defaultEventDedupeTTL        = 10 * time.Minute
+	defaultEventDedupeMaxEntries = 10000
+)
+
+// WithEventDedupe drops events from a TrackEvent batch whose DedupeKey
+// repeats one earlier in the same batch. It is implied by
+// Config.EventDedupe, which also drops keys sent by earlier calls.
+func WithEventDedupe() RequestOption {
+	return func(o *requestOptions) {
+		o.dedupeEvents = true
+	}
+}
+
+// eventDedupeCache is an LRU set of recently accepted event keys with
+// per-entry expiry
+type eventDedupeCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	clock      Clock
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+type eventDedupeEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+func newEventDedupeCache(config *EventDedupeConfig, clock Clock) *eventDedupeCache {
+	cache := &eventDedupeCache{
+		ttl:        config.TTL,
+		maxEntries: config.MaxEntries,
+		clock:      clock,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+	if cache.ttl <= 0 {
+		cache.ttl = defaultEventDedupeTTL
+	}
+	if cache.maxEntries <= 0 {
+		cache.maxEntries = defaultEventDedupeMaxEntries
+	}
+	return cache
+}
+
+// seen reports whether key was remembered and has not expired
+func (dc *eventDedupeCache) seen(key string) bool {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	elem, ok := dc.entries[key]
+	if !ok {
+		return false
+	}
+	if entry := elem.Value.(*eventDedupeEntry); !dc.clock.Now().Before(entry.expiresAt) {
+		dc.order.Remove(elem)
+		delete(dc.entries, key)
+		return false
+	}
+	dc.order.MoveToFront(elem)
+	return true
+}
+
+// remember records the keys of events that were accepted
+func (dc *eventDedupeCache) remember(events []EventData) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	expiresAt := dc.clock.Now().Add(dc.ttl)
+	for _, event := range events {
+		if event.DedupeKey == "" {
+			continue
+		}
+		if elem, ok := dc.entries[event.DedupeKey]; ok {
+			elem.Value.(*eventDedupeEntry).expiresAt = expiresAt
+			dc.order.MoveToFront(elem)
+			continue
+		}
+		dc.entries[event.DedupeKey] = dc.order.PushFront(&eventDedupeEntry{key: event.DedupeKey, expiresAt: expiresAt})
+		for dc.order.Len() > dc.maxEntries {
+			oldest := dc.order.Back()
+			dc.order.Remove(oldest)
+			delete(dc.entries, oldest.Value.(*eventDedupeEntry).key)
+		}
+	}
+}
+
+// dedupeEvents drops events whose DedupeKey repeats within the batch, when
+// requested, or was accepted recently, returning the remaining events with
+// their positions in events, or nil positions when none were dropped. The
+// caller's slice is not modified.
+func (c *Client) dedupeEvents(ctx context.Context, events []EventData) ([]EventData, []int) {
+	withinBatch := c.eventDedupe != nil
+	if o := requestOptionsFrom(ctx); o != nil && o.dedupeEvents {
+		withinBatch = true
+	}
+	if !withinBatch {
+		return events, nil
+	}
+
+	seen := make(map[string]struct{}, len(events))
+	kept := make([]EventData, 0, len(events))
+	positions := make([]int, 0, len(events))
+	for i, event := range events {
+		if key := event.DedupeKey; key != "" {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			if c.eventDedupe != nil && c.eventDedupe.seen(key) {
+				continue
+			}
+		}
+		kept = append(kept, event)
+		positions = append(positions, i)
+	}
+	if len(kept) == len(events) {
+		return events, nil
+	}
+	return kept, positions
+}
+
+// rememberEvents records the keys of accepted events for Config.EventDedupe
+func (c *Client) rememberEvents(events []EventData) {
+	if c.eventDedupe != nil {
+		c.eventDedupe.remember(events)
+	}
+}
@@ -0,0 +1,137 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// keyedEvents builds one event per dedupe key, with the key as the event type
+func keyedEvents(keys ...string) []bento.EventData {
+	events := make([]bento.EventData, len(keys))
+	for i, key := range keys {
+		events[i] = bento.EventData{Type: "$" + key, Email: "test@example.com", DedupeKey: key}
+	}
+	return events
+}
+
+// eventTypes records the event types of each request, replying with status
+func eventTypes(t *testing.T, sent *[][]string, status *int) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Events []map[string]interface{} `json:"events"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		var types []string
+		for _, event := range body.Events {
+			if _, ok := event["DedupeKey"]; ok {
+				t.Error("expected the dedupe key not to be sent")
+			}
+			types = append(types, event["type"].(string))
+		}
+		*sent = append(*sent, types)
+		if *status != http.StatusOK {
+			return mockResponse(*status, map[string]string{"error": "boom"}), nil
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": len(types), "failed": 0}), nil
+	}
+}
+
+func TestTrackEventDedupeWithinBatch(t *testing.T) {
+	var sent [][]string
+	status := http.StatusOK
+	client, err := setupTestClient(eventTypes(t, &sent, &status))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	events := keyedEvents("a", "b", "a", "c", "b")
+	events = append(events, bento.EventData{Type: "$unkeyed", Email: "test@example.com"}, bento.EventData{Type: "$unkeyed", Email: "test@example.com"})
+
+	result, err := client.TrackEventWithResult(context.Background(), events, bento.WithEventDedupe())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := [][]string{{"$a", "$b", "$c", "$unkeyed", "$unkeyed"}}; !reflect.DeepEqual(sent, want) {
+		t.Errorf("expected %v, got %v", want, sent)
+	}
+	if result.Suppressed != 2 || result.Accepted != 5 {
+		t.Errorf("expected 2 suppressed and 5 accepted, got %+v", result)
+	}
+
+	// Without the option every event is sent
+	sent = nil
+	if _, err := client.TrackEventWithResult(context.Background(), keyedEvents("a", "a")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := [][]string{{"$a", "$a"}}; !reflect.DeepEqual(sent, want) {
+		t.Errorf("expected duplicates to be sent without dedupe, got %v", sent)
+	}
+}
+
+func TestTrackEventDedupeAcrossBatches(t *testing.T) {
+	clock := newFakeClock()
+	var sent [][]string
+	status := http.StatusOK
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+		c.EventDedupe = &bento.EventDedupeConfig{TTL: time.Minute}
+	}, eventTypes(t, &sent, &status))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.TrackEventWithResult(ctx, keyedEvents("a", "b")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := client.TrackEventWithResult(ctx, keyedEvents("b", "c"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Suppressed != 1 || !reflect.DeepEqual(sent[1], []string{"$c"}) {
+		t.Errorf("expected b to be suppressed, got %+v and %v", result, sent)
+	}
+
+	// A batch of only recent keys is not sent at all
+	result, err = client.TrackEventWithResult(ctx, keyedEvents("a", "c"))
+	if err != nil || result.Suppressed != 2 || len(sent) != 2 {
+		t.Errorf("expected both events to be suppressed without a request, got %+v, %v and %v", result, err, sent)
+	}
+
+	// Keys expire after the TTL
+	clock.Advance(time.Minute)
+	result, err = client.TrackEventWithResult(ctx, keyedEvents("a"))
+	if err != nil || result.Suppressed != 0 || !reflect.DeepEqual(sent[len(sent)-1], []string{"$a"}) {
+		t.Errorf("expected a to be sent again after expiry, got %+v, %v and %v", result, err, sent)
+	}
+}
+
+func TestTrackEventDedupeFailedSendNotRemembered(t *testing.T) {
+	var sent [][]string
+	status := http.StatusInternalServerError
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.EventDedupe = &bento.EventDedupeConfig{}
+	}, eventTypes(t, &sent, &status))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if err := client.TrackEvent(context.Background(), keyedEvents("a")); err == nil {
+		t.Fatal("expected the 500 to fail the call")
+	}
+
+	status = http.StatusOK
+	result, err := client.TrackEventWithResult(context.Background(), keyedEvents("a"))
+	if err != nil || result.Suppressed != 0 || len(sent) != 2 {
+		t.Errorf("expected the retried event to be sent, got %+v, %v and %v", result, err, sent)
+	}
+}
@@ -0,0 +1,164 @@
+package bento
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// ErrCorruptSpool is passed to FileSpool.OnCorrupt for a line that cannot be read
+var ErrCorruptSpool = newError(CodeDecode, "corrupt spool entry")
+
+// EventSpool stores batches an EventBuffer failed to send so they survive a
+// restart. The buffer replays the batches returned by Load, then calls
+// Replace with those that failed again, so batches stay stored until they
+// are sent. A batch replayed just before a crash may be sent twice.
+//
+// Spooled events are stored as they are sent to Bento, so DedupeKey is not
+// kept, and events without a Date are recorded at the time they are replayed.
+type EventSpool interface {
+	// Persist adds a batch to the spool
+	Persist(batch []EventData) error
+	// Load returns every stored batch, leaving them in the spool
+	Load() ([][]EventData, error)
+	// Replace replaces the stored batches with batches, which may be empty
+	Replace(batches [][]EventData) error
+}
+
+// FileSpool is an EventSpool that appends each batch as a line of JSON to a
+// file. It is safe for concurrent use, but not for use by several processes.
+type FileSpool struct {
+	path string
+
+	// OnCorrupt, when set, is called by Load for each line that cannot be
+	// read, such as one cut short by a crash. The line is skipped and dropped
+	// by the next Replace.
+	OnCorrupt func(line int, err error)
+
+	mu sync.Mutex
+}
+
+// NewFileSpool returns a FileSpool backed by the file at path, which is
+// created on the first Persist
+func NewFileSpool(path string) *FileSpool {
+	return &FileSpool{path: path}
+}
+
+// Persist appends batch to the file and syncs it to disk
+func (s *FileSpool) Persist(batch []EventData) error {
+	lines, err := spoolLines([][]EventData{batch})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	return writeAndClose(f, lines)
+}
+
+// Replace writes batches to a temporary file that is then renamed over the
+// spool, so a crash leaves either the old or the new batches. The file is
+// removed when batches is empty.
+func (s *FileSpool) Replace(batches [][]EventData) error {
+	lines, err := spoolLines(batches)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(batches) == 0 {
+		if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := writeAndClose(f, lines); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// spoolLines encodes each batch as a line of JSON
+func spoolLines(batches [][]EventData) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, batch := range batches {
+		line, err := json.Marshal(batch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: spooling events: %v", ErrInvalidRequest, err)
+		}
+		buf.Write(append(line, '\n'))
+	}
+	return buf.Bytes(), nil
+}
+
+// writeAndClose writes data to f, syncs it to disk and closes it
+func writeAndClose(f *os.File, data []byte) error {
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads every batch in the file without removing it. A missing file
+// holds no batches.
+func (s *FileSpool) Load() ([][]EventData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var batches [][]EventData
+	reader := bufio.NewReader(f)
+	for lines := 1; ; lines++ {
+		line, readErr := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var batch []EventData
+			if err := json.Unmarshal(line, &batch); err != nil {
+				if s.OnCorrupt != nil {
+					s.OnCorrupt(lines, fmt.Errorf("%w: %v", ErrCorruptSpool, err))
+				}
+			} else if len(batch) > 0 {
+				batches = append(batches, batch)
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+
+	return batches, nil
+}
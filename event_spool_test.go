@@ -0,0 +1,178 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestEventBufferSpoolsAndRecovers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	_, unavailable := bufferedBatches(t, http.StatusServiceUnavailable)
+	buf := setupEventBuffer(t, newFakeClock(), unavailable, bento.EventBufferOptions{
+		FlushSize:     100,
+		FlushInterval: time.Hour,
+		Spool:         bento.NewFileSpool(path),
+	})
+	addEvents(t, buf, 0, 2)
+	err := buf.Close(context.Background())
+	if status, ok := bento.HTTPStatus(err); !ok || status != http.StatusServiceUnavailable {
+		t.Fatalf("expected the 503 to be reported, got %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the failed batch to be spooled: %v", err)
+	}
+
+	// A new buffer, as after a restart, replays the spool
+	batches, ok := bufferedBatches(t, http.StatusOK)
+	buf = setupEventBuffer(t, newFakeClock(), ok, bento.EventBufferOptions{
+		FlushSize:     100,
+		FlushInterval: time.Hour,
+		Spool:         bento.NewFileSpool(path),
+	})
+	if err := buf.Recover(context.Background()); err != nil {
+		t.Fatalf("unexpected error recovering: %v", err)
+	}
+	want := []string{"user0@example.com", "user1@example.com"}
+	if got := receiveBatch(t, batches); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v to be replayed, got %v", want, got)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the spool to be deleted, got %v", err)
+	}
+}
+
+func TestEventBufferSpoolSkipsRejectedEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	buf := setupEventBuffer(t, newFakeClock(), func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 0, "failed": 1}), nil
+	}, bento.EventBufferOptions{FlushInterval: time.Hour, Spool: bento.NewFileSpool(path)})
+
+	addEvents(t, buf, 0, 1)
+	if err := buf.Flush(context.Background()); !errors.Is(err, bento.ErrPartialFailure) {
+		t.Fatalf("expected a partial failure, got %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected rejected events not to be spooled, got %v", err)
+	}
+}
+
+func TestEventBufferSpoolDropsPermanentFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	spool := bento.NewFileSpool(path)
+	if err := spool.Persist([]bento.EventData{{Type: "$pageview", Email: "user0@example.com"}}); err != nil {
+		t.Fatalf("unexpected error persisting: %v", err)
+	}
+
+	_, unauthorized := bufferedBatches(t, http.StatusUnauthorized)
+	buf := setupEventBuffer(t, newFakeClock(), unauthorized, bento.EventBufferOptions{FlushInterval: time.Hour, Spool: spool})
+	addEvents(t, buf, 1, 1)
+	if err := buf.Flush(context.Background()); !errors.Is(err, bento.ErrUnauthorized) {
+		t.Fatalf("expected the 401 to be reported, got %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected batches failing with a 401 to be dropped from the spool, got %v", err)
+	}
+}
+
+func TestFileSpoolSkipsCorruptEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	spool := bento.NewFileSpool(path)
+	var corrupt []int
+	spool.OnCorrupt = func(line int, err error) {
+		if !errors.Is(err, bento.ErrCorruptSpool) {
+			t.Errorf("expected ErrCorruptSpool, got %v", err)
+		}
+		corrupt = append(corrupt, line)
+	}
+
+	first := []bento.EventData{{Type: "$pageview", Email: "user0@example.com"}}
+	if err := spool.Persist(first); err != nil {
+		t.Fatalf("unexpected error persisting: %v", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("[{\"type\": \"$pagev\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	second := []bento.EventData{{Type: "$pageview", Email: "user1@example.com"}}
+	if err := spool.Persist(second); err != nil {
+		t.Fatalf("unexpected error persisting: %v", err)
+	}
+
+	batches, err := spool.Load()
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if want := [][]bento.EventData{first, second}; !reflect.DeepEqual(batches, want) {
+		t.Errorf("expected %v, got %v", want, batches)
+	}
+	if !reflect.DeepEqual(corrupt, []int{2}) {
+		t.Errorf("expected line 2 to be reported corrupt, got %v", corrupt)
+	}
+
+	if again, err := spool.Load(); err != nil || !reflect.DeepEqual(again, batches) {
+		t.Errorf("expected Load to leave the batches in the spool, got %v, %v", again, err)
+	}
+	if err := spool.Replace(nil); err != nil {
+		t.Fatalf("unexpected error replacing: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected an emptied spool to be removed, got %v", err)
+	}
+}
+
+func TestEventBufferSpoolSurvivesInterruptedReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	spool := bento.NewFileSpool(path)
+	for _, email := range []string{"user0@example.com", "user1@example.com"} {
+		if err := spool.Persist([]bento.EventData{{Type: "$pageview", Email: email}}); err != nil {
+			t.Fatalf("unexpected error persisting: %v", err)
+		}
+	}
+
+	// The second batch hangs, standing in for a process killed mid-replay
+	started := make(chan struct{})
+	release := make(chan struct{})
+	requests := 0
+	buf := setupEventBuffer(t, newFakeClock(), func(req *http.Request) (*http.Response, error) {
+		requests++
+		if requests == 2 {
+			close(started)
+			<-release
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	}, bento.EventBufferOptions{FlushInterval: time.Hour, Spool: spool})
+
+	recovered := make(chan error, 1)
+	go func() { recovered <- buf.Recover(context.Background()) }()
+
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the replay")
+	}
+	batches, err := bento.NewFileSpool(path).Load()
+	if err != nil || len(batches) != 2 {
+		t.Errorf("expected both batches to stay spooled during the replay, got %v, %v", batches, err)
+	}
+
+	close(release)
+	if err := <-recovered; err != nil {
+		t.Fatalf("unexpected error recovering: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the spool to be deleted after the replay, got %v", err)
+	}
+}
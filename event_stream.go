@@ -0,0 +1,140 @@
+package bento
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EventStreamOptions controls TrackEventStream
+type EventStreamOptions struct {
+	// BatchSize is the number of events sent per request. Defaults to 100.
+	BatchSize int
+
+	// FlushInterval is the longest the first event of a batch waits before
+	// the batch is sent, however small it is. Defaults to 5 seconds.
+	FlushInterval time.Duration
+
+	// OnBatchError is called with the events of a batch whose request failed
+	// and its error. Returning true continues with the next batch; without
+	// it, the stream stops at the first failed batch. Events the API rejects
+	// in an accepted request are counted in the result instead.
+	OnBatchError func(events []EventData, err error) bool
+}
+
+// Defaults for EventStreamOptions
+const (
+	defaultStreamBatchSize     = 100
+	defaultStreamFlushInterval = 5 * time.Second
+)
+
+// TrackEventStream reads events from the channel until it is closed and
+// sends them in batches, returning the results added up across batches.
+// A batch is sent once BatchSize events are read or FlushInterval has passed
+// since its first event. Events are not read while a batch is being sent,
+// so a slow API applies backpressure to the sender.
+//
+// When ctx is done, TrackEventStream returns its error with the events read
+// since the last batch unsent. Failed batches are listed in the result's
+// Failures, with Start counting events from the start of the stream.
+func (c *Client) TrackEventStream(ctx context.Context, events <-chan EventData, opts EventStreamOptions, reqOpts ...RequestOption) (*EventResult, error) {
+	ctx = withRequestOptions(ctx, reqOpts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if events == nil {
+		return nil, fmt.Errorf("%w: event channel is required", ErrInvalidRequest)
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultStreamBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultStreamFlushInterval
+	}
+
+	clock := c.clock()
+	result := &EventResult{Reported: true}
+	var (
+		batch   []EventData
+		flushAt <-chan time.Time
+	)
+
+	// send tracks the current batch, returning an error when the stream
+	// should stop
+	send := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		sent, start := batch, result.Submitted
+		batch, flushAt = nil, nil
+
+		batchResult, err := c.TrackEventWithResult(ctx, sent)
+		result.Submitted += len(sent)
+		if batchResult != nil {
+			result.Accepted += batchResult.Accepted
+			result.Failed += batchResult.Failed
+			result.Suppressed += batchResult.Suppressed
+			result.Reported = result.Reported && batchResult.Reported
+			for _, failure := range batchResult.Rejected {
+				failure.Index += start
+				result.Rejected = append(result.Rejected, failure)
+			}
+		}
+
+		var partial *PartialFailureError
+		if err == nil || (errors.As(err, &partial) && len(batchResult.Failures) == 0) {
+			return nil
+		}
+		batchErr := &EventChunkError{Start: start, Size: len(sent), Err: err}
+		result.Failures = append(result.Failures, batchErr)
+		if ctx.Err() != nil || opts.OnBatchError == nil || !opts.OnBatchError(sent, err) {
+			return batchErr
+		}
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-flushAt:
+			if err := send(); err != nil {
+				return result, err
+			}
+		case event, ok := <-events:
+			if !ok {
+				if err := send(); err != nil {
+					return result, err
+				}
+				return result, streamError(result)
+			}
+			batch = append(batch, event)
+			if len(batch) == 1 {
+				flushAt = clock.After(opts.FlushInterval)
+			}
+			if len(batch) >= opts.BatchSize {
+				if err := send(); err != nil {
+					return result, err
+				}
+			}
+		}
+	}
+}
+
+// streamError reports the batches a finished stream continued past and any
+// events the API rejected
+func streamError(result *EventResult) error {
+	var errs []error
+	for _, failure := range result.Failures {
+		errs = append(errs, failure)
+	}
+	if result.Failed > 0 {
+		errs = append(errs, &PartialFailureError{Operation: "event tracking", Succeeded: result.Accepted, Failed: result.Failed})
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,159 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// feedEvents sends events on a new channel from a goroutine and closes it.
+// The channel is buffered so the goroutine finishes even if the stream stops.
+func feedEvents(events []bento.EventData) <-chan bento.EventData {
+	ch := make(chan bento.EventData, len(events))
+	go func() {
+		defer close(ch)
+		for _, event := range events {
+			ch <- event
+		}
+	}()
+	return ch
+}
+
+func TestTrackEventStream(t *testing.T) {
+	var sizes []int
+	client, err := setupTestClient(eventChunkHandler(t, &sizes, 0, nil))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	result, err := client.TrackEventStream(context.Background(), feedEvents(pageviews(7)), bento.EventStreamOptions{
+		BatchSize:     3,
+		FlushInterval: time.Hour,
+	})
+
+	if want := []int{3, 3, 1}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("expected batches %v, got %v", want, sizes)
+	}
+	// eventChunkHandler rejects one event per request
+	if result == nil || result.Submitted != 7 || result.Accepted != 4 || result.Failed != 3 {
+		t.Fatalf("expected 4 accepted and 3 failed of 7, got %+v", result)
+	}
+	var partial *bento.PartialFailureError
+	if !errors.As(err, &partial) || partial.Failed != 3 {
+		t.Errorf("expected the rejected events to be reported, got %v", err)
+	}
+}
+
+func TestTrackEventStreamFlushInterval(t *testing.T) {
+	clock := newFakeClock()
+	var sizes []int
+	sent := make(chan struct{}, 1)
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+	}, eventChunkHandler(t, &sizes, 0, func() { sent <- struct{}{} }))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	events := make(chan bento.EventData)
+	done := make(chan struct{})
+	var result *bento.EventResult
+	go func() {
+		defer close(done)
+		result, _ = client.TrackEventStream(context.Background(), events, bento.EventStreamOptions{BatchSize: 10, FlushInterval: time.Second})
+	}()
+
+	events <- pageviews(1)[0]
+	clock.BlockUntil(t, 1)
+	clock.Advance(time.Second)
+	select {
+	case <-sent:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the interval flush")
+	}
+
+	close(events)
+	<-done
+	if !reflect.DeepEqual(sizes, []int{1}) || result.Submitted != 1 {
+		t.Errorf("expected one batch of one event, got %v and %+v", sizes, result)
+	}
+}
+
+func TestTrackEventStreamCancellation(t *testing.T) {
+	var sizes []int
+	client, err := setupTestClient(eventChunkHandler(t, &sizes, 0, nil))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan bento.EventData)
+	done := make(chan struct{})
+	var (
+		result    *bento.EventResult
+		streamErr error
+	)
+	go func() {
+		defer close(done)
+		result, streamErr = client.TrackEventStream(ctx, events, bento.EventStreamOptions{BatchSize: 2, FlushInterval: time.Hour})
+	}()
+
+	for _, event := range pageviews(3) {
+		events <- event
+	}
+	cancel()
+	<-done
+
+	if !errors.Is(streamErr, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", streamErr)
+	}
+	if !reflect.DeepEqual(sizes, []int{2}) || result.Submitted != 2 {
+		t.Errorf("expected only the full batch to be sent, got %v and %+v", sizes, result)
+	}
+}
+
+func TestTrackEventStreamOnBatchError(t *testing.T) {
+	tests := []struct {
+		name      string
+		continues bool
+		wantSizes []int
+	}{
+		{name: "stops", wantSizes: []int{2, 2}},
+		{name: "continues", continues: true, wantSizes: []int{2, 2, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sizes []int
+			client, err := setupTestClient(eventChunkHandler(t, &sizes, 2, nil))
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			var failed []bento.EventData
+			result, err := client.TrackEventStream(context.Background(), feedEvents(pageviews(6)), bento.EventStreamOptions{
+				BatchSize:     2,
+				FlushInterval: time.Hour,
+				OnBatchError: func(events []bento.EventData, err error) bool {
+					failed = events
+					return tt.continues
+				},
+			})
+
+			if !reflect.DeepEqual(sizes, tt.wantSizes) {
+				t.Errorf("expected batches %v, got %v", tt.wantSizes, sizes)
+			}
+			var batchErr *bento.EventChunkError
+			if !errors.As(err, &batchErr) || batchErr.Start != 2 || batchErr.Size != 2 {
+				t.Fatalf("expected the second batch to fail, got %v", err)
+			}
+			if len(failed) != 2 || failed[0].Email != "user2@example.com" || len(result.Failures) != 1 {
+				t.Errorf("expected the failed batch to be passed to OnBatchError, got %v and %+v", failed, result)
+			}
+		})
+	}
+}
@@ -3,62 +3,531 @@ package bento
 import (
 	"bytes"
 	"context"
+	"encoding"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"net/mail"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
-// TrackEvent sends tracking events to Bento
-func (c *Client) TrackEvent(ctx context.Context, events []EventData) error {
+// EventResult reports how many events Bento accepted
+type EventResult struct {
+	Accepted int
+	Failed   int
+	// Reported is false when a response did not include the counts, in
+	// which case they are missing from Accepted and Failed
+	Reported bool
+	// Submitted is the number of events, from the start of the slice, in
+	// chunks that were sent before tracking stopped
+	Submitted int
+	// Failures lists every chunk of a chunked call whose request failed
+	Failures []*EventChunkError
+	// Suppressed is the number of events dropped as duplicates by
+	// WithEventDedupe or Config.EventDedupe. Submitted and chunk positions
+	// count only the events that were kept.
+	Suppressed int
+	// Rejected lists the events the API rejected, when the response names
+	// them or Config.BisectEventFailures isolated them
+	Rejected []EventFailure
+}
+
+// EventFailure identifies an event the API rejected
+type EventFailure struct {
+	// Index is the event's position in the slice passed to TrackEvent
+	Index  int
+	Reason string
+}
+
+// EventChunkError describes a chunk of a chunked TrackEvent call whose
+// request failed
+type EventChunkError struct {
+	// Start is the position in the input slice of the chunk's first event
+	Start int
+	Size  int
+	Err   error
+}
+
+func (e *EventChunkError) Error() string {
+	return fmt.Sprintf("event chunk %d-%d: %v", e.Start, e.Start+e.Size-1, e.Err)
+}
+
+func (e *EventChunkError) Unwrap() error { return e.Err }
+
+// TrackEvent sends tracking events to Bento. To record how many events were
+// accepted, use TrackEventWithResult.
+func (c *Client) TrackEvent(ctx context.Context, events []EventData, opts ...RequestOption) error {
+	_, err := c.TrackEventWithResult(ctx, events, opts...)
+	return err
+}
+
+// TrackEventSingle sends one event of the given type for email. The event is
+// validated on its own, so errors carry no batch index, and an event the API
+// rejects fails with ErrAPIResponse instead of a *PartialFailureError.
+func (c *Client) TrackEventSingle(ctx context.Context, eventType, email string, fields map[string]interface{}, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	event := c.normalizeEvents([]EventData{{Type: eventType, Email: email, Fields: fields}})[0]
+	if err := c.validateEventAt(event, c.clock().Now()); err != nil {
+		return err
+	}
+
+	err := c.TrackEvent(ctx, []EventData{event})
+	var partial *PartialFailureError
+	if errors.As(err, &partial) {
+		return withCode(CodeAPIError, fmt.Errorf("%w: %s event for %s was rejected", ErrAPIResponse, event.Type, event.Email))
+	}
+	return err
+}
+
+// TrackEventWithResult sends tracking events to Bento like TrackEvent,
+// returning the counts from the response. On a partial failure the result is
+// returned alongside a *PartialFailureError.
+//
+// Slices larger than BatchLimits.MaxEventsPerRequest are sent as sequential requests
+// with the counts added up. Tracking stops at the first chunk that fails,
+// returning an *EventChunkError, unless Config.ContinueOnEventChunkError is
+// set, and stops with the context's error if it is done between chunks.
+func (c *Client) TrackEventWithResult(ctx context.Context, events []EventData, opts ...RequestOption) (*EventResult, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	if len(events) == 0 {
-		return ErrInvalidRequest
+		return nil, ErrInvalidRequest
+	}
+	if err := c.checkBatchSize(len(events), c.eventChunkSize(), "events"); err != nil {
+		return nil, err
 	}
 
-	// Validate all emails in events before sending
-	for _, event := range events {
-		if _, err := mail.ParseAddress(event.Email); err != nil {
-			return fmt.Errorf("%w: %s", ErrInvalidEmail, event.Email)
+	// Validate all events before sending
+	events = c.normalizeEvents(events)
+	now := c.clock().Now()
+	if err := validateEntries(events, func(event EventData) error {
+		return c.validateEventAt(event, now)
+	}); err != nil {
+		return nil, err
+	}
+	events = preferEmail(c.formatEventFieldTimes(events))
+
+	total := len(events)
+	events, positions := c.dedupeEvents(ctx, events)
+	suppressed := total - len(events)
+	if len(events) == 0 {
+		return &EventResult{Reported: true, Suppressed: suppressed}, nil
+	}
+	// origin maps a position in events back to the caller's slice
+	origin := func(i int) int {
+		if positions == nil {
+			return i
+		}
+		return positions[i]
+	}
+
+	chunkSize := c.eventChunkSize()
+
+	// A batch that fits in one request fails as it always has
+	if len(events) <= chunkSize {
+		result, err := c.trackEventChunk(ctx, events)
+		if err != nil {
+			return nil, err
+		}
+		result.Submitted = len(events)
+		result.Suppressed = suppressed
+		rejected, isolateErr := c.isolateRejected(ctx, events, result)
+		result.Rejected = nil
+		for _, failure := range rejected {
+			failure.Index = origin(failure.Index)
+			result.Rejected = append(result.Rejected, failure)
 		}
-		if event.Type == "" {
-			return fmt.Errorf("%w: event type is required", ErrInvalidRequest)
+		c.rememberAccepted(events, rejected, result.Failed)
+		if result.Failed > 0 {
+			err := &PartialFailureError{Operation: "event tracking", Succeeded: result.Accepted, Failed: result.Failed}
+			if isolateErr != nil {
+				return result, errors.Join(err, isolateErr)
+			}
+			return result, err
 		}
+		return result, nil
 	}
 
+	result := &EventResult{Reported: true, Suppressed: suppressed}
+	var isolateErrs []error
+	for start := 0; start < len(events); start += chunkSize {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		end := min(start+chunkSize, len(events))
+		result.Submitted = end
+		chunk, err := c.trackEventChunk(ctx, events[start:end])
+		if err != nil {
+			chunkErr := &EventChunkError{Start: start, Size: end - start, Err: err}
+			result.Failures = append(result.Failures, chunkErr)
+			if !c.config.ContinueOnEventChunkError || ctx.Err() != nil {
+				return result, chunkErr
+			}
+			continue
+		}
+		result.Accepted += chunk.Accepted
+		result.Failed += chunk.Failed
+		result.Reported = result.Reported && chunk.Reported
+
+		rejected, err := c.isolateRejected(ctx, events[start:end], chunk)
+		if err != nil {
+			isolateErrs = append(isolateErrs, err)
+		}
+		for _, failure := range rejected {
+			failure.Index = origin(start + failure.Index)
+			result.Rejected = append(result.Rejected, failure)
+		}
+		c.rememberAccepted(events[start:end], rejected, chunk.Failed)
+	}
+
+	var errs []error
+	for _, failure := range result.Failures {
+		errs = append(errs, failure)
+	}
+	if result.Failed > 0 {
+		errs = append(errs, &PartialFailureError{Operation: "event tracking", Succeeded: result.Accepted, Failed: result.Failed})
+	}
+	errs = append(errs, isolateErrs...)
+	if len(errs) == 1 {
+		return result, errs[0]
+	}
+	return result, errors.Join(errs...)
+}
+
+// eventChunkSize is the number of events TrackEvent sends per request
+func (c *Client) eventChunkSize() int {
+	return c.batchLimits().MaxEventsPerRequest
+}
+
+// trackEventChunk sends one request of events, returning the counts from
+// the response and any rejected events it lists
+func (c *Client) trackEventChunk(ctx context.Context, events []EventData) (*EventResult, error) {
 	body, err := json.Marshal(map[string]interface{}{
 		"events": events,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
 		fmt.Sprintf("%s/batch/events", c.baseURL), bytes.NewBuffer(body))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	resp, err := c.do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
-	var result struct {
-		Results int `json:"results"`
-		Failed  int `json:"failed"`
+	var response struct {
+		Results *int `json:"results"`
+		Failed  *int `json:"failed"`
+		Errors  []struct {
+			Index int    `json:"index"`
+			Error string `json:"error"`
+		} `json:"errors"`
+	}
+	if err := c.decodeJSON(req, resp, &response, "results"); err != nil {
+		return nil, err
+	}
+
+	result := &EventResult{Reported: response.Results != nil}
+	if response.Results != nil {
+		result.Accepted = *response.Results
+	}
+	if response.Failed != nil {
+		result.Failed = *response.Failed
+	}
+	for _, item := range response.Errors {
+		if item.Index >= 0 && item.Index < len(events) {
+			result.Rejected = append(result.Rejected, EventFailure{Index: item.Index, Reason: item.Error})
+		}
+	}
+	return result, nil
+}
+
+// rejectedByCount is the reason given for events isolated by bisection,
+// where the API reports only how many events it rejected
+const rejectedByCount = "rejected by the API"
+
+// isolateRejected returns the rejected events of a chunk, indexed within it:
+// those the response lists or, with Config.BisectEventFailures, those found
+// by resending halves of the chunk
+func (c *Client) isolateRejected(ctx context.Context, events []EventData, chunk *EventResult) ([]EventFailure, error) {
+	if len(chunk.Rejected) > 0 || chunk.Failed == 0 || !c.config.BisectEventFailures {
+		return chunk.Rejected, nil
+	}
+	rejected, err := c.bisectEvents(ctx, events, 0, chunk.Failed)
+	if err != nil {
+		return rejected, withCode(CodeOf(err), fmt.Errorf("isolating rejected events: %w", err))
+	}
+	return rejected, nil
+}
+
+// bisectEvents splits events, of which failed were rejected, and resends
+// each half until every rejected event is isolated
+func (c *Client) bisectEvents(ctx context.Context, events []EventData, offset, failed int) ([]EventFailure, error) {
+	if failed <= 0 {
+		return nil, nil
+	}
+	var rejected []EventFailure
+	if failed >= len(events) {
+		for i := range events {
+			rejected = append(rejected, EventFailure{Index: offset + i, Reason: rejectedByCount})
+		}
+		return rejected, nil
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+
+	mid := len(events) / 2
+	for _, half := range [][2]int{{0, mid}, {mid, len(events)}} {
+		part := events[half[0]:half[1]]
+		result, err := c.trackEventChunk(ctx, part)
+		if err != nil {
+			return rejected, err
+		}
+		if len(result.Rejected) > 0 {
+			for _, failure := range result.Rejected {
+				failure.Index += offset + half[0]
+				rejected = append(rejected, failure)
+			}
+			continue
+		}
+		found, err := c.bisectEvents(ctx, part, offset+half[0], result.Failed)
+		rejected = append(rejected, found...)
+		if err != nil {
+			return rejected, err
+		}
+	}
+	return rejected, nil
+}
+
+// rememberAccepted records the keys of a chunk's accepted events for
+// Config.EventDedupe, which is only possible when every rejected event is known
+func (c *Client) rememberAccepted(events []EventData, rejected []EventFailure, failed int) {
+	if failed == 0 {
+		c.rememberEvents(events)
+		return
+	}
+	if len(rejected) != failed {
+		return
+	}
+	bad := make(map[int]bool, len(rejected))
+	for _, failure := range rejected {
+		bad[failure.Index] = true
+	}
+	accepted := make([]EventData, 0, len(events)-len(rejected))
+	for i, event := range events {
+		if !bad[i] {
+			accepted = append(accepted, event)
+		}
+	}
+	c.rememberEvents(accepted)
+}
+
+// validateEvent checks a single event before it is sent
+func validateEvent(event EventData) error {
+	if err := validateEventIdentity(event); err != nil {
+		return err
+	}
+	if err := validateEventType(event.Type); err != nil {
+		return err
+	}
+	if err := validateEventValues("fields", event.Fields); err != nil {
 		return err
 	}
+	return validateEventValues("details", event.Details)
+}
 
-	if result.Failed > 0 {
-		return fmt.Errorf("event tracking partially failed: %d succeeded, %d failed", result.Results, result.Failed)
+// maxEventTypeLength is the longest event type accepted
+const maxEventTypeLength = 100
+
+// reservedEventTypes are the "$" event types accepted by Config.StrictEventTypes
+var reservedEventTypes = map[string]bool{
+	EventSubscribe:   true,
+	EventUnsubscribe: true,
+	EventPurchase:    true,
+	EventTag:         true,
+}
+
+// validateEventType rejects blank event types, types containing whitespace
+// and types longer than maxEventTypeLength
+func validateEventType(eventType string) error {
+	switch {
+	case eventType == "":
+		return invalidField(ErrInvalidRequest, "type", "", "event type is required")
+	case strings.IndexFunc(eventType, unicode.IsSpace) >= 0:
+		return invalidField(ErrInvalidRequest, "type", eventType, "event type cannot contain whitespace")
+	case utf8.RuneCountInString(eventType) > maxEventTypeLength:
+		return invalidField(ErrInvalidRequest, "type", eventType, fmt.Sprintf("event type exceeds %d characters", maxEventTypeLength))
+	}
+	return nil
+}
+
+// validateEventAt checks an event before it is sent at now, applying
+// Config.StrictEventTypes
+func (c *Client) validateEventAt(event EventData, now time.Time) error {
+	if err := validateEvent(event); err != nil {
+		return err
+	}
+	if c.config.StrictEventTypes && strings.HasPrefix(event.Type, "$") && !reservedEventTypes[event.Type] {
+		return invalidField(ErrInvalidRequest, "type", event.Type, "unknown reserved event type")
+	}
+	return validateEventDate(event, now)
+}
+
+// validateEventIdentity requires a valid email or, for anonymous visitors,
+// a visitor UUID
+func validateEventIdentity(event EventData) error {
+	if event.Email == "" && strings.TrimSpace(event.VisitorUUID) == "" {
+		return invalidField(ErrInvalidEmail, "email", "", "email or visitor UUID is required")
+	}
+	if event.Email != "" {
+		if _, err := mail.ParseAddress(event.Email); err != nil {
+			return invalidField(ErrInvalidEmail, "email", event.Email, "invalid email")
+		}
+	}
+	return nil
+}
+
+// preferEmail returns events with the visitor UUID dropped from those that
+// also have an email, copying the slice only when one does
+func preferEmail(events []EventData) []EventData {
+	var identified []EventData
+	for i, event := range events {
+		if event.Email == "" || event.VisitorUUID == "" {
+			continue
+		}
+		if identified == nil {
+			identified = append([]EventData(nil), events...)
+		}
+		identified[i].VisitorUUID = ""
+	}
+	if identified == nil {
+		return events
+	}
+	return identified
+}
+
+// maxEventValueDepth bounds how deeply event values are walked, so a map
+// that contains itself is reported instead of recursing forever
+const maxEventValueDepth = 64
+
+// textMarshalerType matches values that encode themselves as strings
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// validateEventValues rejects the first value in values, taken in key
+// order, that JSON cannot encode, naming it by its key path such as
+// "details.cart.items[2].price"
+func validateEventValues(name string, values map[string]interface{}) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if path, reason := encodingProblem(reflect.ValueOf(values[key]), name+"."+key, 0); reason != "" {
+			return invalidField(ErrInvalidRequest, path, "", reason)
+		}
+	}
+	return nil
+}
+
+// encodingProblem walks v and returns the path of the first value JSON
+// cannot encode and why, or an empty reason when all of v can be encoded
+func encodingProblem(v reflect.Value, path string, depth int) (string, string) {
+	if depth > maxEventValueDepth {
+		return path, "value is nested too deeply"
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Type() == timeType ||
+		v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) {
+		return "", ""
 	}
 
+	switch v.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "", ""
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return path, "NaN and infinite numbers cannot be encoded as JSON"
+		}
+		return "", ""
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return "", ""
+		}
+		for i := 0; i < v.Len(); i++ {
+			if p, reason := encodingProblem(v.Index(i), fmt.Sprintf("%s[%d]", path, i), depth+1); reason != "" {
+				return p, reason
+			}
+		}
+		return "", ""
+	case reflect.Map:
+		switch v.Type().Key().Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			if !v.Type().Key().Implements(textMarshalerType) {
+				return path, fmt.Sprintf("%s keys cannot be encoded as JSON", v.Type().Key())
+			}
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, key := range keys {
+			if p, reason := encodingProblem(v.MapIndex(key), fmt.Sprintf("%s.%v", path, key), depth+1); reason != "" {
+				return p, reason
+			}
+		}
+		return "", ""
+	case reflect.Struct:
+		// Struct tags decide what is encoded, so defer to encoding/json
+		if _, err := json.Marshal(v.Interface()); err != nil {
+			return path, err.Error()
+		}
+		return "", ""
+	default:
+		return path, fmt.Sprintf("%s values cannot be encoded as JSON", v.Type())
+	}
+}
+
+// maxEventClockSkew is how far in the future an event's date may be, to
+// allow for clocks that run slightly ahead of Bento's
+const maxEventClockSkew = 5 * time.Minute
+
+// validateEventDate rejects events dated in the future
+func validateEventDate(event EventData, now time.Time) error {
+	if event.Date != nil && event.Date.After(now.Add(maxEventClockSkew)) {
+		return invalidField(ErrInvalidRequest, "date", event.Date.UTC().Format(time.RFC3339), "date is in the future")
+	}
 	return nil
 }
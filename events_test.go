@@ -3,10 +3,15 @@ package bento_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bentonow/bento-golang-sdk"
 )
@@ -306,3 +311,648 @@ func TestTrackEventContextCancellation(t *testing.T) {
 		t.Errorf("expected context.Canceled error, got %v", err)
 	}
 }
+
+func TestTrackEventWithResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		response map[string]interface{}
+		want     bento.EventResult
+		partial  bool
+	}{
+		{
+			name:     "all accepted",
+			response: map[string]interface{}{"results": 2, "failed": 0},
+			want:     bento.EventResult{Accepted: 2, Reported: true, Submitted: 2},
+		},
+		{
+			name:     "partial failure",
+			response: map[string]interface{}{"results": 1, "failed": 1},
+			want:     bento.EventResult{Accepted: 1, Failed: 1, Reported: true, Submitted: 2},
+			partial:  true,
+		},
+		{
+			name:     "counts missing",
+			response: map[string]interface{}{},
+			want:     bento.EventResult{Submitted: 2},
+		},
+	}
+
+	events := []bento.EventData{
+		{Type: "$pageview", Email: "one@example.com"},
+		{Type: "$pageview", Email: "two@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				return mockResponse(http.StatusOK, tt.response), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			result, err := client.TrackEventWithResult(context.Background(), events)
+			var partial *bento.PartialFailureError
+			if errors.As(err, &partial) != tt.partial {
+				t.Fatalf("expected partial failure %v, got %v", tt.partial, err)
+			}
+			if !tt.partial && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result == nil || !reflect.DeepEqual(*result, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, result)
+			}
+		})
+	}
+}
+
+// eventChunkHandler records the size of each event request, failing the
+// request numbered failChunk with a 500 when it is not zero
+func eventChunkHandler(t *testing.T, sizes *[]int, failChunk int, onRequest func()) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Events []bento.EventData `json:"events"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		*sizes = append(*sizes, len(body.Events))
+		if onRequest != nil {
+			onRequest()
+		}
+		if len(*sizes) == failChunk {
+			return mockResponse(http.StatusInternalServerError, map[string]string{"error": "boom"}), nil
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": len(body.Events) - 1, "failed": 1}), nil
+	}
+}
+
+func pageviews(n int) []bento.EventData {
+	events := make([]bento.EventData, n)
+	for i := range events {
+		events[i] = bento.EventData{Type: "$pageview", Email: fmt.Sprintf("user%d@example.com", i)}
+	}
+	return events
+}
+
+func TestTrackEventChunking(t *testing.T) {
+	var sizes []int
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.BatchLimits.MaxEventsPerRequest = 4
+	}, eventChunkHandler(t, &sizes, 0, nil))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	result, err := client.TrackEventWithResult(context.Background(), pageviews(10))
+	if want := []int{4, 4, 2}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("expected chunks %v, got %v", want, sizes)
+	}
+	if result == nil || result.Accepted != 7 || result.Failed != 3 || result.Submitted != 10 || !result.Reported {
+		t.Errorf("expected 7 accepted and 3 failed across all chunks, got %+v", result)
+	}
+	var partial *bento.PartialFailureError
+	if !errors.As(err, &partial) || partial.Succeeded != 7 || partial.Failed != 3 {
+		t.Errorf("expected an aggregated partial failure, got %v", err)
+	}
+}
+
+func TestTrackEventChunkFailure(t *testing.T) {
+	tests := []struct {
+		name          string
+		continueOnErr bool
+		wantSizes     []int
+		wantSubmitted int
+	}{
+		{name: "stops", wantSizes: []int{4, 4}, wantSubmitted: 8},
+		{name: "continues", continueOnErr: true, wantSizes: []int{4, 4, 2}, wantSubmitted: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sizes []int
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.BatchLimits.MaxEventsPerRequest = 4
+				c.ContinueOnEventChunkError = tt.continueOnErr
+			}, eventChunkHandler(t, &sizes, 2, nil))
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			result, err := client.TrackEventWithResult(context.Background(), pageviews(10))
+			if !reflect.DeepEqual(sizes, tt.wantSizes) {
+				t.Errorf("expected chunks %v, got %v", tt.wantSizes, sizes)
+			}
+			var chunkErr *bento.EventChunkError
+			if !errors.As(err, &chunkErr) || chunkErr.Start != 4 || chunkErr.Size != 4 {
+				t.Fatalf("expected the second chunk to fail, got %v", err)
+			}
+			if status, ok := bento.HTTPStatus(err); !ok || status != http.StatusInternalServerError {
+				t.Errorf("expected the chunk's 500, got %v", err)
+			}
+			if result.Submitted != tt.wantSubmitted || len(result.Failures) != 1 {
+				t.Errorf("expected %d submitted and one failure, got %+v", tt.wantSubmitted, result)
+			}
+		})
+	}
+}
+
+func TestTrackEventChunkCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var sizes []int
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.BatchLimits.MaxEventsPerRequest = 4
+	}, eventChunkHandler(t, &sizes, 0, cancel))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	result, err := client.TrackEventWithResult(ctx, pageviews(10))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(sizes) != 1 || result.Submitted != 4 || result.Accepted != 3 {
+		t.Errorf("expected only the first chunk to be sent, got %v and %+v", sizes, result)
+	}
+}
+
+func TestTrackEventDate(t *testing.T) {
+	clock := newFakeClock()
+	past := time.Date(2023, 6, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	future := clock.Now().Add(24 * time.Hour)
+	skewed := clock.Now().Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		date     *time.Time
+		wantDate interface{}
+		wantErr  bool
+	}{
+		{name: "past date", date: &past, wantDate: "2023-06-01T10:00:00Z"},
+		{name: "zero value", date: nil, wantDate: nil},
+		{name: "within clock skew", date: &skewed, wantDate: "2024-01-01T00:01:00Z"},
+		{name: "far future", date: &future, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body struct {
+				Events []map[string]interface{} `json:"events"`
+			}
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.Clock = clock
+			}, func(req *http.Request) (*http.Response, error) {
+				if tt.wantErr {
+					t.Errorf("unexpected request: %s", req.URL)
+				}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			err = client.TrackEvent(context.Background(), []bento.EventData{
+				{Type: "$imported", Email: "test@example.com", Date: tt.date},
+			})
+			if tt.wantErr {
+				var verr *bento.ValidationError
+				if !errors.As(err, &verr) || verr.Field != "date" || !errors.Is(err, bento.ErrInvalidRequest) {
+					t.Errorf("expected a date validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			date, ok := body.Events[0]["date"]
+			if tt.wantDate == nil && ok {
+				t.Errorf("expected date to be omitted, got %v", date)
+			}
+			if tt.wantDate != nil && date != tt.wantDate {
+				t.Errorf("expected date %v, got %v", tt.wantDate, date)
+			}
+		})
+	}
+}
+
+func TestTrackEventValueValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		event  bento.EventData
+		field  string
+		reason string
+	}{
+		{
+			name:   "channel field",
+			event:  bento.EventData{Fields: map[string]interface{}{"plan": "pro", "updates": make(chan int)}},
+			field:  "fields.updates",
+			reason: "chan int values cannot be encoded",
+		},
+		{
+			name: "nested func detail",
+			event: bento.EventData{Details: map[string]interface{}{
+				"cart": map[string]interface{}{"items": []interface{}{"a", func() {}}},
+			}},
+			field:  "details.cart.items[1]",
+			reason: "func() values cannot be encoded",
+		},
+		{
+			name:   "NaN detail",
+			event:  bento.EventData{Details: map[string]interface{}{"score": math.NaN()}},
+			field:  "details.score",
+			reason: "NaN",
+		},
+		{
+			name:   "struct with a channel",
+			event:  bento.EventData{Details: map[string]interface{}{"meta": struct{ C chan int }{}}},
+			field:  "details.meta",
+			reason: "unsupported type",
+		},
+	}
+
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request: %s", req.URL)
+		return mockResponse(http.StatusOK, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bad := tt.event
+			bad.Type, bad.Email = "$pageview", "bad@example.com"
+			events := []bento.EventData{
+				{Type: "$pageview", Email: "one@example.com"},
+				{Type: "$pageview", Email: "two@example.com"},
+				bad,
+			}
+
+			err := client.TrackEvent(context.Background(), events)
+			var verr *bento.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+			if verr.Index != 2 || verr.Field != tt.field || !strings.Contains(verr.Reason, tt.reason) {
+				t.Errorf("expected entry 2 %s to be rejected for %q, got %+v", tt.field, tt.reason, verr)
+			}
+		})
+	}
+}
+
+func TestTrackEventEncodableValues(t *testing.T) {
+	var body struct {
+		Events []map[string]interface{} `json:"events"`
+	}
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	paidAt := time.Date(2024, 3, 15, 10, 30, 0, 0, time.FixedZone("EST", -5*60*60))
+	err = client.TrackEvent(context.Background(), []bento.EventData{{
+		Type:   "$purchase",
+		Email:  "test@example.com",
+		Fields: map[string]interface{}{"plan": "pro", "seats": 3, "tags": []string{"a", "b"}},
+		Details: map[string]interface{}{
+			"paid_at": paidAt,
+			"cart":    map[string]interface{}{"items": []map[string]int{{"quantity": 1}}},
+			"meta":    struct{ Source string }{"web"},
+			"raw":     []byte("ok"),
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	details := body.Events[0]["details"].(map[string]interface{})
+	if details["paid_at"] != "2024-03-15T15:30:00Z" {
+		t.Errorf("expected paid_at in UTC, got %v", details["paid_at"])
+	}
+}
+
+func TestTrackEventSingle(t *testing.T) {
+	var body map[string]interface{}
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.TrackEventSingle(context.Background(), "$signup", "test@example.com", map[string]interface{}{"plan": "pro"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"events": []interface{}{map[string]interface{}{
+			"type":   "$signup",
+			"email":  "test@example.com",
+			"fields": map[string]interface{}{"plan": "pro"},
+		}},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("unexpected request body:\n got %v\nwant %v", body, want)
+	}
+}
+
+func TestTrackEventSingleErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		email     string
+		reply     *http.Response
+		wantErr   error
+	}{
+		{name: "invalid email", eventType: "$signup", email: "invalid", wantErr: bento.ErrInvalidEmail},
+		{name: "missing type", eventType: "", email: "test@example.com", wantErr: bento.ErrInvalidRequest},
+		{
+			name:      "rejected",
+			eventType: "$signup",
+			email:     "test@example.com",
+			reply:     mockResponse(http.StatusOK, map[string]interface{}{"results": 0, "failed": 1}),
+			wantErr:   bento.ErrAPIResponse,
+		},
+		{
+			name:      "server error",
+			eventType: "$signup",
+			email:     "test@example.com",
+			reply:     mockResponse(http.StatusUnauthorized, map[string]string{"error": "nope"}),
+			wantErr:   bento.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				if tt.reply == nil {
+					t.Errorf("unexpected request: %s", req.URL)
+					return mockResponse(http.StatusOK, nil), nil
+				}
+				return tt.reply, nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			err = client.TrackEventSingle(context.Background(), tt.eventType, tt.email, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			var partial *bento.PartialFailureError
+			if errors.As(err, &partial) {
+				t.Errorf("expected no *PartialFailureError for a single event, got %v", err)
+			}
+			var verr *bento.ValidationError
+			if errors.As(err, &verr) && verr.Index != -1 {
+				t.Errorf("expected no batch index, got %d", verr.Index)
+			}
+		})
+	}
+}
+
+func TestTrackEventRejectedFromResponse(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusOK, map[string]interface{}{
+			"results": 1,
+			"failed":  1,
+			"errors":  []map[string]interface{}{{"index": 1, "error": "unknown event type"}},
+		}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	// The duplicate is dropped, so the second event sent is the third given
+	events := []bento.EventData{
+		{Type: "$ok", Email: "test@example.com", DedupeKey: "a"},
+		{Type: "$ok", Email: "test@example.com", DedupeKey: "a"},
+		{Type: "$bad", Email: "test@example.com", DedupeKey: "b"},
+	}
+	result, err := client.TrackEventWithResult(context.Background(), events, bento.WithEventDedupe())
+
+	var partial *bento.PartialFailureError
+	if !errors.As(err, &partial) {
+		t.Fatalf("expected a partial failure, got %v", err)
+	}
+	want := []bento.EventFailure{{Index: 2, Reason: "unknown event type"}}
+	if !reflect.DeepEqual(result.Rejected, want) {
+		t.Errorf("expected %v, got %v", want, result.Rejected)
+	}
+}
+
+// rejectingEvents counts only, rejecting every $bad event in each request
+func rejectingEvents(t *testing.T, requests *int) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		*requests++
+		var body struct {
+			Events []bento.EventData `json:"events"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		failed := 0
+		for _, event := range body.Events {
+			if event.Type == "$bad" {
+				failed++
+			}
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": len(body.Events) - failed, "failed": failed}), nil
+	}
+}
+
+func TestTrackEventBisection(t *testing.T) {
+	events := pageviews(8)
+	events[2].Type = "$bad"
+	events[5].Type = "$bad"
+
+	tests := []struct {
+		name         string
+		bisect       bool
+		wantRejected []bento.EventFailure
+		wantRequests int
+	}{
+		{name: "counts only", bisect: false, wantRequests: 1},
+		{
+			name:   "bisection",
+			bisect: true,
+			wantRejected: []bento.EventFailure{
+				{Index: 2, Reason: "rejected by the API"},
+				{Index: 5, Reason: "rejected by the API"},
+			},
+			// 8 -> 4+4 -> 2+2 on each side -> 1+1 for each failing pair
+			wantRequests: 1 + 2 + 4 + 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.BisectEventFailures = tt.bisect
+			}, rejectingEvents(t, &requests))
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			result, err := client.TrackEventWithResult(context.Background(), events)
+			var partial *bento.PartialFailureError
+			if !errors.As(err, &partial) || partial.Failed != 2 {
+				t.Fatalf("expected a partial failure of 2, got %v", err)
+			}
+			if !reflect.DeepEqual(result.Rejected, tt.wantRejected) {
+				t.Errorf("expected %v, got %v", tt.wantRejected, result.Rejected)
+			}
+			if result.Accepted != 6 || requests != tt.wantRequests {
+				t.Errorf("expected 6 accepted in %d requests, got %d in %d", tt.wantRequests, result.Accepted, requests)
+			}
+		})
+	}
+}
+
+func TestTrackEventVisitorUUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   bento.EventData
+		want    map[string]interface{}
+		wantErr error
+	}{
+		{
+			name:  "visitor UUID only",
+			event: bento.EventData{Type: "$view", VisitorUUID: "visitor-123"},
+			want:  map[string]interface{}{"type": "$view", "visitor_uuid": "visitor-123"},
+		},
+		{
+			name:  "email only",
+			event: bento.EventData{Type: "$view", Email: "test@example.com"},
+			want:  map[string]interface{}{"type": "$view", "email": "test@example.com"},
+		},
+		{
+			name:  "both prefers email",
+			event: bento.EventData{Type: "$view", Email: "test@example.com", VisitorUUID: "visitor-123"},
+			want:  map[string]interface{}{"type": "$view", "email": "test@example.com"},
+		},
+		{
+			name:    "neither",
+			event:   bento.EventData{Type: "$view", VisitorUUID: " "},
+			wantErr: bento.ErrInvalidEmail,
+		},
+		{
+			name:    "invalid email with visitor UUID",
+			event:   bento.EventData{Type: "$view", Email: "invalid", VisitorUUID: "visitor-123"},
+			wantErr: bento.ErrInvalidEmail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body struct {
+				Events []map[string]interface{} `json:"events"`
+			}
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				if tt.wantErr != nil {
+					t.Errorf("unexpected request: %s", req.URL)
+				}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			events := []bento.EventData{tt.event}
+			err = client.TrackEvent(context.Background(), events)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !reflect.DeepEqual(body.Events[0], tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, body.Events[0])
+			}
+			if events[0].VisitorUUID != tt.event.VisitorUUID {
+				t.Error("expected the caller's events to be left unchanged")
+			}
+		})
+	}
+}
+
+func TestReservedEventTypes(t *testing.T) {
+	reserved := map[string]string{
+		bento.EventSubscribe:   "$subscribe",
+		bento.EventUnsubscribe: "$unsubscribe",
+		bento.EventPurchase:    "$purchase",
+		bento.EventTag:         "$tag",
+	}
+	for got, want := range reserved {
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
+
+func TestTrackEventTypeValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		strict    bool
+		wantErr   bool
+	}{
+		{name: "reserved type", eventType: bento.EventPurchase},
+		{name: "reserved type strict", eventType: bento.EventPurchase, strict: true},
+		{name: "custom type", eventType: "completed_onboarding"},
+		{name: "custom type strict", eventType: "completed_onboarding", strict: true},
+		{name: "unknown $ type", eventType: "$puchase"},
+		{name: "unknown $ type strict", eventType: "$puchase", strict: true, wantErr: true},
+		{name: "whitespace", eventType: "completed onboarding", wantErr: true},
+		{name: "whitespace strict", eventType: "$tag\t", strict: true, wantErr: true},
+		{name: "too long", eventType: strings.Repeat("a", 101), wantErr: true},
+		{name: "at length limit", eventType: strings.Repeat("a", 100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClientWithConfig(func(config *bento.Config) {
+				config.StrictEventTypes = tt.strict
+			}, func(req *http.Request) (*http.Response, error) {
+				if tt.wantErr {
+					t.Errorf("unexpected request: %s", req.URL)
+				}
+				return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			err = client.TrackEvent(context.Background(), []bento.EventData{{Type: tt.eventType, Email: "test@example.com"}})
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var validationErr *bento.ValidationError
+			if !errors.Is(err, bento.ErrInvalidRequest) || !errors.As(err, &validationErr) || validationErr.Field != "type" {
+				t.Errorf("expected a type validation error, got %v", err)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package bento
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -10,7 +9,12 @@ import (
 )
 
 // GetBlacklistStatus checks domain or IP address blacklist status
-func (c *Client) GetBlacklistStatus(ctx context.Context, data *BlacklistData) (map[string]interface{}, error) {
+func (c *Client) GetBlacklistStatus(ctx context.Context, data *BlacklistData, opts ...RequestOption) (map[string]interface{}, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	if data.Domain == "" && data.IPAddress == "" {
 		return nil, fmt.Errorf("%w: either domain or IP address is required", ErrInvalidRequest)
 	}
@@ -43,11 +47,11 @@ func (c *Client) GetBlacklistStatus(ctx context.Context, data *BlacklistData) (m
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.decodeJSON(req, resp, &result); err != nil {
 		return nil, err
 	}
 
@@ -55,7 +59,12 @@ func (c *Client) GetBlacklistStatus(ctx context.Context, data *BlacklistData) (m
 }
 
 // ValidateEmail validates an email address
-func (c *Client) ValidateEmail(ctx context.Context, data *ValidationData) (*ValidationResponse, error) {
+func (c *Client) ValidateEmail(ctx context.Context, data *ValidationData, opts ...RequestOption) (*ValidationResponse, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	if _, err := mail.ParseAddress(data.EmailAddress); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrInvalidEmail, data.EmailAddress)
 	}
@@ -66,6 +75,12 @@ func (c *Client) ValidateEmail(ctx context.Context, data *ValidationData) (*Vali
 		}
 	}
 
+	if c.validationCache != nil && !data.SkipCache {
+		if cached, ok := c.validationCache.get(data.EmailAddress); ok {
+			return cached, nil
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
 		fmt.Sprintf("%s/experimental/validation", c.baseURL), nil)
 	if err != nil {
@@ -92,19 +107,52 @@ func (c *Client) ValidateEmail(ctx context.Context, data *ValidationData) (*Vali
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result ValidationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := c.decodeJSON(req, resp, &result); err != nil {
+		return nil, err
+	}
+
+	if c.validationCache != nil {
+		c.validationCache.set(data.EmailAddress, &result)
 	}
 
 	return &result, nil
 }
 
+// ValidateEmails validates several email addresses, consulting the validation
+// cache for each one. It stops at the first error.
+func (c *Client) ValidateEmails(ctx context.Context, data []*ValidationData, opts ...RequestOption) ([]*ValidationResponse, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, ErrInvalidRequest
+	}
+
+	results := make([]*ValidationResponse, 0, len(data))
+	for _, d := range data {
+		result, err := c.ValidateEmail(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
 // GetContentModeration performs content moderation
-func (c *Client) GetContentModeration(ctx context.Context, content string) (map[string]interface{}, error) {
+func (c *Client) GetContentModeration(ctx context.Context, content string, opts ...RequestOption) (map[string]interface{}, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	if content == "" {
 		return nil, fmt.Errorf("%w: content is required", ErrInvalidContent)
 	}
@@ -126,11 +174,11 @@ func (c *Client) GetContentModeration(ctx context.Context, content string) (map[
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.decodeJSON(req, resp, &result); err != nil {
 		return nil, err
 	}
 
@@ -138,7 +186,12 @@ func (c *Client) GetContentModeration(ctx context.Context, content string) (map[
 }
 
 // GetGender predicts gender from a name
-func (c *Client) GetGender(ctx context.Context, fullName string) (map[string]interface{}, error) {
+func (c *Client) GetGender(ctx context.Context, fullName string, opts ...RequestOption) (map[string]interface{}, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	if fullName == "" {
 		return nil, fmt.Errorf("%w: full name is required", ErrInvalidName)
 	}
@@ -160,11 +213,11 @@ func (c *Client) GetGender(ctx context.Context, fullName string) (map[string]int
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.decodeJSON(req, resp, &result); err != nil {
 		return nil, err
 	}
 
@@ -172,7 +225,12 @@ func (c *Client) GetGender(ctx context.Context, fullName string) (map[string]int
 }
 
 // GeoLocateIP performs IP geolocation
-func (c *Client) GeoLocateIP(ctx context.Context, ipAddress string) (map[string]interface{}, error) {
+func (c *Client) GeoLocateIP(ctx context.Context, ipAddress string, opts ...RequestOption) (map[string]interface{}, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	if ip := net.ParseIP(ipAddress); ip == nil {
 		return nil, fmt.Errorf("%w: %s", ErrInvalidIPAddress, ipAddress)
 	}
@@ -194,11 +252,11 @@ func (c *Client) GeoLocateIP(ctx context.Context, ipAddress string) (map[string]
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.decodeJSON(req, resp, &result); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,76 @@
+package bento
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ExportOptions controls ExportSubscribers
+type ExportOptions struct {
+	// Query, when set, exports only the subscribers it matches. Its
+	// ListSubscribersOptions select the first page and the page size.
+	Query *SubscriberQuery
+
+	// Limit stops the export after this many subscribers. Disabled when zero.
+	Limit int
+}
+
+// ExportSubscribers writes every subscriber to w as JSON Lines, one
+// SubscriberData object per line, fetching one page at a time so memory use
+// does not grow with the size of the site. If w has a Flush method, such as
+// a *bufio.Writer, it is flushed after every page.
+//
+// It returns the number of subscribers written. When the export fails part
+// way, that count covers the lines already written and the error says where
+// the export stopped.
+func (c *Client) ExportSubscribers(ctx context.Context, w io.Writer, opts ExportOptions, reqOpts ...RequestOption) (int, error) {
+	ctx = withRequestOptions(ctx, reqOpts)
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	if opts.Limit < 0 {
+		return 0, fmt.Errorf("%w: limit must be non-negative", ErrInvalidRequest)
+	}
+
+	it := c.Subscribers(ctx, nil)
+	if opts.Query != nil {
+		query := *opts.Query
+		it.query = &query
+		it.opts = query.ListSubscribersOptions
+	}
+	flusher, _ := w.(interface{ Flush() error })
+
+	encoder := json.NewEncoder(w)
+	written := 0
+	for (opts.Limit == 0 || written < opts.Limit) && it.Next() {
+		if err := encoder.Encode(it.Subscriber()); err != nil {
+			return written, exportError(written, err)
+		}
+		written++
+
+		// Flush at the end of every page
+		if flusher != nil && it.index == len(it.page.Subscribers) {
+			if err := flusher.Flush(); err != nil {
+				return written, exportError(written, err)
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return written, exportError(written, err)
+	}
+	if flusher != nil {
+		if err := flusher.Flush(); err != nil {
+			return written, exportError(written, err)
+		}
+	}
+
+	return written, nil
+}
+
+// exportError reports how many subscribers were written before err stopped an export
+func exportError(written int, err error) error {
+	return withCode(CodeOf(err), fmt.Errorf("export stopped after %d subscribers: %w", written, err))
+}
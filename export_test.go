@@ -0,0 +1,114 @@
+package bento_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestExportSubscribers(t *testing.T) {
+	var requested []string
+	client, err := setupTestClient(pagedSubscribers(t, 0, &requested))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := client.ExportSubscribers(context.Background(), &buf, bento.ExportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if n != 6 || len(lines) != 6 {
+		t.Fatalf("expected 6 rows, got %d and %d lines", n, len(lines))
+	}
+	for i, line := range lines {
+		var sub bento.SubscriberData
+		if err := json.Unmarshal([]byte(line), &sub); err != nil {
+			t.Errorf("line %d is not valid JSON: %v", i+1, err)
+		}
+		if sub.ID == "" {
+			t.Errorf("line %d has no subscriber ID: %s", i+1, line)
+		}
+	}
+	if want := []string{"1", "2", "3"}; !reflect.DeepEqual(requested, want) {
+		t.Errorf("expected pages %v, got %v", want, requested)
+	}
+}
+
+func TestExportSubscribersLimit(t *testing.T) {
+	var requested []string
+	client, err := setupTestClient(pagedSubscribers(t, 0, &requested))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := client.ExportSubscribers(context.Background(), &buf, bento.ExportOptions{Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 || strings.Count(buf.String(), "\n") != 3 {
+		t.Errorf("expected 3 rows, got %d:\n%s", n, buf.String())
+	}
+	if len(requested) != 2 {
+		t.Errorf("expected the export to stop after page 2, got %v", requested)
+	}
+}
+
+func TestExportSubscribersQuery(t *testing.T) {
+	var query url.Values
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		query = req.URL.Query()
+		return mockResponse(http.StatusOK, map[string]interface{}{
+			"data": []interface{}{map[string]interface{}{"id": "sub_1", "type": "subscriber"}},
+		}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	opts := bento.ExportOptions{Query: &bento.SubscriberQuery{
+		TagName:                "customer",
+		ListSubscribersOptions: bento.ListSubscribersOptions{PerPage: 50},
+	}}
+	var buf bytes.Buffer
+	if _, err := client.ExportSubscribers(context.Background(), &buf, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query.Get("tag_name") != "customer" || query.Get("per_page") != "50" {
+		t.Errorf("expected the query filters, got %s", query.Encode())
+	}
+}
+
+func TestExportSubscribersError(t *testing.T) {
+	var requested []string
+	client, err := setupTestClient(pagedSubscribers(t, 3, &requested))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	n, err := client.ExportSubscribers(context.Background(), w, bento.ExportOptions{})
+	if !errors.Is(err, bento.ErrAPIResponse) || !strings.Contains(err.Error(), "after 4 subscribers") {
+		t.Errorf("expected the API error and the rows written, got %v", err)
+	}
+	if n != 4 {
+		t.Errorf("expected 4 rows before the failure, got %d", n)
+	}
+	// Pages written before the failure were flushed
+	if strings.Count(buf.String(), "\n") != 4 {
+		t.Errorf("expected 4 flushed lines, got %q", buf.String())
+	}
+}
@@ -0,0 +1,95 @@
+package bento
+
+import "time"
+
+// formatFieldTime renders a time.Time custom field value in UTC, using
+// Config.FieldTimeLayout or RFC 3339
+func (c *Client) formatFieldTime(t time.Time) string {
+	if c.config.FieldTimeLayout != "" {
+		return t.UTC().Format(c.config.FieldTimeLayout)
+	}
+	return formatTime(t)
+}
+
+// formatTimeFields returns a copy of fields with time.Time and *time.Time
+// values formatted as strings, or nil when there are none
+func (c *Client) formatTimeFields(fields map[string]interface{}) map[string]interface{} {
+	var formatted map[string]interface{}
+	for key, value := range fields {
+		var t time.Time
+		switch v := value.(type) {
+		case time.Time:
+			t = v
+		case *time.Time:
+			if v == nil {
+				continue
+			}
+			t = *v
+		default:
+			continue
+		}
+		if formatted == nil {
+			formatted = make(map[string]interface{}, len(fields))
+			for k, v := range fields {
+				formatted[k] = v
+			}
+		}
+		formatted[key] = c.formatFieldTime(t)
+	}
+	return formatted
+}
+
+// formatFieldTimes returns subscribers with time field values formatted.
+// Subscribers without times are passed through as they are; the others are
+// copied rather than modifying the caller's.
+func (c *Client) formatFieldTimes(subscribers []*SubscriberInput) []*SubscriberInput {
+	var formatted []*SubscriberInput
+	for i, sub := range subscribers {
+		fields := c.formatTimeFields(sub.Fields)
+		if fields == nil {
+			continue
+		}
+		if formatted == nil {
+			formatted = append([]*SubscriberInput(nil), subscribers...)
+		}
+		copied := *sub
+		copied.Fields = fields
+		formatted[i] = &copied
+	}
+	if formatted == nil {
+		return subscribers
+	}
+	return formatted
+}
+
+// formatEventFieldTimes returns events with time values in Fields and the
+// top level of Details formatted and dates in UTC, copying the slice only
+// when an event needs any of them
+func (c *Client) formatEventFieldTimes(events []EventData) []EventData {
+	var formatted []EventData
+	for i, event := range events {
+		fields := c.formatTimeFields(event.Fields)
+		details := c.formatTimeFields(event.Details)
+		utcDate := event.Date != nil && event.Date.Location() != time.UTC
+		if fields == nil && details == nil && !utcDate {
+			continue
+		}
+		if formatted == nil {
+			formatted = append([]EventData(nil), events...)
+		}
+		if fields != nil {
+			formatted[i].Fields = fields
+		}
+		if details != nil {
+			formatted[i].Details = details
+		}
+		if utcDate {
+			date := event.Date.UTC()
+			formatted[i].Date = &date
+		}
+	}
+	if formatted == nil {
+		return events
+	}
+	return formatted
+}
@@ -0,0 +1,97 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestFieldTimes(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*3600)
+	trialEnds := time.Date(2024, 7, 1, 0, 30, 0, 0, berlin)
+	signedUp := time.Date(2024, 3, 15, 10, 30, 45, 999, time.UTC)
+
+	tests := []struct {
+		name      string
+		layout    string
+		wantTrial string
+		wantSign  string
+	}{
+		{name: "RFC 3339 in UTC", wantTrial: "2024-06-30T22:30:00Z", wantSign: "2024-03-15T10:30:45Z"},
+		{name: "date only", layout: time.DateOnly, wantTrial: "2024-06-30", wantSign: "2024-03-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []map[string][]struct {
+				Fields map[string]interface{} `json:"fields"`
+			}
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.FieldTimeLayout = tt.layout
+			}, func(req *http.Request) (*http.Response, error) {
+				var body map[string][]struct {
+					Fields map[string]interface{} `json:"fields"`
+				}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				bodies = append(bodies, body)
+				return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			fields := map[string]interface{}{"trial_ends_at": trialEnds, "signed_up_at": &signedUp, "plan": "pro"}
+			if err := client.ImportSubscribers(context.Background(), []*bento.SubscriberInput{{Email: "test@example.com", Fields: fields}}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := client.TrackEvent(context.Background(), []bento.EventData{{Type: "$trial", Email: "test@example.com", Fields: fields}}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(bodies) != 2 {
+				t.Fatalf("expected 2 requests, got %d", len(bodies))
+			}
+			for i, sent := range []map[string]interface{}{bodies[0]["subscribers"][0].Fields, bodies[1]["events"][0].Fields} {
+				if sent["trial_ends_at"] != tt.wantTrial || sent["signed_up_at"] != tt.wantSign || sent["plan"] != "pro" {
+					t.Errorf("request %d: unexpected fields %v", i, sent)
+				}
+			}
+			if _, ok := fields["trial_ends_at"].(time.Time); !ok {
+				t.Error("expected the caller's fields to be left alone")
+			}
+		})
+	}
+}
+
+func TestSubscriberAttributesFieldTime(t *testing.T) {
+	var sub bento.SubscriberData
+	data := `{"id":"sub_1","attributes":{"fields":{"trial_ends_at":"2024-06-30T22:30:00Z","renews_on":"2024-07-01","plan":"pro","seats":5}}}`
+	if err := json.NewDecoder(strings.NewReader(data)).Decode(&sub); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	tests := []struct {
+		key    string
+		want   time.Time
+		wantOK bool
+	}{
+		{key: "trial_ends_at", want: time.Date(2024, 6, 30, 22, 30, 0, 0, time.UTC), wantOK: true},
+		{key: "renews_on", want: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), wantOK: true},
+		{key: "plan"},
+		{key: "seats"},
+		{key: "missing"},
+	}
+	for _, tt := range tests {
+		got, ok := sub.Attributes.FieldTime(tt.key)
+		if ok != tt.wantOK || !got.Equal(tt.want) {
+			t.Errorf("FieldTime(%q) = %v, %v; want %v, %v", tt.key, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
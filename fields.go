@@ -9,7 +9,12 @@ import (
 )
 
 // GetFields retrieves all custom fields
-func (c *Client) GetFields(ctx context.Context) ([]FieldData, error) {
+func (c *Client) GetFields(ctx context.Context, opts ...RequestOption) ([]FieldData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
 		fmt.Sprintf("%s/fetch/fields", c.baseURL), nil)
 	if err != nil {
@@ -23,19 +28,30 @@ func (c *Client) GetFields(ctx context.Context) ([]FieldData, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result FieldsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := c.decodeJSON(req, resp, &result, "data"); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(result.Data))
+	for _, field := range result.Data {
+		keys = append(keys, field.Attributes.Key)
 	}
+	c.fieldCache.replace(keys, c.clock().Now())
 
 	return result.Data, nil
 }
 
 // CreateField creates a new custom field
-func (c *Client) CreateField(ctx context.Context, key string) (*FieldData, error) {
+func (c *Client) CreateField(ctx context.Context, key string, opts ...RequestOption) (*FieldData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	if key == "" {
 		return nil, fmt.Errorf("%w: field key is required", ErrInvalidRequest)
 	}
@@ -62,15 +78,49 @@ func (c *Client) CreateField(ctx context.Context, key string) (*FieldData, error
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result struct {
 		Data FieldData `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := c.decodeJSON(req, resp, &result, "data"); err != nil {
+		return nil, err
 	}
 
+	c.fieldCache.add(key)
+
 	return &result.Data, nil
 }
+
+// EnsureField creates the custom field key unless it already exists, reporting
+// whether it was created. Existing keys are looked up through a cache of the
+// last GetFields result, so repeated calls do not refetch the field list.
+func (c *Client) EnsureField(ctx context.Context, key string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return false, err
+	}
+
+	if key == "" {
+		return false, fmt.Errorf("%w: field key is required", ErrInvalidRequest)
+	}
+
+	found, fresh := c.fieldCache.lookup(key, c.clock().Now(), c.catalogTTL())
+	if found {
+		return false, nil
+	}
+	if !fresh {
+		if _, err := c.GetFields(ctx); err != nil {
+			return false, err
+		}
+		if found, _ = c.fieldCache.lookup(key, c.clock().Now(), c.catalogTTL()); found {
+			return false, nil
+		}
+	}
+
+	if _, err := c.CreateField(ctx, key); err != nil {
+		return false, err
+	}
+	return true, nil
+}
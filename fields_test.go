@@ -309,3 +309,67 @@ func TestFieldsContextCancellation(t *testing.T) {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func TestEnsureField(t *testing.T) {
+	handler := &fieldCatalogHandler{existing: []string{"company"}}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	ctx := context.Background()
+
+	created, err := client.EnsureField(ctx, "company")
+	if err != nil || created {
+		t.Errorf("expected existing field, got created=%v err=%v", created, err)
+	}
+
+	created, err = client.EnsureField(ctx, "plan")
+	if err != nil || !created {
+		t.Errorf("expected new field, got created=%v err=%v", created, err)
+	}
+
+	created, err = client.EnsureField(ctx, "plan")
+	if err != nil || created {
+		t.Errorf("expected cached field, got created=%v err=%v", created, err)
+	}
+
+	if handler.fieldFetches != 1 {
+		t.Errorf("expected fields to be fetched once, got %d", handler.fieldFetches)
+	}
+	if len(handler.createdKeys) != 1 {
+		t.Errorf("expected one field creation, got %v", handler.createdKeys)
+	}
+
+	if _, err := client.EnsureField(ctx, ""); err == nil {
+		t.Error("expected error for empty key, got nil")
+	}
+}
+
+func TestEnsureFieldCacheExpiry(t *testing.T) {
+	clock := newFakeClock()
+	handler := &fieldCatalogHandler{existing: []string{"company"}}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+		c.CatalogCacheTTL = time.Minute
+	}, handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.EnsureField(ctx, "company"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A field created elsewhere is picked up once the cache has expired
+	handler.existing = append(handler.existing, "plan")
+	clock.Advance(2 * time.Minute)
+
+	created, err := client.EnsureField(ctx, "plan")
+	if err != nil || created {
+		t.Errorf("expected refreshed cache to know the field, got created=%v err=%v", created, err)
+	}
+	if handler.fieldFetches != 2 {
+		t.Errorf("expected fields to be refetched, got %d fetches", handler.fieldFetches)
+	}
+}
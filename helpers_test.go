@@ -1,10 +1,14 @@
 package bento_test
 
 import (
+    "context"
     "encoding/json"
     "io"
     "net/http"
     "strings"
+    "sync"
+    "sync/atomic"
+    "testing"
     "time"
 
     bento "github.com/bentonow/bento-golang-sdk"
@@ -40,6 +44,30 @@ func setupTestClient(handler func(req *http.Request) (*http.Response, error)) (*
     return client, nil
 }
 
+// setupTestClientWithConfig creates a mocked Client after letting configure adjust the Config
+func setupTestClientWithConfig(configure func(*bento.Config), handler func(req *http.Request) (*http.Response, error)) (*bento.Client, error) {
+    config := &bento.Config{
+        PublishableKey: "pc422f7e69255a4bf9c9fafcaac64b14", // 32 chars exactly
+        SecretKey:      "s1803b8d410fd4ca3a7d1d1f5be6d3b6", // 32 chars exactly
+        SiteUUID:       "2103f23614d9877a6b4ee73d28a5c610", // 32 chars exactly
+        Timeout:        10 * time.Second,
+    }
+    if configure != nil {
+        configure(config)
+    }
+
+    client, err := bento.NewClient(config)
+    if err != nil {
+        return nil, err
+    }
+
+    if err := client.SetHTTPClient(&mockHTTPClient{DoFunc: handler}); err != nil {
+        return nil, err
+    }
+
+    return client, nil
+}
+
 // mockResponse creates a mock HTTP response with the given status code and body
 func mockResponse(statusCode int, body interface{}) *http.Response {
     jsonBody, _ := json.Marshal(body)
@@ -54,4 +82,128 @@ func mockResponse(statusCode int, body interface{}) *http.Response {
 func validateAuthHeaders(req *http.Request) bool {
     auth := req.Header.Get("Authorization")
     return auth != "" && strings.HasPrefix(auth, "Basic ")
-}
\ No newline at end of file
+}
+
+// fakeClock is a manually advanced bento.Clock for testing time-based helpers
+type fakeClock struct {
+    mu      sync.Mutex
+    now     time.Time
+    waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+    deadline time.Time
+    ch       chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+    return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    ch := make(chan time.Time, 1)
+    if d <= 0 {
+        ch <- f.now
+        return ch
+    }
+    f.waiters = append(f.waiters, fakeWaiter{deadline: f.now.Add(d), ch: ch})
+    return ch
+}
+
+// Advance moves the clock forward and fires every waiter whose deadline has passed
+func (f *fakeClock) Advance(d time.Duration) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    f.now = f.now.Add(d)
+    pending := f.waiters[:0]
+    for _, w := range f.waiters {
+        if !w.deadline.After(f.now) {
+            w.ch <- f.now
+            continue
+        }
+        pending = append(pending, w)
+    }
+    f.waiters = pending
+}
+
+// BlockUntil waits until at least n goroutines are waiting on the clock
+func (f *fakeClock) BlockUntil(t *testing.T, n int) {
+    t.Helper()
+    deadline := time.Now().Add(2 * time.Second)
+    for time.Now().Before(deadline) {
+        f.mu.Lock()
+        waiting := len(f.waiters)
+        f.mu.Unlock()
+        if waiting >= n {
+            return
+        }
+        time.Sleep(time.Millisecond)
+    }
+    t.Fatalf("timed out waiting for %d clock waiters", n)
+}
+
+func TestUserAgent(t *testing.T) {
+    tests := []struct {
+        name   string
+        suffix string
+        want   string
+    }{
+        {name: "default", want: "bento-go-sdk/" + bento.Version},
+        {name: "with suffix", suffix: "myapp/1.2.3", want: "bento-go-sdk/" + bento.Version + " myapp/1.2.3"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var userAgent, siteUUID string
+            client, err := setupTestClientWithConfig(func(c *bento.Config) {
+                c.UserAgentSuffix = tt.suffix
+            }, func(req *http.Request) (*http.Response, error) {
+                userAgent = req.Header.Get("User-Agent")
+                siteUUID = req.URL.Query().Get("site_uuid")
+                return mockResponse(http.StatusOK, map[string]interface{}{"data": []interface{}{}}), nil
+            })
+            if err != nil {
+                t.Fatalf("failed to setup test client: %v", err)
+            }
+
+            if _, err := client.GetTags(context.Background()); err != nil {
+                t.Fatalf("unexpected error: %v", err)
+            }
+            if userAgent != tt.want {
+                t.Errorf("expected User-Agent %q, got %q", tt.want, userAgent)
+            }
+            if strings.Contains(userAgent, siteUUID) {
+                t.Errorf("User-Agent leaks the site UUID: %q", userAgent)
+            }
+            if siteUUID == "" {
+                t.Error("expected site UUID in the query")
+            }
+        })
+    }
+}
+
+// closeCountingBody counts how often a response body is closed
+type closeCountingBody struct {
+    io.Reader
+    closes *atomic.Int32
+}
+
+func (b *closeCountingBody) Close() error {
+    b.closes.Add(1)
+    return nil
+}
+
+// trackedResponse is mockResponse with a body that reports Close calls to closes
+func trackedResponse(statusCode int, body interface{}, closes *atomic.Int32) *http.Response {
+    resp := mockResponse(statusCode, body)
+    resp.Body = &closeCountingBody{Reader: resp.Body, closes: closes}
+    return resp
+}
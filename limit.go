@@ -0,0 +1,48 @@
+package bento
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// defaultMaxResponseBytes caps response bodies when Config.MaxResponseBytes is unset
+const defaultMaxResponseBytes = 10 << 20
+
+// limitedBody fails reads with ErrResponseTooLarge once more than limit
+// bytes have been read from the underlying body
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, fmt.Errorf("%w (limit %d bytes)", ErrResponseTooLarge, b.limit)
+	}
+	// Read one byte past the limit so a body of exactly limit bytes still succeeds
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), fmt.Errorf("%w (limit %d bytes)", ErrResponseTooLarge, b.limit)
+	}
+	return n, err
+}
+
+// maxResponseBytes returns the configured response size limit
+func (c *Client) maxResponseBytes() int64 {
+	if c.config.MaxResponseBytes > 0 {
+		return c.config.MaxResponseBytes
+	}
+	return defaultMaxResponseBytes
+}
+
+// limitBody caps the body of resp at the configured response size limit
+func (c *Client) limitBody(resp *http.Response) {
+	limit := c.maxResponseBytes()
+	resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: limit, limit: limit}
+}
@@ -0,0 +1,111 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// streamingResponse answers with a JSON object whose "data" string is n bytes
+// long, generated lazily so the test never holds the whole body
+func streamingResponse(status, n int) *http.Response {
+	body := io.MultiReader(
+		strings.NewReader(`{"data":"`),
+		io.LimitReader(repeatReader('a'), int64(n)),
+		strings.NewReader(`"}`),
+	)
+	return &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(body),
+	}
+}
+
+// repeatReader yields the same byte forever
+type repeatReader byte
+
+func (r repeatReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
+
+func limitResponses(limit int64) func(*bento.Config) {
+	return func(c *bento.Config) {
+		c.MaxResponseBytes = limit
+	}
+}
+
+func TestMaxResponseBytesExceeded(t *testing.T) {
+	client, err := setupTestClientWithConfig(limitResponses(1024), func(req *http.Request) (*http.Response, error) {
+		return streamingResponse(http.StatusOK, 64<<20), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, err = client.GetSiteStats(context.Background())
+	if !errors.Is(err, bento.ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got %v", err)
+	}
+	if !errors.Is(err, bento.ErrAPIResponse) {
+		t.Errorf("expected error to wrap ErrAPIResponse, got %v", err)
+	}
+}
+
+func TestMaxResponseBytesDefault(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		return streamingResponse(http.StatusOK, 11<<20), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if _, err := client.GetSiteStats(context.Background()); !errors.Is(err, bento.ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge above the 10 MiB default, got %v", err)
+	}
+}
+
+func TestMaxResponseBytesWithinLimit(t *testing.T) {
+	const limit = 1024
+	// The body is exactly at the limit once the JSON wrapper is added
+	size := limit - len(`{"data":""}`)
+	client, err := setupTestClientWithConfig(limitResponses(limit), func(req *http.Request) (*http.Response, error) {
+		return streamingResponse(http.StatusOK, size), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	stats, err := client.GetSiteStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := len(stats["data"].(string)); got != size {
+		t.Errorf("expected %d bytes of data, got %d", size, got)
+	}
+}
+
+func TestMaxResponseBytesErrorBody(t *testing.T) {
+	client, err := setupTestClientWithConfig(limitResponses(100), func(req *http.Request) (*http.Response, error) {
+		return streamingResponse(http.StatusInternalServerError, 64<<20), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, err = client.GetTags(context.Background())
+	var apiErr *bento.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if len(apiErr.Body) > 100 {
+		t.Errorf("expected error body capped at 100 bytes, got %d", len(apiErr.Body))
+	}
+}
@@ -0,0 +1,37 @@
+package bento
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Logger observes the requests the client sends. URLs are redacted before
+// they are passed on: the site UUID query parameter and any user info are
+// replaced, and the Basic auth header is never exposed.
+type Logger interface {
+	LogRequest(ctx context.Context, method, url string)
+	// LogResponse is called once per request; status is zero when no response was received
+	LogResponse(ctx context.Context, method, url string, status int, duration time.Duration)
+}
+
+// redactedValue replaces secrets in logged URLs
+const redactedValue = "REDACTED"
+
+// redactURL returns the request URL with credentials removed
+func redactURL(req *http.Request) string {
+	return redactedURL(req.URL).String()
+}
+
+// redactedURL returns a copy of u without user info and with the site UUID masked
+func redactedURL(u *url.URL) *url.URL {
+	redacted := *u
+	redacted.User = nil
+	q := redacted.Query()
+	if q.Has("site_uuid") {
+		q.Set("site_uuid", redactedValue)
+		redacted.RawQuery = q.Encode()
+	}
+	return &redacted
+}
@@ -0,0 +1,125 @@
+package bento_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+type loggedCall struct {
+	kind   string
+	method string
+	url    string
+	status int
+}
+
+// recordingLogger captures every logger invocation
+type recordingLogger struct {
+	mu    sync.Mutex
+	calls []loggedCall
+}
+
+func (l *recordingLogger) LogRequest(ctx context.Context, method, url string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, loggedCall{kind: "request", method: method, url: url})
+}
+
+func (l *recordingLogger) LogResponse(ctx context.Context, method, url string, status int, duration time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, loggedCall{kind: "response", method: method, url: url, status: status})
+}
+
+// assertRedacted fails if any credential from the test config was logged
+func assertRedacted(t *testing.T, calls []loggedCall) {
+	t.Helper()
+	secrets := []string{
+		"pc422f7e69255a4bf9c9fafcaac64b14",
+		"s1803b8d410fd4ca3a7d1d1f5be6d3b6",
+		"2103f23614d9877a6b4ee73d28a5c610",
+	}
+	for _, call := range calls {
+		for _, secret := range secrets {
+			if strings.Contains(call.url, secret) {
+				t.Errorf("%s log leaks a credential: %s", call.kind, call.url)
+			}
+		}
+	}
+}
+
+func TestLoggerGetTags(t *testing.T) {
+	logger := &recordingLogger{}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Logger = logger
+	}, func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusOK, map[string]interface{}{"data": []interface{}{}}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if _, err := client.GetTags(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(logger.calls) != 2 {
+		t.Fatalf("expected 2 log calls, got %d", len(logger.calls))
+	}
+	request, response := logger.calls[0], logger.calls[1]
+	if request.kind != "request" || request.method != http.MethodGet {
+		t.Errorf("unexpected request log: %+v", request)
+	}
+	if !strings.HasSuffix(strings.Split(request.url, "?")[0], "/fetch/tags") {
+		t.Errorf("unexpected request URL: %s", request.url)
+	}
+	if !strings.Contains(request.url, "site_uuid=REDACTED") {
+		t.Errorf("expected site UUID to be redacted, got %s", request.url)
+	}
+	if response.kind != "response" || response.status != http.StatusOK || response.url != request.url {
+		t.Errorf("unexpected response log: %+v", response)
+	}
+	assertRedacted(t, logger.calls)
+}
+
+func TestLoggerCreateBroadcastFailure(t *testing.T) {
+	logger := &recordingLogger{}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Logger = logger
+	}, func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusInternalServerError, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.CreateBroadcast(context.Background(), []bento.BroadcastData{
+		{
+			Name:             "Weekly",
+			Subject:          "This week",
+			Content:          "<p>News</p>",
+			Type:             bento.BroadcastTypePlain,
+			From:             bento.ContactData{Name: "Acme", Email: "news@acme.com"},
+			BatchSizePerHour: 1000,
+		},
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if len(logger.calls) != 2 {
+		t.Fatalf("expected 2 log calls, got %d", len(logger.calls))
+	}
+	if request := logger.calls[0]; request.method != http.MethodPost {
+		t.Errorf("unexpected request log: %+v", request)
+	}
+	if response := logger.calls[1]; response.status != http.StatusInternalServerError {
+		t.Errorf("expected status 500 to be logged, got %+v", response)
+	}
+	assertRedacted(t, logger.calls)
+}
@@ -0,0 +1,338 @@
+package bento
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mappingTag is the struct tag key read by MapSubscriber and MapEvent
+const mappingTag = "bento"
+
+// mappingKind identifies which SDK type a struct is being mapped into
+type mappingKind int
+
+const (
+	mapSubscriber mappingKind = iota
+	mapEvent
+)
+
+// mappedField describes one tagged struct field
+type mappedField struct {
+	name      string
+	index     []int
+	directive string
+	key       string
+	omitEmpty bool
+}
+
+// typeMapping is the parsed tag metadata for a struct type
+type typeMapping struct {
+	fields []mappedField
+	err    error
+}
+
+type mappingCacheKey struct {
+	t    reflect.Type
+	kind mappingKind
+}
+
+// mappingCache holds a *typeMapping per struct type and mapping kind
+var mappingCache sync.Map
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// MapSubscriber builds a SubscriberInput from a struct annotated with bento tags:
+//
+//	Email   string    `bento:"email"`
+//	First   string    `bento:"first_name"`
+//	Last    *string   `bento:"last_name,omitempty"`
+//	Plan    string    `bento:"tag,omitempty"`
+//	Company string    `bento:"field:company"`
+//	Joined  time.Time `bento:"field:joined_at,omitempty"`
+//
+// A tag field may be a string or []string. Embedded structs are flattened,
+// nil pointers are skipped and time.Time values are kept as they are, to be
+// formatted with Config.FieldTimeLayout when the subscriber is sent.
+// Unknown directives are reported the first time a type is mapped.
+func MapSubscriber(v any) (*SubscriberInput, error) {
+	rv, mapping, err := resolveMapping(v, mapSubscriber)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &SubscriberInput{}
+	for _, f := range mapping.fields {
+		fv, ok := fieldValue(rv, f)
+		if !ok {
+			continue
+		}
+
+		switch f.directive {
+		case "email":
+			input.Email = fv.String()
+		case "first_name":
+			input.FirstName = fv.String()
+		case "last_name":
+			input.LastName = fv.String()
+		case "tag":
+			if fv.Kind() == reflect.Slice {
+				for i := 0; i < fv.Len(); i++ {
+					input.Tags = append(input.Tags, fv.Index(i).String())
+				}
+			} else {
+				input.Tags = append(input.Tags, fv.String())
+			}
+		case "field":
+			if input.Fields == nil {
+				input.Fields = make(map[string]interface{})
+			}
+			input.Fields[f.key] = mappedValue(fv)
+		}
+	}
+
+	return input, nil
+}
+
+// MapEvent builds an EventData from a struct annotated with bento tags:
+//
+//	OrderID  string     `bento:"detail:order_id"`
+//	Shipping *Address   `bento:"detail:shipping"`
+//	Plan     string     `bento:"field:plan,omitempty"`
+//	PaidAt   time.Time  `bento:"field:paid_at"`
+//
+// Embedded structs are flattened, nil pointers are skipped, time.Time values
+// are formatted with Config.FieldTimeLayout when the event is sent and other
+// struct values become nested maps using their json tags. The result is checked with the same validation as TrackEvent.
+func MapEvent(eventType, email string, v any) (EventData, error) {
+	rv, mapping, err := resolveMapping(v, mapEvent)
+	if err != nil {
+		return EventData{}, err
+	}
+
+	event := EventData{Type: eventType, Email: email}
+	for _, f := range mapping.fields {
+		fv, ok := fieldValue(rv, f)
+		if !ok {
+			continue
+		}
+
+		value, err := mappedNestedValue(fv)
+		if err != nil {
+			return EventData{}, fmt.Errorf("%w: %s: %v", ErrInvalidMapping, f.name, err)
+		}
+
+		switch f.directive {
+		case "field":
+			if event.Fields == nil {
+				event.Fields = make(map[string]interface{})
+			}
+			event.Fields[f.key] = value
+		case "detail":
+			if event.Details == nil {
+				event.Details = make(map[string]interface{})
+			}
+			event.Details[f.key] = value
+		}
+	}
+
+	if err := validateEvent(event); err != nil {
+		return EventData{}, err
+	}
+
+	return event, nil
+}
+
+// resolveMapping dereferences v and returns it with the cached tag metadata for its type
+func resolveMapping(v any, kind mappingKind) (reflect.Value, *typeMapping, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Value{}, nil, fmt.Errorf("%w: cannot map a nil value", ErrInvalidMapping)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, nil, fmt.Errorf("%w: expected a struct, got %s", ErrInvalidMapping, rv.Kind())
+	}
+
+	key := mappingCacheKey{t: rv.Type(), kind: kind}
+	if cached, ok := mappingCache.Load(key); ok {
+		mapping := cached.(*typeMapping)
+		return rv, mapping, mapping.err
+	}
+
+	mapping := parseMapping(rv.Type(), kind)
+	actual, _ := mappingCache.LoadOrStore(key, mapping)
+	mapping = actual.(*typeMapping)
+	return rv, mapping, mapping.err
+}
+
+// parseMapping reads the bento tags of t, including those of embedded structs
+func parseMapping(t reflect.Type, kind mappingKind) *typeMapping {
+	mapping := &typeMapping{}
+	seen := make(map[string]string)
+	if err := collectFields(t, nil, kind, seen, mapping); err != nil {
+		mapping.err = err
+		return mapping
+	}
+	if kind == mapSubscriber {
+		if _, ok := seen["email"]; !ok {
+			mapping.err = fmt.Errorf("%w: %s has no bento:\"email\" field", ErrInvalidMapping, t)
+		}
+	}
+	return mapping
+}
+
+func collectFields(t reflect.Type, index []int, kind mappingKind, seen map[string]string, mapping *typeMapping) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup(mappingTag)
+		if tag == "-" {
+			continue
+		}
+
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if !tagged {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if sf.Anonymous && ft.Kind() == reflect.Struct {
+				if err := collectFields(ft, fieldIndex, kind, seen, mapping); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if !sf.IsExported() {
+			return fmt.Errorf("%w: %s.%s is tagged but unexported", ErrInvalidMapping, t, sf.Name)
+		}
+
+		f, err := parseFieldTag(t, sf, tag, kind)
+		if err != nil {
+			return err
+		}
+		f.index = fieldIndex
+
+		// Tags accumulate, every other target may only be set once
+		if f.directive != "tag" {
+			target := f.directive
+			if f.key != "" {
+				target += ":" + f.key
+			}
+			if other, ok := seen[target]; ok {
+				return fmt.Errorf("%w: %s.%s and %s both map to %q", ErrInvalidMapping, t, other, sf.Name, target)
+			}
+			seen[target] = sf.Name
+		}
+
+		mapping.fields = append(mapping.fields, f)
+	}
+	return nil
+}
+
+// parseFieldTag parses a single `bento:"directive[:key][,omitempty]"` tag
+func parseFieldTag(owner reflect.Type, sf reflect.StructField, tag string, kind mappingKind) (mappedField, error) {
+	f := mappedField{name: sf.Name}
+
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt != "omitempty" {
+			return f, fmt.Errorf("%w: %s.%s: unknown tag option %q", ErrInvalidMapping, owner, sf.Name, opt)
+		}
+		f.omitEmpty = true
+	}
+
+	directive, key, hasKey := strings.Cut(parts[0], ":")
+	f.directive = directive
+
+	ft := sf.Type
+	if ft.Kind() == reflect.Ptr {
+		ft = ft.Elem()
+	}
+
+	switch {
+	case kind == mapSubscriber && (directive == "email" || directive == "first_name" || directive == "last_name"):
+		if hasKey {
+			return f, fmt.Errorf("%w: %s.%s: directive %q does not take a key", ErrInvalidMapping, owner, sf.Name, directive)
+		}
+		if ft.Kind() != reflect.String {
+			return f, fmt.Errorf("%w: %s.%s: directive %q requires a string field, got %s", ErrInvalidMapping, owner, sf.Name, directive, sf.Type)
+		}
+	case kind == mapSubscriber && directive == "tag":
+		if hasKey {
+			return f, fmt.Errorf("%w: %s.%s: directive %q does not take a key", ErrInvalidMapping, owner, sf.Name, directive)
+		}
+		if ft.Kind() != reflect.String && !(ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.String) {
+			return f, fmt.Errorf("%w: %s.%s: directive %q requires a string or []string field, got %s", ErrInvalidMapping, owner, sf.Name, directive, sf.Type)
+		}
+	case directive == "field" || (kind == mapEvent && directive == "detail"):
+		if key == "" {
+			return f, fmt.Errorf("%w: %s.%s: directive %q requires a key, e.g. field:company", ErrInvalidMapping, owner, sf.Name, directive)
+		}
+		f.key = key
+	default:
+		return f, fmt.Errorf("%w: %s.%s: unknown directive %q", ErrInvalidMapping, owner, sf.Name, parts[0])
+	}
+
+	return f, nil
+}
+
+// fieldValue walks f.index from rv, returning false when a nil pointer is
+// crossed or when the value is empty and the field is omitempty
+func fieldValue(rv reflect.Value, f mappedField) (reflect.Value, bool) {
+	v := rv
+	for _, i := range f.index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if f.omitEmpty && v.IsZero() {
+		return reflect.Value{}, false
+	}
+	return v, true
+}
+
+// mappedValue converts a field value into the form sent to the API. Times
+// are kept as time.Time so the client formats them with its FieldTimeLayout.
+func mappedValue(v reflect.Value) interface{} {
+	return v.Interface()
+}
+
+// mappedNestedValue is mappedValue with struct values converted to maps via their json tags
+func mappedNestedValue(v reflect.Value) (interface{}, error) {
+	if v.Kind() != reflect.Struct || v.Type() == timeType {
+		return mappedValue(v), nil
+	}
+
+	raw, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	var nested map[string]interface{}
+	if err := json.Unmarshal(raw, &nested); err != nil {
+		return nil, err
+	}
+	return nested, nil
+}
+
+// formatTime renders a time the way the SDK sends timestamps to the API
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
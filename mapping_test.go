@@ -0,0 +1,298 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+type auditInfo struct {
+	SignedUpAt time.Time  `bento:"field:signed_up_at"`
+	TrialEnds  *time.Time `bento:"field:trial_ends_at"`
+}
+
+type Profile struct {
+	Company string `bento:"field:company,omitempty"`
+	Role    string `bento:"field:role,omitempty"`
+}
+
+type testUser struct {
+	*Profile
+	auditInfo
+
+	ID        int64    `bento:"field:user_id"`
+	Email     string   `bento:"email"`
+	FirstName string   `bento:"first_name"`
+	LastName  *string  `bento:"last_name"`
+	Plan      string   `bento:"tag,omitempty"`
+	Groups    []string `bento:"tag"`
+	Password  string   `bento:"-"`
+	internal  string
+}
+
+func TestMapSubscriber(t *testing.T) {
+	lastName := "Doe"
+	signedUp := time.Date(2024, 5, 1, 12, 30, 0, 0, time.FixedZone("EST", -5*3600))
+
+	user := &testUser{
+		Profile:   &Profile{Company: "Acme"},
+		auditInfo: auditInfo{SignedUpAt: signedUp},
+		ID:        42,
+		Email:     "jane@example.com",
+		FirstName: "Jane",
+		LastName:  &lastName,
+		Plan:      "pro",
+		Groups:    []string{"beta", "admins"},
+		Password:  "secret",
+		internal:  "ignored",
+	}
+
+	input, err := bento.MapSubscriber(user)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := &bento.SubscriberInput{
+		Email:     "jane@example.com",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Tags:      []string{"pro", "beta", "admins"},
+		Fields: map[string]interface{}{
+			"company":      "Acme",
+			"signed_up_at": signedUp,
+			"user_id":      int64(42),
+		},
+	}
+	if !reflect.DeepEqual(input, want) {
+		t.Errorf("unexpected mapping:\n got %+v\nwant %+v", input, want)
+	}
+}
+
+func TestMapSubscriberNilPointers(t *testing.T) {
+	input, err := bento.MapSubscriber(testUser{Email: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if input.LastName != "" {
+		t.Errorf("expected nil last name to be skipped, got %q", input.LastName)
+	}
+	if _, ok := input.Fields["company"]; ok {
+		t.Error("expected fields of nil embedded pointer to be skipped")
+	}
+	if _, ok := input.Fields["trial_ends_at"]; ok {
+		t.Error("expected nil time pointer to be skipped")
+	}
+	if _, ok := input.Fields["user_id"]; !ok {
+		t.Error("expected zero value without omitempty to be kept")
+	}
+}
+
+func TestMapSubscriberErrors(t *testing.T) {
+	type unknownDirective struct {
+		Email string `bento:"email"`
+		Name  string `bento:"nickname"`
+	}
+	type unknownOption struct {
+		Email string `bento:"email,required"`
+	}
+	type missingFieldKey struct {
+		Email   string `bento:"email"`
+		Company string `bento:"field:"`
+	}
+	type wrongEmailType struct {
+		Email int `bento:"email"`
+	}
+	type duplicateField struct {
+		Email   string `bento:"email"`
+		Company string `bento:"field:company"`
+		Org     string `bento:"field:company"`
+	}
+	type missingEmail struct {
+		Name string `bento:"first_name"`
+	}
+
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "unknown directive", value: unknownDirective{}},
+		{name: "unknown option", value: unknownOption{}},
+		{name: "missing field key", value: missingFieldKey{}},
+		{name: "wrong email type", value: wrongEmailType{}},
+		{name: "duplicate field", value: duplicateField{}},
+		{name: "missing email", value: missingEmail{}},
+		{name: "not a struct", value: "jane@example.com"},
+		{name: "nil pointer", value: (*testUser)(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Map twice so the cached metadata path reports the same error
+			for i := 0; i < 2; i++ {
+				_, err := bento.MapSubscriber(tt.value)
+				if !errors.Is(err, bento.ErrInvalidMapping) {
+					t.Errorf("attempt %d: expected ErrInvalidMapping, got %v", i+1, err)
+				}
+			}
+		})
+	}
+}
+
+type address struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type orderMeta struct {
+	Channel string `bento:"detail:channel"`
+}
+
+type orderPlaced struct {
+	orderMeta
+
+	OrderID  string     `bento:"detail:order_id"`
+	Total    int        `bento:"detail:total_cents"`
+	Shipping *address   `bento:"detail:shipping"`
+	Billing  *address   `bento:"detail:billing"`
+	Plan     string     `bento:"field:plan,omitempty"`
+	PaidAt   time.Time  `bento:"field:paid_at"`
+	Refunded *time.Time `bento:"field:refunded_at"`
+}
+
+func TestMapEvent(t *testing.T) {
+	event, err := bento.MapEvent("$purchase", "jane@example.com", orderPlaced{
+		orderMeta: orderMeta{Channel: "web"},
+		OrderID:   "ord_123",
+		Total:     4999,
+		Shipping:  &address{City: "Berlin", Country: "DE"},
+		PaidAt:    time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := bento.EventData{
+		Type:  "$purchase",
+		Email: "jane@example.com",
+		Fields: map[string]interface{}{
+			"paid_at": time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+		},
+		Details: map[string]interface{}{
+			"channel":     "web",
+			"order_id":    "ord_123",
+			"total_cents": 4999,
+			"shipping": map[string]interface{}{
+				"city":    "Berlin",
+				"country": "DE",
+			},
+		},
+	}
+	if !reflect.DeepEqual(event, want) {
+		t.Errorf("unexpected mapping:\n got %+v\nwant %+v", event, want)
+	}
+}
+
+func TestMappedTimesUseFieldTimeLayout(t *testing.T) {
+	var sent []map[string]interface{}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.FieldTimeLayout = time.DateOnly
+	}, func(req *http.Request) (*http.Response, error) {
+		var body map[string][]struct {
+			Fields map[string]interface{} `json:"fields"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		for _, items := range body {
+			sent = append(sent, items[0].Fields)
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	signedUp := time.Date(2024, 5, 1, 22, 30, 0, 0, time.FixedZone("EST", -5*3600))
+	input, err := bento.MapSubscriber(testUser{Email: "jane@example.com", auditInfo: auditInfo{SignedUpAt: signedUp}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.ImportSubscribers(context.Background(), []*bento.SubscriberInput{input}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	event, err := bento.MapEvent("$purchase", "jane@example.com", orderPlaced{OrderID: "ord_123", PaidAt: signedUp})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.TrackEvent(context.Background(), []bento.EventData{event}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(sent))
+	}
+	if got := sent[0]["signed_up_at"]; got != "2024-05-02" {
+		t.Errorf("expected subscriber field in the configured layout, got %v", got)
+	}
+	if got := sent[1]["paid_at"]; got != "2024-05-02" {
+		t.Errorf("expected event field in the configured layout, got %v", got)
+	}
+}
+
+func TestMapEventValidation(t *testing.T) {
+	if _, err := bento.MapEvent("$purchase", "not-an-email", orderPlaced{}); !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Errorf("expected ErrInvalidEmail, got %v", err)
+	}
+	if _, err := bento.MapEvent("", "jane@example.com", orderPlaced{}); !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+}
+
+func TestMapEventErrors(t *testing.T) {
+	type conflictingDetails struct {
+		OrderID string `bento:"detail:id"`
+		CartID  string `bento:"detail:id"`
+	}
+	type conflictingEmbedded struct {
+		orderMeta
+		Source string `bento:"detail:channel"`
+	}
+	type subscriberDirective struct {
+		Email string `bento:"email"`
+	}
+
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "conflicting detail names", value: conflictingDetails{}},
+		{name: "conflict with embedded field", value: conflictingEmbedded{}},
+		{name: "subscriber-only directive", value: subscriberDirective{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bento.MapEvent("$test", "jane@example.com", tt.value)
+			if !errors.Is(err, bento.ErrInvalidMapping) {
+				t.Errorf("expected ErrInvalidMapping, got %v", err)
+			}
+		})
+	}
+
+	// The same name may be used once as a field and once as a detail
+	type fieldAndDetail struct {
+		FieldPlan  string `bento:"field:plan"`
+		DetailPlan string `bento:"detail:plan"`
+	}
+	if _, err := bento.MapEvent("$test", "jane@example.com", fieldAndDetail{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
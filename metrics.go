@@ -0,0 +1,21 @@
+package bento
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Metrics receives measurements of every request attempt, including retries.
+// endpoint is the API path relative to the base URL, e.g. "/batch/events",
+// so it is safe to use as a metric label.
+type Metrics interface {
+	// ObserveRequest records an attempt; status is zero when no response was received
+	ObserveRequest(endpoint, method string, status int, duration time.Duration)
+	IncError(endpoint string, err error)
+}
+
+// endpoint returns the request path relative to the base URL
+func (c *Client) endpoint(req *http.Request) string {
+	return strings.TrimPrefix(req.URL.Path, c.basePath)
+}
@@ -0,0 +1,114 @@
+package bento_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+type observation struct {
+	endpoint string
+	method   string
+	status   int
+}
+
+// recordingMetrics captures every metrics hook invocation
+type recordingMetrics struct {
+	mu           sync.Mutex
+	observations []observation
+	errors       []string
+}
+
+func (m *recordingMetrics) ObserveRequest(endpoint, method string, status int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observations = append(m.observations, observation{endpoint: endpoint, method: method, status: status})
+}
+
+func (m *recordingMetrics) IncError(endpoint string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors = append(m.errors, endpoint)
+}
+
+func TestMetricsSuccess(t *testing.T) {
+	metrics := &recordingMetrics{}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Metrics = metrics
+	}, func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusOK, map[string]interface{}{"data": []interface{}{}}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if _, err := client.GetTags(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := observation{endpoint: "/fetch/tags", method: http.MethodGet, status: http.StatusOK}
+	if len(metrics.observations) != 1 || metrics.observations[0] != want {
+		t.Errorf("expected %+v, got %+v", want, metrics.observations)
+	}
+	if len(metrics.errors) != 0 {
+		t.Errorf("expected no errors, got %v", metrics.errors)
+	}
+}
+
+func TestMetricsServerError(t *testing.T) {
+	metrics := &recordingMetrics{}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Metrics = metrics
+		c.Retry = &bento.RetryConfig{MaxRetries: 1, InitialBackoff: time.Millisecond, RetryNonIdempotent: true}
+	}, func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusInternalServerError, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.TrackEvent(context.Background(), []bento.EventData{
+		{Type: "$pageview", Email: "test@example.com"},
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	// Every attempt is observed, including the retry
+	want := observation{endpoint: "/batch/events", method: http.MethodPost, status: http.StatusInternalServerError}
+	if len(metrics.observations) != 2 {
+		t.Fatalf("expected 2 observations, got %+v", metrics.observations)
+	}
+	for i, got := range metrics.observations {
+		if got != want {
+			t.Errorf("attempt %d: expected %+v, got %+v", i+1, want, got)
+		}
+	}
+	if len(metrics.errors) != 2 || metrics.errors[0] != "/batch/events" {
+		t.Errorf("expected 2 errors for /batch/events, got %v", metrics.errors)
+	}
+}
+
+func TestMetricsCustomBaseURL(t *testing.T) {
+	metrics := &recordingMetrics{}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Metrics = metrics
+		c.BaseURL = "http://localhost:8080/proxy/bento/"
+	}, func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusOK, map[string]interface{}{"data": []interface{}{}}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if _, err := client.GetFields(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics.observations) != 1 || metrics.observations[0].endpoint != "/fetch/fields" {
+		t.Errorf("expected /fetch/fields endpoint, got %+v", metrics.observations)
+	}
+}
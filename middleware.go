@@ -0,0 +1,26 @@
+package bento
+
+import "net/http"
+
+// Middleware wraps the HTTP client used for every API call, e.g. to add
+// tracing headers or sign requests. It sees each request after the SDK has
+// set authentication and headers, and may short-circuit by returning an error.
+type Middleware func(next HTTPDoer) HTTPDoer
+
+// HTTPDoerFunc adapts a function to the HTTPDoer interface
+type HTTPDoerFunc func(*http.Request) (*http.Response, error)
+
+// Do calls f(req)
+func (f HTTPDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// chain wraps doer with the configured middlewares so the first one runs first
+func (c *Client) chain(doer HTTPDoer) HTTPDoer {
+	for i := len(c.config.Middlewares) - 1; i >= 0; i-- {
+		if mw := c.config.Middlewares[i]; mw != nil {
+			doer = mw(doer)
+		}
+	}
+	return doer
+}
@@ -0,0 +1,88 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// recordingMiddleware appends its name to order before and after the call
+func recordingMiddleware(name string, order *[]string) bento.Middleware {
+	return func(next bento.HTTPDoer) bento.HTTPDoer {
+		return bento.HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+			*order = append(*order, name+" before")
+			req.Header.Add("X-Middleware", name)
+			resp, err := next.Do(req)
+			*order = append(*order, name+" after")
+			return resp, err
+		})
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	var order []string
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Middlewares = []bento.Middleware{
+			recordingMiddleware("first", &order),
+			recordingMiddleware("second", &order),
+		}
+	}, func(req *http.Request) (*http.Response, error) {
+		if !validateAuthHeaders(req) {
+			t.Error("expected auth headers to be set before middlewares run")
+		}
+		if got := req.Header.Values("X-Middleware"); len(got) != 2 || got[0] != "first" || got[1] != "second" {
+			t.Errorf("unexpected middleware headers: %v", got)
+		}
+		order = append(order, "client")
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.TrackEvent(context.Background(), []bento.EventData{
+		{Type: "$pageview", Email: "test@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"first before", "second before", "client", "second after", "first after"}
+	if len(order) != len(want) {
+		t.Fatalf("expected order %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("step %d: expected %q, got %q", i, want[i], order[i])
+		}
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	errBlocked := errors.New("blocked by policy")
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Middlewares = []bento.Middleware{
+			func(next bento.HTTPDoer) bento.HTTPDoer {
+				return bento.HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+					return nil, errBlocked
+				})
+			},
+		}
+	}, func(req *http.Request) (*http.Response, error) {
+		t.Error("request should not reach the HTTP client")
+		return mockResponse(http.StatusOK, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.TrackEvent(context.Background(), []bento.EventData{
+		{Type: "$pageview", Email: "test@example.com"},
+	})
+	if !errors.Is(err, errBlocked) {
+		t.Errorf("expected middleware error, got %v", err)
+	}
+}
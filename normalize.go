@@ -0,0 +1,83 @@
+package bento
+
+import "strings"
+
+// normalizeEmail applies Config.NormalizeEmails to a single address. It is a
+// no-op when the option is off, so the bytes sent match the caller's exactly.
+func (c *Client) normalizeEmail(email string) string {
+	if !c.config.NormalizeEmails {
+		return email
+	}
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], email[at+1:]
+	if c.config.LowercaseEmailLocalPart {
+		local = strings.ToLower(local)
+	}
+	return local + "@" + strings.ToLower(domain)
+}
+
+// normalizeSubscribers returns subscribers with normalized emails, copying
+// the inputs rather than modifying the caller's
+func (c *Client) normalizeSubscribers(subscribers []*SubscriberInput) []*SubscriberInput {
+	if !c.config.NormalizeEmails {
+		return subscribers
+	}
+	normalized := make([]*SubscriberInput, len(subscribers))
+	for i, sub := range subscribers {
+		if sub == nil {
+			continue
+		}
+		copied := *sub
+		copied.Email = c.normalizeEmail(sub.Email)
+		normalized[i] = &copied
+	}
+	return normalized
+}
+
+// normalizeEvents returns a copy of events with normalized emails
+func (c *Client) normalizeEvents(events []EventData) []EventData {
+	if !c.config.NormalizeEmails {
+		return events
+	}
+	normalized := make([]EventData, len(events))
+	for i, event := range events {
+		event.Email = c.normalizeEmail(event.Email)
+		normalized[i] = event
+	}
+	return normalized
+}
+
+// normalizeEmails returns a copy of emails with normalized recipients and senders
+func (c *Client) normalizeEmails(emails []EmailData) []EmailData {
+	if !c.config.NormalizeEmails {
+		return emails
+	}
+	normalized := make([]EmailData, len(emails))
+	for i, email := range emails {
+		email.To = c.normalizeEmail(email.To)
+		email.From = c.normalizeEmail(email.From)
+		normalized[i] = email
+	}
+	return normalized
+}
+
+// normalizeCommands returns a copy of commands with normalized emails,
+// including the new address of a CommandChangeEmail
+func (c *Client) normalizeCommands(commands []CommandData) []CommandData {
+	if !c.config.NormalizeEmails {
+		return commands
+	}
+	normalized := make([]CommandData, len(commands))
+	for i, cmd := range commands {
+		cmd.Email = c.normalizeEmail(cmd.Email)
+		if cmd.Command == CommandChangeEmail {
+			cmd.Query = c.normalizeEmail(cmd.Query)
+		}
+		normalized[i] = cmd
+	}
+	return normalized
+}
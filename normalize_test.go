@@ -0,0 +1,156 @@
+package bento_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestNormalizeEmails(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func(*bento.Client) error
+		// raw and normalized are the forms expected in the request with
+		// the option off and on
+		raw        []string
+		normalized []string
+	}{
+		{
+			name: "find subscriber",
+			call: func(c *bento.Client) error {
+				_, err := c.FindSubscriber(ctx, " Foo@Example.COM ")
+				return err
+			},
+			raw:        []string{"email=+Foo%40Example.COM+"},
+			normalized: []string{"email=Foo%40example.com"},
+		},
+		{
+			name: "import subscribers",
+			call: func(c *bento.Client) error {
+				return c.ImportSubscribers(ctx, []*bento.SubscriberInput{{Email: "Foo@Example.COM\t"}})
+			},
+			raw:        []string{`"email":"Foo@Example.COM\t"`},
+			normalized: []string{`"email":"Foo@example.com"`},
+		},
+		{
+			name: "track event",
+			call: func(c *bento.Client) error {
+				return c.TrackEvent(ctx, []bento.EventData{{Type: "$pageview", Email: "Foo@Example.COM "}})
+			},
+			raw:        []string{`"email":"Foo@Example.COM "`},
+			normalized: []string{`"email":"Foo@example.com"`},
+		},
+		{
+			name: "create emails",
+			call: func(c *bento.Client) error {
+				_, err := c.CreateEmails(ctx, []bento.EmailData{{
+					To: " Foo@Example.COM", From: "Team@Example.COM ", Subject: "Hi", HTMLBody: "<p>Hi</p>",
+				}})
+				return err
+			},
+			raw:        []string{`"to":" Foo@Example.COM"`, `"from":"Team@Example.COM "`},
+			normalized: []string{`"to":"Foo@example.com"`, `"from":"Team@example.com"`},
+		},
+		{
+			name: "subscriber command",
+			call: func(c *bento.Client) error {
+				return c.SubscriberCommand(ctx, []bento.CommandData{{
+					Command: bento.CommandChangeEmail, Email: "Foo@Example.COM ", Query: " Bar@Example.COM",
+				}})
+			},
+			raw:        []string{`"email":"Foo@Example.COM "`, `"query":" Bar@Example.COM"`},
+			normalized: []string{`"email":"Foo@example.com"`, `"query":"Bar@example.com"`},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, normalize := range []bool{false, true} {
+			name := tt.name + "/off"
+			if normalize {
+				name = tt.name + "/on"
+			}
+			t.Run(name, func(t *testing.T) {
+				var sent string
+				client, err := setupTestClientWithConfig(func(c *bento.Config) {
+					c.NormalizeEmails = normalize
+				}, func(req *http.Request) (*http.Response, error) {
+					sent = req.URL.RawQuery
+					if req.Body != nil {
+						body, _ := io.ReadAll(req.Body)
+						sent = string(body)
+					}
+					return mockResponse(http.StatusOK, map[string]interface{}{
+						"data":    map[string]interface{}{"id": "sub_1"},
+						"results": 1,
+					}), nil
+				})
+				if err != nil {
+					t.Fatalf("failed to setup test client: %v", err)
+				}
+
+				if err := tt.call(client); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				want := tt.raw
+				if normalize {
+					want = tt.normalized
+				}
+				for _, w := range want {
+					if !strings.Contains(sent, w) {
+						t.Errorf("expected %s in request, got %s", w, sent)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestNormalizeEmailsLocalPart(t *testing.T) {
+	var sent string
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.NormalizeEmails = true
+		c.LowercaseEmailLocalPart = true
+	}, func(req *http.Request) (*http.Response, error) {
+		sent = req.URL.Query().Get("email")
+		return mockResponse(http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"id": "sub_1"}}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if _, err := client.FindSubscriber(context.Background(), " Foo@Example.COM"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != "foo@example.com" {
+		t.Errorf("expected foo@example.com, got %q", sent)
+	}
+}
+
+func TestNormalizeEmailsLeavesInputsUntouched(t *testing.T) {
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.NormalizeEmails = true
+	}, func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	sub := &bento.SubscriberInput{Email: " Foo@Example.COM"}
+	events := []bento.EventData{{Type: "$pageview", Email: " Foo@Example.COM"}}
+	if err := client.ImportSubscribers(context.Background(), []*bento.SubscriberInput{sub}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.TrackEvent(context.Background(), events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.Email != " Foo@Example.COM" || events[0].Email != " Foo@Example.COM" {
+		t.Errorf("expected the caller's values to be left alone, got %q and %q", sub.Email, events[0].Email)
+	}
+}
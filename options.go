@@ -0,0 +1,118 @@
+package bento
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestOption customizes a single API call. Every Client method that talks
+// to the API accepts options; they apply to each request the call makes, so
+// for CollectSiteStats and WatchSiteStats a timeout bounds every poll rather
+// than the whole watch.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	timeout            time.Duration
+	header             http.Header
+	requestID          *requestIDSink
+	retryNonIdempotent bool
+	dedupeEvents       bool
+}
+
+// requestIDSink receives request IDs, possibly from concurrent nested requests
+type requestIDSink struct {
+	mu  sync.Mutex
+	dst *string
+}
+
+// WithRequestTimeout bounds each request of the call by d. An earlier
+// deadline already set on the context is kept.
+func WithRequestTimeout(d time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = d
+	}
+}
+
+// WithRequestHeader adds a header to each request of the call, e.g. a
+// tracing ID. Headers set by the SDK itself, such as authentication, take
+// precedence.
+func WithRequestHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Add(key, value)
+	}
+}
+
+// WithRequestID stores the request ID of the call's response in dst, for
+// quoting to Bento support. Calls that make several requests store the ID of
+// the last one. dst is set to "" when the response has no request ID header.
+func WithRequestID(dst *string) RequestOption {
+	return func(o *requestOptions) {
+		if dst != nil {
+			o.requestID = &requestIDSink{dst: dst}
+		}
+	}
+}
+
+// WithRetryNonIdempotent lets a call retry requests that are not idempotent,
+// such as the POST batch endpoints, when Config.Retry is set. Only use it
+// when sending a request twice is harmless.
+func WithRetryNonIdempotent() RequestOption {
+	return func(o *requestOptions) {
+		o.retryNonIdempotent = true
+	}
+}
+
+type requestOptionsKey struct{}
+
+// withRequestOptions attaches opts to ctx so do can apply them to every
+// request made on behalf of the call, including nested calls
+func withRequestOptions(ctx context.Context, opts []RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+
+	o := &requestOptions{header: make(http.Header)}
+	if parent := requestOptionsFrom(ctx); parent != nil {
+		o.timeout = parent.timeout
+		o.header = parent.header.Clone()
+		o.requestID = parent.requestID
+		o.retryNonIdempotent = parent.retryNonIdempotent
+		o.dedupeEvents = parent.dedupeEvents
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, o)
+}
+
+func requestOptionsFrom(ctx context.Context) *requestOptions {
+	o, _ := ctx.Value(requestOptionsKey{}).(*requestOptions)
+	return o
+}
+
+// recordRequestID reports a response's request ID to WithRequestID, if set
+func (o *requestOptions) recordRequestID(id string) {
+	if o.requestID == nil {
+		return
+	}
+	o.requestID.mu.Lock()
+	defer o.requestID.mu.Unlock()
+	*o.requestID.dst = id
+}
+
+// cancelOnClose releases a per-request timeout once the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
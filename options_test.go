@@ -0,0 +1,233 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestRequestHeaderOption(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		if got := req.Header.Get("X-Trace"); got != "trace-123" {
+			t.Errorf("expected X-Trace header, got %q", got)
+		}
+		if !validateAuthHeaders(req) {
+			t.Error("expected SDK authentication to take precedence")
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"id": "sub_123"},
+		}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, err = client.FindSubscriber(context.Background(), "test@example.com",
+		bento.WithRequestHeader("X-Trace", "trace-123"),
+		bento.WithRequestHeader("Authorization", "Bearer nope"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRequestTimeoutOption(t *testing.T) {
+	tests := []struct {
+		name        string
+		ctxTimeout  time.Duration
+		reqTimeout  time.Duration
+		maxDeadline time.Duration
+	}{
+		{name: "applies request timeout", reqTimeout: 2 * time.Second, maxDeadline: 2 * time.Second},
+		{name: "keeps shorter context deadline", ctxTimeout: time.Second, reqTimeout: time.Hour, maxDeadline: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				deadline, ok := req.Context().Deadline()
+				if !ok {
+					t.Fatal("expected request deadline")
+				}
+				if remaining := time.Until(deadline); remaining > tt.maxDeadline {
+					t.Errorf("expected deadline within %s, got %s", tt.maxDeadline, remaining)
+				}
+				return mockResponse(http.StatusOK, map[string]interface{}{
+					"data": map[string]interface{}{"id": "sub_123"},
+				}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			// The response body must stay readable after the request returns
+			subscriber, err := client.FindSubscriber(ctx, "test@example.com", bento.WithRequestTimeout(tt.reqTimeout))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if subscriber.ID != "sub_123" {
+				t.Errorf("unexpected subscriber: %+v", subscriber)
+			}
+		})
+	}
+}
+
+func TestDefaultRequestTimeout(t *testing.T) {
+	// hang blocks until the request context is done, like a transport with no timeout
+	hang := func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+
+	t.Run("times out hanging request", func(t *testing.T) {
+		client, err := setupTestClientWithConfig(func(c *bento.Config) {
+			c.DefaultRequestTimeout = 20 * time.Millisecond
+		}, hang)
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := client.GetTags(context.Background())
+			errCh <- err
+		}()
+
+		select {
+		case err := <-errCh:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected context.DeadlineExceeded, got %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("request did not time out")
+		}
+	})
+
+	t.Run("keeps caller deadline", func(t *testing.T) {
+		client, err := setupTestClientWithConfig(func(c *bento.Config) {
+			c.DefaultRequestTimeout = time.Hour
+		}, func(req *http.Request) (*http.Response, error) {
+			deadline, ok := req.Context().Deadline()
+			if !ok {
+				t.Fatal("expected request deadline")
+			}
+			if remaining := time.Until(deadline); remaining > time.Second {
+				t.Errorf("expected the caller's deadline within 1s, got %s", remaining)
+			}
+			return mockResponse(http.StatusOK, map[string]interface{}{
+				"data": map[string]interface{}{"id": "sub_123"},
+			}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		// The response body must stay readable after the request returns
+		subscriber, err := client.FindSubscriber(ctx, "test@example.com")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if subscriber.ID != "sub_123" {
+			t.Errorf("unexpected subscriber: %+v", subscriber)
+		}
+	})
+
+	t.Run("body readable after do returns", func(t *testing.T) {
+		client, err := setupTestClientWithConfig(func(c *bento.Config) {
+			c.DefaultRequestTimeout = time.Hour
+		}, func(req *http.Request) (*http.Response, error) {
+			if _, ok := req.Context().Deadline(); !ok {
+				t.Error("expected the default deadline to be applied")
+			}
+			return mockResponse(http.StatusOK, map[string]interface{}{
+				"data": map[string]interface{}{"id": "sub_123"},
+			}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		subscriber, err := client.FindSubscriber(context.Background(), "test@example.com")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if subscriber.ID != "sub_123" {
+			t.Errorf("unexpected subscriber: %+v", subscriber)
+		}
+	})
+}
+
+func TestRequestOptionsApplyToNestedCalls(t *testing.T) {
+	var methods []string
+	handler := &cloneBroadcastHandler{}
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		if got := req.Header.Get("X-Trace"); got != "trace-123" {
+			t.Errorf("%s %s: expected X-Trace header, got %q", req.Method, req.URL.Path, got)
+		}
+		if _, ok := req.Context().Deadline(); !ok {
+			t.Errorf("%s %s: expected request deadline", req.Method, req.URL.Path)
+		}
+		methods = append(methods, req.Method)
+		return handler.handle(req)
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, err = client.CloneAndCreateBroadcast(context.Background(), "bc_weekly_19", bento.BroadcastData{},
+		bento.WithRequestHeader("X-Trace", "trace-123"),
+		bento.WithRequestTimeout(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(methods, ","); got != "GET,POST" {
+		t.Errorf("expected GET then POST, got %s", got)
+	}
+}
+
+func TestRequestIDOption(t *testing.T) {
+	var calls int
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		calls++
+		resp := mockResponse(http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"id": "sub_123"},
+		})
+		if calls == 1 {
+			resp.Header.Set("X-Request-Id", "req_123")
+		}
+		return resp, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	var requestID string
+	if _, err := client.FindSubscriber(context.Background(), "test@example.com", bento.WithRequestID(&requestID)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requestID != "req_123" {
+		t.Errorf("expected request ID req_123, got %q", requestID)
+	}
+
+	// A response without the header clears a stale ID
+	if _, err := client.FindSubscriber(context.Background(), "test@example.com", bento.WithRequestID(&requestID)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requestID != "" {
+		t.Errorf("expected empty request ID, got %q", requestID)
+	}
+}
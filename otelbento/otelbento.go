@@ -0,0 +1,70 @@
+// Package otelbento traces Bento API calls with OpenTelemetry. It lives in
+// its own module so the SDK itself does not depend on OpenTelemetry.
+//
+//	config.Tracer = otelbento.NewTracer(otel.GetTracerProvider())
+package otelbento
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// tracerName identifies the SDK as the instrumentation scope of its spans
+const tracerName = "github.com/bentonow/bento-golang-sdk"
+
+// propagator writes the span context into outgoing request headers
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// tracer starts a client span per API call
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a bento.Tracer that gives every API call a client span
+// named after its endpoint, e.g. "bento.batch.events". Spans record the
+// status code, attempt count and error, and the trace context is propagated
+// in the request headers.
+func NewTracer(provider trace.TracerProvider) bento.Tracer {
+	return &tracer{
+		tracer: provider.Tracer(tracerName, trace.WithInstrumentationVersion(bento.Version)),
+	}
+}
+
+// StartSpan implements bento.Tracer
+func (t *tracer) StartSpan(req *http.Request, endpoint string) (*http.Request, bento.Span) {
+	ctx, s := t.tracer.Start(req.Context(), "bento"+strings.ReplaceAll(endpoint, "/", "."),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("bento.endpoint", endpoint),
+		))
+
+	req = req.WithContext(ctx)
+	propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req, &span{span: s}
+}
+
+// span adapts an OpenTelemetry span to bento.Span
+type span struct {
+	span trace.Span
+}
+
+// End implements bento.Span
+func (s *span) End(attempts, status int, err error) {
+	s.span.SetAttributes(attribute.Int("bento.attempts", attempts))
+	if status != 0 {
+		s.span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
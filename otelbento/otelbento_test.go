@@ -0,0 +1,146 @@
+package otelbento_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+	"github.com/bentonow/bento-golang-sdk/otelbento"
+)
+
+// doerFunc adapts a function to bento.HTTPDoer
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func mockResponse(statusCode int, body interface{}) *http.Response {
+	jsonBody, _ := json.Marshal(body)
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(jsonBody)),
+	}
+}
+
+func setupTracedClient(t *testing.T, handler func(req *http.Request) (*http.Response, error), configure func(*bento.Config)) (*bento.Client, *tracetest.InMemoryExporter) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	config := &bento.Config{
+		PublishableKey: "pc422f7e69255a4bf9c9fafcaac64b14",
+		SecretKey:      "s1803b8d410fd4ca3a7d1d1f5be6d3b6",
+		SiteUUID:       "2103f23614d9877a6b4ee73d28a5c610",
+		Timeout:        10 * time.Second,
+		HTTPClient:     doerFunc(handler),
+		Tracer:         otelbento.NewTracer(provider),
+	}
+	if configure != nil {
+		configure(config)
+	}
+
+	client, err := bento.NewClient(config)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	return client, exporter
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracingTrackEvent(t *testing.T) {
+	var traceparent string
+	client, exporter := setupTracedClient(t, func(req *http.Request) (*http.Response, error) {
+		traceparent = req.Header.Get("Traceparent")
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	}, nil)
+
+	err := client.TrackEvent(context.Background(), []bento.EventData{
+		{Type: "$pageview", Email: "test@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "bento.batch.events" {
+		t.Errorf("unexpected span name: %s", span.Name)
+	}
+	if span.SpanKind != trace.SpanKindClient {
+		t.Errorf("expected client span, got %s", span.SpanKind)
+	}
+
+	attrs := spanAttributes(span)
+	if got := attrs["http.response.status_code"].AsInt64(); got != http.StatusOK {
+		t.Errorf("expected status 200, got %d", got)
+	}
+	if got := attrs["http.request.method"].AsString(); got != http.MethodPost {
+		t.Errorf("expected POST, got %s", got)
+	}
+	if got := attrs["bento.attempts"].AsInt64(); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+
+	want := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"
+	if traceparent != want {
+		t.Errorf("expected traceparent %q, got %q", want, traceparent)
+	}
+}
+
+func TestTracingRecordsErrorsAndAttempts(t *testing.T) {
+	client, exporter := setupTracedClient(t, func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusServiceUnavailable, nil), nil
+	}, func(c *bento.Config) {
+		c.Retry = &bento.RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, RetryNonIdempotent: true}
+	})
+
+	err := client.TrackEvent(context.Background(), []bento.EventData{
+		{Type: "$pageview", Email: "test@example.com"},
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected a single span covering all attempts, got %d", len(spans))
+	}
+	span := spans[0]
+	attrs := spanAttributes(span)
+	if got := attrs["bento.attempts"].AsInt64(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+	if got := attrs["http.response.status_code"].AsInt64(); got != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", got)
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("expected error status, got %v", span.Status)
+	}
+	if len(span.Events) == 0 || span.Events[0].Name != "exception" {
+		t.Errorf("expected recorded error event, got %+v", span.Events)
+	}
+}
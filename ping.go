@@ -0,0 +1,39 @@
+package bento
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Ping confirms that the API is reachable and accepts the configured
+// credentials by making a lightweight authenticated request. A rejected key
+// or site UUID (401 or 403) satisfies errors.Is(err, ErrUnauthorized).
+func (c *Client) Ping(ctx context.Context, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/stats/site", c.baseURL), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		// A 401 already matches ErrUnauthorized; for Ping a 403 means the
+		// site UUID was rejected, which is a credentials problem too
+		if errors.Is(err, ErrForbidden) {
+			return fmt.Errorf("%w: %w", ErrUnauthorized, err)
+		}
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return nil
+}
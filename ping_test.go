@@ -0,0 +1,90 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name         string
+		handler      func(req *http.Request) (*http.Response, error)
+		expectError  bool
+		unauthorized bool
+		code         bento.ErrorCode
+	}{
+		{
+			name: "success",
+			handler: func(req *http.Request) (*http.Response, error) {
+				if !validateAuthHeaders(req) {
+					return mockResponse(http.StatusUnauthorized, nil), nil
+				}
+				if req.Method != http.MethodGet || !strings.HasSuffix(req.URL.Path, "/stats/site") {
+					t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+				}
+				return mockResponse(http.StatusOK, map[string]interface{}{"user_count": 10}), nil
+			},
+		},
+		{
+			name: "unauthorized",
+			handler: func(req *http.Request) (*http.Response, error) {
+				return mockResponse(http.StatusUnauthorized, nil), nil
+			},
+			expectError:  true,
+			unauthorized: true,
+			code:         bento.CodeUnauthorized,
+		},
+		{
+			name: "forbidden",
+			handler: func(req *http.Request) (*http.Response, error) {
+				return mockResponse(http.StatusForbidden, nil), nil
+			},
+			expectError:  true,
+			unauthorized: true,
+			code:         bento.CodeUnauthorized,
+		},
+		{
+			name: "network failure",
+			handler: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			},
+			expectError: true,
+			code:        bento.CodeNetwork,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(tt.handler)
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			err = client.Ping(context.Background())
+			if !tt.expectError {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if got := errors.Is(err, bento.ErrUnauthorized); got != tt.unauthorized {
+				t.Errorf("errors.Is(err, ErrUnauthorized) = %v, want %v (%v)", got, tt.unauthorized, err)
+			}
+			if tt.unauthorized && !errors.Is(err, bento.ErrAPIResponse) {
+				t.Errorf("expected ErrAPIResponse to be preserved, got %v", err)
+			}
+			if bento.CodeOf(err) != tt.code {
+				t.Errorf("expected code %s, got %s", tt.code, bento.CodeOf(err))
+			}
+		})
+	}
+}
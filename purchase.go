@@ -0,0 +1,107 @@
+package bento
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PurchaseEvent describes an order tracked with TrackPurchase
+type PurchaseEvent struct {
+	// OrderID identifies the order. Bento counts each order once.
+	OrderID string
+	// Amount is the order total in the currency's minor unit, e.g. cents
+	Amount int64
+	// Currency is an ISO 4217 code such as "USD"
+	Currency string
+	Items    []PurchaseItem
+}
+
+// PurchaseItem is a line of a PurchaseEvent's cart
+type PurchaseItem struct {
+	SKU      string
+	Name     string
+	Quantity int
+	// Price is the unit price in the currency's minor unit
+	Price int64
+}
+
+// TrackPurchase sends a $purchase event for the subscriber with the given
+// email, shaped the way Bento expects for lifetime value tracking. The order
+// ID is required, the currency must be a three-letter code and amounts
+// cannot be negative.
+func (c *Client) TrackPurchase(ctx context.Context, email string, p PurchaseEvent, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	p.Currency = strings.ToUpper(strings.TrimSpace(p.Currency))
+	if err := validatePurchase(p); err != nil {
+		return err
+	}
+
+	return c.TrackEvent(ctx, []EventData{{
+		Type:    EventPurchase,
+		Email:   email,
+		Details: purchaseDetails(p),
+	}})
+}
+
+// validatePurchase checks a purchase before it is sent
+func validatePurchase(p PurchaseEvent) error {
+	if strings.TrimSpace(p.OrderID) == "" {
+		return invalidField(ErrInvalidRequest, "order_id", "", "order ID is required")
+	}
+	if !isCurrencyCode(p.Currency) {
+		return invalidField(ErrInvalidRequest, "currency", p.Currency, "currency must be a three-letter ISO 4217 code")
+	}
+	if p.Amount < 0 {
+		return invalidField(ErrInvalidRequest, "amount", strconv.FormatInt(p.Amount, 10), "amount cannot be negative")
+	}
+	for i, item := range p.Items {
+		field := fmt.Sprintf("items[%d]", i)
+		if item.Quantity <= 0 {
+			return invalidField(ErrInvalidRequest, field+".quantity", strconv.Itoa(item.Quantity), "quantity must be positive")
+		}
+		if item.Price < 0 {
+			return invalidField(ErrInvalidRequest, field+".price", strconv.FormatInt(item.Price, 10), "price cannot be negative")
+		}
+	}
+	return nil
+}
+
+// isCurrencyCode reports whether code has the shape of an ISO 4217 code
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// purchaseDetails builds the details of a $purchase event
+func purchaseDetails(p PurchaseEvent) map[string]interface{} {
+	details := map[string]interface{}{
+		"unique": map[string]interface{}{"key": p.OrderID},
+		"value":  map[string]interface{}{"currency": p.Currency, "amount": p.Amount},
+	}
+	if len(p.Items) > 0 {
+		items := make([]map[string]interface{}, len(p.Items))
+		for i, item := range p.Items {
+			items[i] = map[string]interface{}{
+				"product_sku":   item.SKU,
+				"product_name":  item.Name,
+				"quantity":      item.Quantity,
+				"product_price": item.Price,
+			}
+		}
+		details["cart"] = map[string]interface{}{"items": items}
+	}
+	return details
+}
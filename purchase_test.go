@@ -0,0 +1,105 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestTrackPurchase(t *testing.T) {
+	var body map[string]interface{}
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.TrackPurchase(context.Background(), "buyer@example.com", bento.PurchaseEvent{
+		OrderID:  "order_123",
+		Amount:   8000,
+		Currency: "usd",
+		Items: []bento.PurchaseItem{
+			{SKU: "SKU-1", Name: "Widget", Quantity: 2, Price: 2500},
+			{SKU: "SKU-2", Name: "Gadget", Quantity: 1, Price: 3000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"events": []interface{}{map[string]interface{}{
+			"type":  "$purchase",
+			"email": "buyer@example.com",
+			"details": map[string]interface{}{
+				"unique": map[string]interface{}{"key": "order_123"},
+				"value":  map[string]interface{}{"currency": "USD", "amount": float64(8000)},
+				"cart": map[string]interface{}{"items": []interface{}{
+					map[string]interface{}{"product_sku": "SKU-1", "product_name": "Widget", "quantity": float64(2), "product_price": float64(2500)},
+					map[string]interface{}{"product_sku": "SKU-2", "product_name": "Gadget", "quantity": float64(1), "product_price": float64(3000)},
+				}},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("unexpected request body:\n got %v\nwant %v", body, want)
+	}
+}
+
+func TestTrackPurchaseValidation(t *testing.T) {
+	valid := bento.PurchaseEvent{OrderID: "order_123", Amount: 1000, Currency: "EUR"}
+	tests := []struct {
+		name   string
+		modify func(*bento.PurchaseEvent)
+		field  string
+	}{
+		{name: "missing order ID", modify: func(p *bento.PurchaseEvent) { p.OrderID = " " }, field: "order_id"},
+		{name: "missing currency", modify: func(p *bento.PurchaseEvent) { p.Currency = "" }, field: "currency"},
+		{name: "symbol currency", modify: func(p *bento.PurchaseEvent) { p.Currency = "$" }, field: "currency"},
+		{name: "long currency", modify: func(p *bento.PurchaseEvent) { p.Currency = "EURO" }, field: "currency"},
+		{name: "negative amount", modify: func(p *bento.PurchaseEvent) { p.Amount = -1 }, field: "amount"},
+		{
+			name:   "negative item price",
+			modify: func(p *bento.PurchaseEvent) { p.Items = []bento.PurchaseItem{{SKU: "a", Quantity: 1, Price: -5}} },
+			field:  "items[0].price",
+		},
+		{
+			name:   "zero quantity",
+			modify: func(p *bento.PurchaseEvent) { p.Items = []bento.PurchaseItem{{SKU: "a", Quantity: 0}} },
+			field:  "items[0].quantity",
+		},
+	}
+
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request: %s", req.URL)
+		return mockResponse(http.StatusOK, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid
+			tt.modify(&p)
+			err := client.TrackPurchase(context.Background(), "buyer@example.com", p)
+			var verr *bento.ValidationError
+			if !errors.As(err, &verr) || verr.Field != tt.field || !errors.Is(err, bento.ErrInvalidRequest) {
+				t.Errorf("expected a %s validation error, got %v", tt.field, err)
+			}
+		})
+	}
+
+	if err := client.TrackPurchase(context.Background(), "not-an-email", valid); !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Errorf("expected ErrInvalidEmail, got %v", err)
+	}
+}
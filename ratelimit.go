@@ -0,0 +1,98 @@
+package bento
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimit is the API rate limit as reported by response headers. Limit
+// and Remaining are -1 when the response did not report them, and ResetAt is
+// zero when no reset time is known.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+}
+
+// RateLimitError is returned for a 429 response. It wraps the APIError, so
+// it satisfies errors.Is(err, ErrRateLimited) and errors.As with *APIError.
+type RateLimitError struct {
+	RateLimit
+	Err *APIError
+}
+
+func (e *RateLimitError) Error() string { return e.Err.Error() }
+
+func (e *RateLimitError) Unwrap() error { return e.Err }
+
+// Code classifies the error as CodeRateLimited
+func (e *RateLimitError) Code() ErrorCode { return CodeRateLimited }
+
+// rateLimitTracker holds the most recently observed rate limit. It is shared
+// by clients derived with WithSiteUUID, which count against the same keys.
+type rateLimitTracker struct {
+	last atomic.Pointer[RateLimit]
+}
+
+// LastRateLimit returns the rate limit reported by the most recent response
+// carrying rate limit headers, and false if none has been seen yet
+func (c *Client) LastRateLimit() (RateLimit, bool) {
+	last := c.rateLimits.last.Load()
+	if last == nil {
+		return RateLimit{}, false
+	}
+	return *last, true
+}
+
+// observeRateLimit records the rate limit headers of resp, if any
+func (c *Client) observeRateLimit(resp *http.Response) {
+	limit, ok := parseRateLimit(resp.Header, c.clock().Now())
+	if ok {
+		c.rateLimits.last.Store(&limit)
+	}
+}
+
+// parseRateLimit reads the X-RateLimit-* headers, or their unprefixed
+// RateLimit-* equivalents, ignoring missing or malformed values
+func parseRateLimit(header http.Header, now time.Time) (RateLimit, bool) {
+	limit := RateLimit{
+		Limit:     rateLimitHeader(header, "Limit"),
+		Remaining: rateLimitHeader(header, "Remaining"),
+	}
+	// Reset is either a Unix timestamp or a number of seconds from now
+	if reset := rateLimitHeader(header, "Reset"); reset >= 0 {
+		if reset > 1e9 {
+			limit.ResetAt = time.Unix(int64(reset), 0)
+		} else {
+			limit.ResetAt = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	ok := limit.Limit >= 0 || limit.Remaining >= 0 || !limit.ResetAt.IsZero()
+	return limit, ok
+}
+
+// rateLimitHeader returns a non-negative rate limit header value, or -1
+func rateLimitHeader(header http.Header, name string) int {
+	value := header.Get("X-RateLimit-" + name)
+	if value == "" {
+		value = header.Get("RateLimit-" + name)
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
+
+// rateLimitError describes a 429 response, falling back to Retry-After for
+// the reset time when the rate limit headers do not give one
+func (c *Client) rateLimitError(apiErr *APIError, header http.Header) *RateLimitError {
+	now := c.clock().Now()
+	limit, _ := parseRateLimit(header, now)
+	if limit.ResetAt.IsZero() && apiErr.RetryAfter > 0 {
+		limit.ResetAt = now.Add(apiErr.RetryAfter)
+	}
+	return &RateLimitError{RateLimit: limit, Err: apiErr}
+}
@@ -0,0 +1,139 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// withRateLimitHeaders answers every request with status and the given headers
+func withRateLimitHeaders(status int, headers map[string]string) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		resp := mockResponse(status, map[string]interface{}{"data": []interface{}{}})
+		for key, value := range headers {
+			resp.Header.Set(key, value)
+		}
+		return resp, nil
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	clock := newFakeClock()
+	reset := time.Unix(1900000000, 0)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bento.RateLimit
+	}{
+		{
+			name: "full headers",
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "100",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "1900000000",
+			},
+			want: bento.RateLimit{Limit: 100, Remaining: 0, ResetAt: reset},
+		},
+		{
+			name: "reset in seconds",
+			headers: map[string]string{
+				"RateLimit-Limit":     "100",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "30",
+			},
+			want: bento.RateLimit{Limit: 100, Remaining: 0, ResetAt: clock.Now().Add(30 * time.Second)},
+		},
+		{
+			name:    "retry after only",
+			headers: map[string]string{"Retry-After": "45"},
+			want:    bento.RateLimit{Limit: -1, Remaining: -1, ResetAt: clock.Now().Add(45 * time.Second)},
+		},
+		{
+			name:    "no headers",
+			headers: nil,
+			want:    bento.RateLimit{Limit: -1, Remaining: -1},
+		},
+		{
+			name: "malformed headers",
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "lots",
+				"X-RateLimit-Remaining": "-3",
+				"X-RateLimit-Reset":     "soon",
+			},
+			want: bento.RateLimit{Limit: -1, Remaining: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.Clock = clock
+			}, withRateLimitHeaders(http.StatusTooManyRequests, tt.headers))
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			_, err = client.GetTags(context.Background())
+			var rateErr *bento.RateLimitError
+			if !errors.As(err, &rateErr) {
+				t.Fatalf("expected RateLimitError, got %v", err)
+			}
+			if rateErr.Limit != tt.want.Limit || rateErr.Remaining != tt.want.Remaining || !rateErr.ResetAt.Equal(tt.want.ResetAt) {
+				t.Errorf("expected %+v, got %+v", tt.want, rateErr.RateLimit)
+			}
+			if !errors.Is(err, bento.ErrRateLimited) || !errors.Is(err, bento.ErrAPIResponse) {
+				t.Errorf("expected ErrRateLimited and ErrAPIResponse, got %v", err)
+			}
+			var apiErr *bento.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+				t.Errorf("expected APIError with status 429, got %v", err)
+			}
+			if bento.CodeOf(err) != bento.CodeRateLimited {
+				t.Errorf("expected CodeRateLimited, got %s", bento.CodeOf(err))
+			}
+		})
+	}
+}
+
+func TestLastRateLimit(t *testing.T) {
+	remaining := "42"
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		return withRateLimitHeaders(http.StatusOK, map[string]string{
+			"X-RateLimit-Limit":     "100",
+			"X-RateLimit-Remaining": remaining,
+		})(req)
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if _, ok := client.LastRateLimit(); ok {
+		t.Error("expected no rate limit before the first response")
+	}
+
+	if _, err := client.GetTags(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	limit, ok := client.LastRateLimit()
+	if !ok || limit.Limit != 100 || limit.Remaining != 42 {
+		t.Errorf("expected 42 of 100 remaining, got %+v (%v)", limit, ok)
+	}
+
+	// Derived clients count against the same keys and see the same limit
+	derived, err := client.WithSiteUUID("9c3f23614d9877a6b4ee73d28a5c6100")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	remaining = "41"
+	if _, err := derived.GetTags(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limit, _ := client.LastRateLimit(); limit.Remaining != 41 {
+		t.Errorf("expected 41 remaining after the derived client's call, got %+v", limit)
+	}
+}
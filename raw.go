@@ -0,0 +1,49 @@
+package bento
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// NewRequest builds a request for an API endpoint the SDK does not cover yet.
+// path is joined to the configured base URL, e.g. "/fetch/whatever", and a
+// non-nil body is encoded as JSON. Send it with DoRequest.
+func (c *Client) NewRequest(ctx context.Context, method, path string, body any, opts ...RequestOption) (*http.Request, error) {
+	ctx = withRequestOptions(ctx, opts)
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: encoding body: %v", ErrInvalidRequest, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	return http.NewRequestWithContext(ctx, method, c.baseURL+"/"+strings.TrimLeft(path, "/"), reader)
+}
+
+// DoRequest sends a request built by NewRequest with the SDK's authentication
+// and error mapping, decoding a JSON response into out when it is non-nil.
+// An empty response body leaves out untouched.
+func (c *Client) DoRequest(req *http.Request, out any) error {
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if out == nil {
+		return nil
+	}
+	if err := c.decodeJSON(req, resp, out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
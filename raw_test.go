@@ -0,0 +1,170 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestRawRequest(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		if !validateAuthHeaders(req) {
+			t.Error("expected authentication headers")
+		}
+		if req.URL.Path != "/api/v1/fetch/whatever" {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		if req.URL.Query().Get("site_uuid") == "" {
+			t.Error("expected site_uuid query parameter")
+		}
+		if req.URL.Query().Get("page") != "2" {
+			t.Errorf("expected page query parameter, got %q", req.URL.RawQuery)
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{
+			"data": []map[string]string{{"id": "w_1"}},
+		}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "fetch/whatever?page=2", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := client.DoRequest(req, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Data) != 1 || out.Data[0].ID != "w_1" {
+		t.Errorf("unexpected response: %+v", out)
+	}
+}
+
+func TestRawRequestBody(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost || req.URL.Path != "/api/v1/batch/whatever" {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		body, _ := io.ReadAll(req.Body)
+		var payload map[string]string
+		if err := json.Unmarshal(body, &payload); err != nil || payload["name"] != "test" {
+			t.Errorf("unexpected body: %s", body)
+		}
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Header:     make(http.Header),
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "/batch/whatever", map[string]string{"name": "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out map[string]interface{}
+	if err := client.DoRequest(req, &out); err != nil {
+		t.Errorf("expected empty body to be accepted, got %v", err)
+	}
+}
+
+func TestRawRequestErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *http.Response
+		code     bento.ErrorCode
+	}{
+		{name: "not found", response: mockResponse(http.StatusNotFound, nil), code: bento.CodeNotFound},
+		{name: "rate limited", response: mockResponse(http.StatusTooManyRequests, nil), code: bento.CodeRateLimited},
+		{
+			name: "malformed response",
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("{not json")),
+				Header:     make(http.Header),
+			},
+			code: bento.CodeDecode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				return tt.response, nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			req, err := client.NewRequest(context.Background(), http.MethodGet, "/fetch/whatever", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var out map[string]interface{}
+			err = client.DoRequest(req, &out)
+			if bento.CodeOf(err) != tt.code {
+				t.Errorf("expected code %s, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestRawRequestInvalidBody(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Error("unexpected request")
+		return mockResponse(http.StatusOK, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, err = client.NewRequest(context.Background(), http.MethodPost, "/batch/whatever", make(chan int))
+	if !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+}
+
+func TestRawRequestReuse(t *testing.T) {
+	var bodies []string
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query()["site_uuid"]; len(got) != 1 {
+			t.Errorf("expected exactly one site_uuid, got %v", got)
+		}
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		return mockResponse(http.StatusOK, map[string]interface{}{}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "/batch/whatever", map[string]string{"name": "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := client.DoRequest(req, nil); err != nil {
+			t.Fatalf("attempt %d: unexpected error: %v", i+1, err)
+		}
+	}
+
+	if req.URL.RawQuery != "" || req.Header.Get("Authorization") != "" {
+		t.Errorf("expected the caller's request to be left untouched, got query %q", req.URL.RawQuery)
+	}
+	if len(bodies) != 2 || bodies[0] != `{"name":"test"}` || bodies[1] != bodies[0] {
+		t.Errorf("expected the body to be sent both times, got %q", bodies)
+	}
+}
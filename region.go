@@ -0,0 +1,31 @@
+package bento
+
+import "fmt"
+
+// Region selects the Bento data residency region the client talks to
+type Region string
+
+const (
+	// RegionUS is the default region
+	RegionUS Region = "us"
+	// RegionEU keeps data in the European Union
+	RegionEU Region = "eu"
+)
+
+// regionBaseURLs maps each region to its API endpoint
+var regionBaseURLs = map[Region]string{
+	RegionUS: defaultBaseURL,
+	RegionEU: "https://eu.bentonow.com/api/v1",
+}
+
+// regionBaseURL resolves Config.Region, which cannot be combined with BaseURL
+func regionBaseURL(config *Config) (string, error) {
+	if config.BaseURL != "" {
+		return "", fmt.Errorf("%w: Region cannot be combined with BaseURL", ErrInvalidConfig)
+	}
+	baseURL, ok := regionBaseURLs[config.Region]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown Region %q", ErrInvalidConfig, config.Region)
+	}
+	return baseURL, nil
+}
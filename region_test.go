@@ -0,0 +1,74 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestRegionBaseURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		region bento.Region
+		want   string
+	}{
+		{name: "default", want: "https://app.bentonow.com/api/v1/fetch/tags"},
+		{name: "us", region: bento.RegionUS, want: "https://app.bentonow.com/api/v1/fetch/tags"},
+		{name: "eu", region: bento.RegionEU, want: "https://eu.bentonow.com/api/v1/fetch/tags"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.Region = tt.region
+			}, func(req *http.Request) (*http.Response, error) {
+				u := *req.URL
+				u.RawQuery = ""
+				got = u.String()
+				return mockResponse(http.StatusOK, map[string]interface{}{"data": []interface{}{}}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			if _, err := client.GetTags(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected request to %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRegionValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*bento.Config)
+	}{
+		{
+			name: "combined with BaseURL",
+			configure: func(c *bento.Config) {
+				c.Region = bento.RegionEU
+				c.BaseURL = "https://staging.example.com/api/v1"
+			},
+		},
+		{
+			name:      "unknown region",
+			configure: func(c *bento.Config) { c.Region = "mars" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := setupTestClientWithConfig(tt.configure, nil)
+			if !errors.Is(err, bento.ErrInvalidConfig) {
+				t.Errorf("expected ErrInvalidConfig, got %v", err)
+			}
+		})
+	}
+}
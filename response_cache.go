@@ -0,0 +1,129 @@
+package bento
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ResponseCacheConfig enables conditional GET caching. Responses carrying an
+// ETag or Last-Modified header are kept in memory and revalidated with
+// If-None-Match / If-Modified-Since; a 304 response is served from the cache.
+type ResponseCacheConfig struct {
+	// MaxEntries bounds the number of cached URLs. Defaults to 100.
+	MaxEntries int
+}
+
+const defaultResponseCacheMaxEntries = 100
+
+// responseCache is an LRU cache of GET response bodies keyed by URL
+type responseCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+type responseCacheEntry struct {
+	url          string
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+func newResponseCache(config *ResponseCacheConfig) *responseCache {
+	maxEntries := config.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = defaultResponseCacheMaxEntries
+	}
+	return &responseCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// prepare adds validators for a cached URL to the request
+func (rc *responseCache) prepare(req *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	elem, ok := rc.entries[req.URL.String()]
+	if !ok {
+		return
+	}
+	entry := elem.Value.(*responseCacheEntry)
+	if entry.etag != "" {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+	if entry.lastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.lastModified)
+	}
+}
+
+// serve replaces a 304 response with the cached response, reporting whether it could
+func (rc *responseCache) serve(req *http.Request, resp *http.Response) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	elem, ok := rc.entries[req.URL.String()]
+	if !ok {
+		return false
+	}
+	rc.order.MoveToFront(elem)
+	entry := elem.Value.(*responseCacheEntry)
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	resp.StatusCode = http.StatusOK
+	resp.Status = http.StatusText(http.StatusOK)
+	resp.Header = entry.header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(entry.body))
+	resp.ContentLength = int64(len(entry.body))
+	return true
+}
+
+// store caches a successful response that carries validators, leaving the body readable
+func (rc *responseCache) store(req *http.Request, resp *http.Response) error {
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	key := req.URL.String()
+	entry := &responseCacheEntry{
+		url:          key,
+		etag:         etag,
+		lastModified: lastModified,
+		header:       resp.Header.Clone(),
+		body:         body,
+	}
+	if elem, ok := rc.entries[key]; ok {
+		elem.Value = entry
+		rc.order.MoveToFront(elem)
+		return nil
+	}
+
+	rc.entries[key] = rc.order.PushFront(entry)
+	for rc.order.Len() > rc.maxEntries {
+		oldest := rc.order.Back()
+		rc.order.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*responseCacheEntry).url)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package bento_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// conditionalHandler serves a tag list with the given validator headers and
+// answers 304 when the request revalidates with a matching value
+type conditionalHandler struct {
+	etag          string
+	lastModified  string
+	fullResponses int
+	notModified   int
+	conditional   []string
+}
+
+func (h *conditionalHandler) handle(req *http.Request) (*http.Response, error) {
+	inm := req.Header.Get("If-None-Match")
+	ims := req.Header.Get("If-Modified-Since")
+	h.conditional = append(h.conditional, inm+ims)
+
+	if (h.etag != "" && inm == h.etag) || (h.lastModified != "" && ims == h.lastModified) {
+		h.notModified++
+		return &http.Response{
+			StatusCode: http.StatusNotModified,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Header:     make(http.Header),
+		}, nil
+	}
+
+	h.fullResponses++
+	resp := mockResponse(http.StatusOK, map[string]interface{}{
+		"data": []map[string]interface{}{
+			{"id": "tag_1", "attributes": map[string]interface{}{"name": "customer"}},
+		},
+		"broadcasts": []map[string]interface{}{{"name": "Weekly"}},
+	})
+	if h.etag != "" {
+		resp.Header.Set("ETag", h.etag)
+	}
+	if h.lastModified != "" {
+		resp.Header.Set("Last-Modified", h.lastModified)
+	}
+	return resp, nil
+}
+
+func TestResponseCacheETag(t *testing.T) {
+	handler := &conditionalHandler{etag: `"v1"`}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.ResponseCache = &bento.ResponseCacheConfig{}
+	}, handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		tags, err := client.GetTags(context.Background())
+		if err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i+1, err)
+		}
+		if len(tags) != 1 || tags[0].Attributes.Name != "customer" {
+			t.Errorf("call %d: unexpected tags: %+v", i+1, tags)
+		}
+	}
+
+	if handler.fullResponses != 1 {
+		t.Errorf("expected 1 full payload transfer, got %d", handler.fullResponses)
+	}
+	if handler.notModified != 2 {
+		t.Errorf("expected 2 not modified responses, got %d", handler.notModified)
+	}
+}
+
+func TestResponseCacheLastModified(t *testing.T) {
+	handler := &conditionalHandler{lastModified: "Mon, 01 Jan 2024 00:00:00 GMT"}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.ResponseCache = &bento.ResponseCacheConfig{}
+	}, handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		broadcasts, err := client.GetBroadcasts(context.Background())
+		if err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i+1, err)
+		}
+		if len(broadcasts) != 1 {
+			t.Errorf("call %d: unexpected broadcasts: %+v", i+1, broadcasts)
+		}
+	}
+
+	if handler.fullResponses != 1 || handler.notModified != 1 {
+		t.Errorf("expected 1 full and 1 not modified response, got %d and %d", handler.fullResponses, handler.notModified)
+	}
+}
+
+func TestResponseCacheWithoutValidators(t *testing.T) {
+	handler := &conditionalHandler{}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.ResponseCache = &bento.ResponseCacheConfig{}
+	}, handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTags(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if handler.fullResponses != 2 {
+		t.Errorf("expected 2 full payload transfers, got %d", handler.fullResponses)
+	}
+	for i, header := range handler.conditional {
+		if header != "" {
+			t.Errorf("request %d: unexpected conditional header %q", i+1, header)
+		}
+	}
+}
+
+func TestResponseCacheDisabled(t *testing.T) {
+	handler := &conditionalHandler{etag: `"v1"`}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTags(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if handler.fullResponses != 2 || handler.notModified != 0 {
+		t.Errorf("expected no conditional requests, got %d full and %d not modified", handler.fullResponses, handler.notModified)
+	}
+}
+
+func TestResponseCacheBounded(t *testing.T) {
+	handler := &conditionalHandler{etag: `"v1"`}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.ResponseCache = &bento.ResponseCacheConfig{MaxEntries: 1}
+	}, handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	ctx := context.Background()
+
+	// Alternating between two URLs evicts each entry before it is reused
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTags(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.GetBroadcasts(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if handler.fullResponses != 4 {
+		t.Errorf("expected 4 full payload transfers, got %d", handler.fullResponses)
+	}
+}
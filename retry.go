@@ -0,0 +1,216 @@
+package bento
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig enables automatic retries of requests that fail with a
+// transient status (429, 500 or 503). Retries wait for the server's
+// Retry-After header when present, capped at MaxBackoff, and otherwise use
+// jittered exponential backoff. They stop as soon as the request context is
+// done.
+//
+// Only idempotent requests are retried by default, so a retried POST cannot
+// send an email twice. Set RetryNonIdempotent, or pass WithRetryNonIdempotent
+// to a single call, to retry every request.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt. Defaults to 3.
+	MaxRetries int
+	// InitialBackoff is the wait before the first retry. Defaults to 500ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts, including a longer
+	// Retry-After from the server. Defaults to 30s.
+	MaxBackoff time.Duration
+	// Classifier decides which requests are idempotent. Defaults to
+	// treating GET, HEAD and OPTIONS requests as idempotent.
+	Classifier RetryClassifier
+	// RetryNonIdempotent retries requests the Classifier rejects as well
+	RetryNonIdempotent bool
+}
+
+// RetryClassifier reports whether a request is safe to send more than once
+type RetryClassifier interface {
+	Idempotent(req *http.Request) bool
+}
+
+// RetryClassifierFunc adapts a function to the RetryClassifier interface
+type RetryClassifierFunc func(req *http.Request) bool
+
+// Idempotent calls f(req)
+func (f RetryClassifierFunc) Idempotent(req *http.Request) bool {
+	return f(req)
+}
+
+// safeMethods is the default RetryClassifier
+var safeMethods = RetryClassifierFunc(func(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+})
+
+const (
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// retryPolicy is a RetryConfig with defaults applied
+type retryPolicy struct {
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	classifier     RetryClassifier
+	nonIdempotent  bool
+}
+
+func newRetryPolicy(config *RetryConfig) *retryPolicy {
+	p := &retryPolicy{
+		maxRetries:     config.MaxRetries,
+		initialBackoff: config.InitialBackoff,
+		maxBackoff:     config.MaxBackoff,
+		classifier:     config.Classifier,
+		nonIdempotent:  config.RetryNonIdempotent,
+	}
+	if p.maxRetries <= 0 {
+		p.maxRetries = defaultMaxRetries
+	}
+	if p.initialBackoff <= 0 {
+		p.initialBackoff = defaultInitialBackoff
+	}
+	if p.maxBackoff <= 0 {
+		p.maxBackoff = defaultMaxBackoff
+	}
+	if p.classifier == nil {
+		p.classifier = safeMethods
+	}
+	return p
+}
+
+// allows reports whether req may be retried at all, whatever its outcome
+func (p *retryPolicy) allows(req *http.Request) bool {
+	if p.nonIdempotent || p.classifier.Idempotent(req) {
+		return true
+	}
+	opts := requestOptionsFrom(req.Context())
+	return opts != nil && opts.retryNonIdempotent
+}
+
+// retryable reports whether a failed attempt may succeed when repeated
+func (p *retryPolicy) retryable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before the given retry, counting from 1. The
+// exponential delay is jittered down to between half and all of its value.
+func (p *retryPolicy) backoff(retry int) time.Duration {
+	delay := p.maxBackoff
+	if shift := retry - 1; shift < 32 {
+		if d := p.initialBackoff << shift; d > 0 && d < delay {
+			delay = d
+		}
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// parseRetryAfter reads a Retry-After header in delta-seconds or HTTP-date
+// form, returning zero when it is absent, malformed or already past
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// makeReplayable ensures the request body can be recreated for every attempt
+func makeReplayable(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return err
+	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.Body, _ = req.GetBody()
+	return nil
+}
+
+// doWithRetry sends req until it succeeds, fails permanently or runs out of
+// retries, reporting how many attempts were made
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, int, error) {
+	if err := makeReplayable(req); err != nil {
+		return nil, 0, withCode(CodeNetwork, fmt.Errorf("buffering request body: %w", err))
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		// Each attempt gets its own copy so middlewares and caches start clean
+		current := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, attempt, withCode(CodeNetwork, fmt.Errorf("replaying request body: %w", err))
+			}
+			current.Body = body
+		}
+
+		resp, err := c.send(current)
+		if err == nil || attempt > c.retry.maxRetries || !c.retry.retryable(err) {
+			if err != nil && attempt > 1 {
+				return nil, attempt, withCode(CodeOf(err), fmt.Errorf("after %d attempts: %w", attempt, err))
+			}
+			return resp, attempt, err
+		}
+
+		wait := c.retry.backoff(attempt)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			// A bad Retry-After must not stall a caller without a deadline
+			wait = min(apiErr.RetryAfter, c.retry.maxBackoff)
+			// Give up now rather than sleep past the deadline
+			if deadline, ok := ctx.Deadline(); ok && wait > time.Until(deadline) {
+				return nil, attempt, withCode(CodeOf(err), fmt.Errorf("after %d attempts: %w", attempt, err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, attempt, withCode(CodeCanceled, fmt.Errorf("after %d attempts: %w", attempt, ctx.Err()))
+		case <-c.clock().After(wait):
+		}
+	}
+}
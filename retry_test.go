@@ -0,0 +1,447 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// fastRetry keeps test backoffs short
+func fastRetry(maxRetries int) func(*bento.Config) {
+	return func(c *bento.Config) {
+		c.Retry = &bento.RetryConfig{
+			MaxRetries:     maxRetries,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
+		}
+	}
+}
+
+func TestRetryFindSubscriber(t *testing.T) {
+	attempts := 0
+	client, err := setupTestClientWithConfig(fastRetry(3), func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if got := req.URL.Query()["site_uuid"]; len(got) != 1 {
+			t.Errorf("attempt %d: expected a single site_uuid, got %v", attempts, got)
+		}
+		if attempts < 3 {
+			return mockResponse(http.StatusServiceUnavailable, nil), nil
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"id": "sub_123"},
+		}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	subscriber, err := client.FindSubscriber(context.Background(), "test@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if subscriber.ID != "sub_123" {
+		t.Errorf("unexpected subscriber: %+v", subscriber)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryTrackEventReplaysBody(t *testing.T) {
+	var bodies []string
+	client, err := setupTestClientWithConfig(fastRetry(3), func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			return mockResponse(http.StatusTooManyRequests, nil), nil
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.TrackEvent(context.Background(), []bento.EventData{
+		{Type: "$pageview", Email: "test@example.com"},
+	}, bento.WithRetryNonIdempotent())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(bodies))
+	}
+	if bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("expected identical non-empty bodies, got %q and %q", bodies[0], bodies[1])
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	attempts := 0
+	client, err := setupTestClientWithConfig(fastRetry(2), func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return mockResponse(http.StatusInternalServerError, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, err = client.FindSubscriber(context.Background(), "test@example.com")
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if !errors.Is(err, bento.ErrAPIResponse) {
+		t.Errorf("expected ErrAPIResponse, got %v", err)
+	}
+	if bento.CodeOf(err) != bento.CodeServerError {
+		t.Errorf("expected CodeServerError, got %s", bento.CodeOf(err))
+	}
+	if err == nil || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Errorf("expected attempt count in error, got %v", err)
+	}
+}
+
+func TestRetrySkipsPermanentErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+	}{
+		{name: "bad request", statusCode: http.StatusBadRequest},
+		{name: "unauthorized", statusCode: http.StatusUnauthorized},
+		{name: "not found", statusCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			client, err := setupTestClientWithConfig(fastRetry(3), func(req *http.Request) (*http.Response, error) {
+				attempts++
+				return mockResponse(tt.statusCode, nil), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			if _, err := client.FindSubscriber(context.Background(), "test@example.com"); err == nil {
+				t.Error("expected error, got nil")
+			}
+			if attempts != 1 {
+				t.Errorf("expected 1 attempt, got %d", attempts)
+			}
+		})
+	}
+}
+
+func TestRetryDisabledByDefault(t *testing.T) {
+	attempts := 0
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return mockResponse(http.StatusServiceUnavailable, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if _, err := client.FindSubscriber(context.Background(), "test@example.com"); err == nil {
+		t.Error("expected error, got nil")
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestRetryContextCancelledDuringBackoff(t *testing.T) {
+	clock := newFakeClock()
+	attempts := 0
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+		c.Retry = &bento.RetryConfig{MaxRetries: 5, InitialBackoff: time.Minute}
+	}, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return mockResponse(http.StatusServiceUnavailable, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := client.FindSubscriber(ctx, "test@example.com")
+		errCh <- err
+	}()
+
+	clock.BlockUntil(t, 1)
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("retry did not stop after cancellation")
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+// rateLimitedOnce answers the first request with a 429 carrying the given
+// Retry-After header and every later request with a subscriber
+func rateLimitedOnce(retryAfter string, attempts *atomic.Int32) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		if attempts.Add(1) == 1 {
+			resp := mockResponse(http.StatusTooManyRequests, nil)
+			if retryAfter != "" {
+				resp.Header.Set("Retry-After", retryAfter)
+			}
+			return resp, nil
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"id": "sub_123"},
+		}), nil
+	}
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter func(now time.Time) string
+		wait       time.Duration
+	}{
+		{
+			name:       "delta seconds",
+			retryAfter: func(time.Time) string { return "2" },
+			wait:       2 * time.Second,
+		},
+		{
+			name:       "http date",
+			retryAfter: func(now time.Time) string { return now.Add(5 * time.Second).Format(http.TimeFormat) },
+			wait:       5 * time.Second,
+		},
+		{
+			name:       "missing header falls back to backoff",
+			retryAfter: func(time.Time) string { return "" },
+			wait:       time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			var attempts atomic.Int32
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				c.Clock = clock
+				c.Retry = &bento.RetryConfig{InitialBackoff: time.Minute}
+			}, rateLimitedOnce(tt.retryAfter(clock.Now()), &attempts))
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			errCh := make(chan error, 1)
+			go func() {
+				_, err := client.FindSubscriber(context.Background(), "test@example.com")
+				errCh <- err
+			}()
+
+			clock.BlockUntil(t, 1)
+			if tt.wait > time.Minute/2 {
+				// Backoff is jittered between half and all of the delay
+				clock.Advance(time.Minute/2 - time.Nanosecond)
+			} else {
+				clock.Advance(tt.wait - time.Nanosecond)
+			}
+			if got := attempts.Load(); got != 1 {
+				t.Errorf("expected no retry before the wait elapsed, got %d attempts", got)
+			}
+			clock.Advance(tt.wait)
+
+			select {
+			case err := <-errCh:
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("request did not complete")
+			}
+			if got := attempts.Load(); got != 2 {
+				t.Errorf("expected 2 attempts, got %d", got)
+			}
+		})
+	}
+}
+
+func TestRetryAfterSurfacedWithoutRetries(t *testing.T) {
+	var attempts atomic.Int32
+	client, err := setupTestClient(rateLimitedOnce("120", &attempts))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, err = client.FindSubscriber(context.Background(), "test@example.com")
+	var apiErr *bento.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.RetryAfter != 2*time.Minute {
+		t.Errorf("expected RetryAfter of 2m, got %s", apiErr.RetryAfter)
+	}
+}
+
+func TestRetryAfterBeyondDeadline(t *testing.T) {
+	var attempts atomic.Int32
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Retry = &bento.RetryConfig{MaxRetries: 3, MaxBackoff: 2 * time.Hour}
+	}, rateLimitedOnce("3600", &attempts))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, err = client.FindSubscriber(ctx, "test@example.com")
+	if bento.CodeOf(err) != bento.CodeRateLimited {
+		t.Errorf("expected rate limited error, got %v", err)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestRetryAfterCappedAtMaxBackoff(t *testing.T) {
+	clock := newFakeClock()
+	var attempts atomic.Int32
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+		c.Retry = &bento.RetryConfig{MaxBackoff: 10 * time.Second}
+	}, rateLimitedOnce("86400", &attempts))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := client.FindSubscriber(context.Background(), "test@example.com")
+		errCh <- err
+	}()
+
+	clock.BlockUntil(t, 1)
+	clock.Advance(10*time.Second - time.Nanosecond)
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected no retry before MaxBackoff elapsed, got %d attempts", got)
+	}
+	clock.Advance(time.Nanosecond)
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("request was not retried after MaxBackoff")
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestRetryAttemptsStartFromCleanRequest(t *testing.T) {
+	attempts := 0
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		fastRetry(2)(c)
+		c.Middlewares = []bento.Middleware{
+			func(next bento.HTTPDoer) bento.HTTPDoer {
+				return bento.HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+					req.Header.Add("X-Attempt", "1")
+					return next.Do(req)
+				})
+			},
+		}
+	}, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if got := req.Header.Values("X-Attempt"); len(got) != 1 {
+			t.Errorf("attempt %d: expected one middleware header, got %v", attempts, got)
+		}
+		if got := req.URL.Query()["site_uuid"]; len(got) != 1 {
+			t.Errorf("attempt %d: expected one site_uuid, got %v", attempts, got)
+		}
+		return mockResponse(http.StatusServiceUnavailable, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, _ = client.GetTags(context.Background())
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryNonIdempotentOptIn(t *testing.T) {
+	sendEmail := func(client *bento.Client, opts ...bento.RequestOption) error {
+		_, err := client.CreateEmails(context.Background(), []bento.EmailData{{
+			To:       "test@example.com",
+			From:     "sender@example.com",
+			Subject:  "Receipt",
+			HTMLBody: "<p>Thanks</p>",
+		}}, opts...)
+		return err
+	}
+
+	tests := []struct {
+		name      string
+		configure func(*bento.RetryConfig)
+		opts      []bento.RequestOption
+		attempts  int
+	}{
+		{name: "not retried by default", attempts: 1},
+		{name: "per call opt-in", opts: []bento.RequestOption{bento.WithRetryNonIdempotent()}, attempts: 3},
+		{name: "per client opt-in", configure: func(r *bento.RetryConfig) { r.RetryNonIdempotent = true }, attempts: 3},
+		{
+			name: "custom classifier",
+			configure: func(r *bento.RetryConfig) {
+				r.Classifier = bento.RetryClassifierFunc(func(req *http.Request) bool {
+					return req.URL.Path == "/api/v1/batch/emails"
+				})
+			},
+			attempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			client, err := setupTestClientWithConfig(func(c *bento.Config) {
+				fastRetry(2)(c)
+				if tt.configure != nil {
+					tt.configure(c.Retry)
+				}
+			}, func(req *http.Request) (*http.Response, error) {
+				attempts++
+				return mockResponse(http.StatusServiceUnavailable, nil), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			if err := sendEmail(client, tt.opts...); err == nil {
+				t.Error("expected error, got nil")
+			}
+			if attempts != tt.attempts {
+				t.Errorf("expected %d attempts, got %d", tt.attempts, attempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package bento
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+)
+
+// SegmentData identifies a segment a subscriber belongs to
+type SegmentData struct {
+	ID   string
+	Name string
+}
+
+// GetSubscriberSegments returns the segments the subscriber with the given
+// email belongs to, or an empty slice when there are none. An unknown email
+// fails with ErrSubscriberNotFound.
+func (c *Client) GetSubscriberSegments(ctx context.Context, email string, opts ...RequestOption) ([]SegmentData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	email = c.normalizeEmail(email)
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEmail, email)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/fetch/segments", c.baseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("email", email)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s: %w", ErrSubscriberNotFound, email, err)
+		}
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.statusError(req, resp)
+	}
+
+	var response struct {
+		Data []struct {
+			ID         string `json:"id"`
+			Type       string `json:"type"`
+			Attributes struct {
+				Name string `json:"name"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := c.decodeJSON(req, resp, &response, "data"); err != nil {
+		return nil, err
+	}
+
+	segments := make([]SegmentData, 0, len(response.Data))
+	for _, segment := range response.Data {
+		segments = append(segments, SegmentData{ID: segment.ID, Name: segment.Attributes.Name})
+	}
+	return segments, nil
+}
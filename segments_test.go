@@ -0,0 +1,85 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestGetSubscriberSegments(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response interface{}
+		want     []bento.SegmentData
+		wantErr  error
+	}{
+		{
+			name:   "two segments",
+			status: http.StatusOK,
+			response: map[string]interface{}{
+				"data": []map[string]interface{}{
+					{"id": "seg_1", "type": "segments", "attributes": map[string]interface{}{"name": "churn-risk"}},
+					{"id": "seg_2", "type": "segments", "attributes": map[string]interface{}{"name": "power-users"}},
+				},
+			},
+			want: []bento.SegmentData{{ID: "seg_1", Name: "churn-risk"}, {ID: "seg_2", Name: "power-users"}},
+		},
+		{
+			name:     "no segments",
+			status:   http.StatusOK,
+			response: map[string]interface{}{"data": []interface{}{}},
+			want:     []bento.SegmentData{},
+		},
+		{
+			name:     "unknown subscriber",
+			status:   http.StatusNotFound,
+			response: map[string]string{"error": "Not found"},
+			wantErr:  bento.ErrSubscriberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				if req.Method != http.MethodGet || !strings.HasSuffix(req.URL.Path, "/fetch/segments") {
+					t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+				}
+				if got := req.URL.Query().Get("email"); got != "test@example.com" {
+					t.Errorf("unexpected email in query: %s", got)
+				}
+				return mockResponse(tt.status, tt.response), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			got, err := client.GetSubscriberSegments(context.Background(), "test@example.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetSubscriberSegmentsInvalidEmail(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request: %s", req.URL)
+		return mockResponse(http.StatusOK, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if _, err := client.GetSubscriberSegments(context.Background(), "invalid-email"); !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Errorf("expected ErrInvalidEmail, got %v", err)
+	}
+}
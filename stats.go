@@ -2,13 +2,22 @@ package bento
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"math/rand"
 	"net/http"
+	"time"
 )
 
+// statsDateLayout is the date format used by the stats endpoints
+const statsDateLayout = "2006-01-02"
+
 // GetSiteStats retrieves site statistics
-func (c *Client) GetSiteStats(ctx context.Context) (map[string]interface{}, error) {
+func (c *Client) GetSiteStats(ctx context.Context, opts ...RequestOption) (map[string]interface{}, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
 		fmt.Sprintf("%s/stats/site", c.baseURL), nil)
 	if err != nil {
@@ -22,11 +31,11 @@ func (c *Client) GetSiteStats(ctx context.Context) (map[string]interface{}, erro
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.decodeJSON(req, resp, &result); err != nil {
 		return nil, err
 	}
 
@@ -34,7 +43,12 @@ func (c *Client) GetSiteStats(ctx context.Context) (map[string]interface{}, erro
 }
 
 // GetSegmentStats retrieves segment statistics
-func (c *Client) GetSegmentStats(ctx context.Context, segmentID string) (map[string]interface{}, error) {
+func (c *Client) GetSegmentStats(ctx context.Context, segmentID string, opts ...RequestOption) (map[string]interface{}, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	if segmentID == "" {
 		return nil, fmt.Errorf("%w: segment ID is required", ErrInvalidSegmentID)
 	}
@@ -56,11 +70,11 @@ func (c *Client) GetSegmentStats(ctx context.Context, segmentID string) (map[str
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.decodeJSON(req, resp, &result); err != nil {
 		return nil, err
 	}
 
@@ -68,7 +82,12 @@ func (c *Client) GetSegmentStats(ctx context.Context, segmentID string) (map[str
 }
 
 // GetReportStats retrieves report statistics
-func (c *Client) GetReportStats(ctx context.Context, reportID string) (map[string]interface{}, error) {
+func (c *Client) GetReportStats(ctx context.Context, reportID string, opts ...RequestOption) (map[string]interface{}, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	if reportID == "" {
 		return nil, fmt.Errorf("%w: report ID is required", ErrInvalidRequest)
 	}
@@ -90,13 +109,214 @@ func (c *Client) GetReportStats(ctx context.Context, reportID string) (map[strin
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.decodeJSON(req, resp, &result); err != nil {
 		return nil, err
 	}
 
 	return result, nil
 }
+
+// Validate checks that the range is well formed
+func (r *SiteStatsRange) Validate() error {
+	if r.Start.IsZero() || r.End.IsZero() {
+		return fmt.Errorf("%w: start and end dates are required", ErrInvalidRequest)
+	}
+	if !r.Start.Before(r.End) {
+		return fmt.Errorf("%w: start date must be before end date", ErrInvalidRequest)
+	}
+	if r.Granularity != "" && !r.Granularity.IsValid() {
+		return fmt.Errorf("%w: invalid granularity: %s", ErrInvalidRequest, r.Granularity)
+	}
+	return nil
+}
+
+// GetSiteStatsRange retrieves a historical series of site statistics.
+// Granularity defaults to StatsGranularityDay when empty.
+func (c *Client) GetSiteStatsRange(ctx context.Context, r *SiteStatsRange, opts ...RequestOption) ([]SiteStatsPoint, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if r == nil {
+		return nil, fmt.Errorf("%w: stats range is required", ErrInvalidRequest)
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	granularity := r.Granularity
+	if granularity == "" {
+		granularity = StatsGranularityDay
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/stats/site", c.baseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("start_date", r.Start.UTC().Format(statsDateLayout))
+	q.Add("end_date", r.End.UTC().Format(statsDateLayout))
+	q.Add("granularity", string(granularity))
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.statusError(req, resp)
+	}
+
+	var result struct {
+		Data []SiteStatsPoint `json:"data"`
+	}
+	if err := c.decodeJSON(req, resp, &result, "data"); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// SiteStatsSink receives samples taken by CollectSiteStats
+type SiteStatsSink interface {
+	StoreSiteStats(ctx context.Context, sample SiteStatsSample) error
+}
+
+// CollectSiteStats samples the current site stats every interval and stores each
+// sample in sink, building a history for sites where the stats endpoint has no
+// range support. The first sample is taken immediately. It blocks until ctx is
+// done, returning ctx.Err(), or until a fetch or the sink fails.
+func (c *Client) CollectSiteStats(ctx context.Context, sink SiteStatsSink, interval time.Duration, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	if sink == nil {
+		return fmt.Errorf("%w: stats sink is required", ErrInvalidRequest)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("%w: interval must be positive", ErrInvalidRequest)
+	}
+
+	clock := c.clock()
+	for {
+		stats, err := c.fetchSiteStats(ctx)
+		if err != nil {
+			return err
+		}
+		if err := sink.StoreSiteStats(ctx, SiteStatsSample{At: clock.Now(), Stats: *stats}); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(interval):
+		}
+	}
+}
+
+// maxWatchBackoffShift caps the backoff after consecutive polling failures at 16 intervals
+const maxWatchBackoffShift = 4
+
+// WatchSiteStats polls the current site stats every interval, plus up to 10%
+// jitter, and sends a snapshot on the returned channel whenever it differs from
+// the previous one. The first poll happens immediately. Polling errors are
+// passed to Config.OnWatchError and double the wait before the next poll, up
+// to 16 intervals. The channel is closed once ctx is done.
+func (c *Client) WatchSiteStats(ctx context.Context, interval time.Duration, opts ...RequestOption) (<-chan SiteStats, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("%w: interval must be positive", ErrInvalidRequest)
+	}
+
+	updates := make(chan SiteStats)
+	go c.watchSiteStats(ctx, interval, updates)
+	return updates, nil
+}
+
+func (c *Client) watchSiteStats(ctx context.Context, interval time.Duration, updates chan<- SiteStats) {
+	defer close(updates)
+
+	clock := c.clock()
+	var last *SiteStats
+	failures := 0
+	for {
+		stats, err := c.fetchSiteStats(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			failures++
+			if c.config.OnWatchError != nil {
+				c.config.OnWatchError(err)
+			}
+		default:
+			failures = 0
+			if last == nil || *last != *stats {
+				select {
+				case updates <- *stats:
+				case <-ctx.Done():
+					return
+				}
+				last = stats
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-clock.After(watchDelay(interval, failures)):
+		}
+	}
+}
+
+// watchDelay returns the jittered wait before the next poll
+func watchDelay(interval time.Duration, failures int) time.Duration {
+	shift := failures
+	if shift > maxWatchBackoffShift {
+		shift = maxWatchBackoffShift
+	}
+	delay := interval << shift
+	return delay + time.Duration(rand.Int63n(int64(delay)/10+1))
+}
+
+// fetchSiteStats retrieves the current site statistics as a typed snapshot
+func (c *Client) fetchSiteStats(ctx context.Context) (*SiteStats, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/stats/site", c.baseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.statusError(req, resp)
+	}
+
+	var result SiteStats
+	if err := c.decodeJSON(req, resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -2,9 +2,13 @@ package bento_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	bento "github.com/bentonow/bento-golang-sdk"
 )
@@ -350,10 +354,10 @@ func TestStatsContextCancellation(t *testing.T) {
 
 func TestRequestValidation(t *testing.T) {
 	client, err := setupTestClient(func(_ *http.Request) (*http.Response, error) {
-        return mockResponse(http.StatusOK, map[string]interface{}{
-            "data": "test",
-        }), nil
-    })
+		return mockResponse(http.StatusOK, map[string]interface{}{
+			"data": "test",
+		}), nil
+	})
 
 	if err != nil {
 		t.Fatalf("failed to setup test client: %v", err)
@@ -392,4 +396,353 @@ func TestRequestValidation(t *testing.T) {
 			t.Errorf("expected context.Canceled error, got %v", err)
 		}
 	})
-}
\ No newline at end of file
+}
+func TestGetSiteStatsRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		statsRange      *bento.SiteStatsRange
+		wantGranularity string
+		expectError     bool
+	}{
+		{
+			name:            "weekly range",
+			statsRange:      &bento.SiteStatsRange{Start: start, End: end, Granularity: bento.StatsGranularityWeek},
+			wantGranularity: "week",
+		},
+		{
+			name:            "default granularity",
+			statsRange:      &bento.SiteStatsRange{Start: start, End: end},
+			wantGranularity: "day",
+		},
+		{
+			name:        "nil range",
+			expectError: true,
+		},
+		{
+			name:        "missing start",
+			statsRange:  &bento.SiteStatsRange{End: end},
+			expectError: true,
+		},
+		{
+			name:        "start after end",
+			statsRange:  &bento.SiteStatsRange{Start: end, End: start},
+			expectError: true,
+		},
+		{
+			name:        "invalid granularity",
+			statsRange:  &bento.SiteStatsRange{Start: start, End: end, Granularity: "hourly"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				q := req.URL.Query()
+				if q.Get("start_date") != "2024-01-01" {
+					t.Errorf("unexpected start_date: %s", q.Get("start_date"))
+				}
+				if q.Get("end_date") != "2024-03-31" {
+					t.Errorf("unexpected end_date: %s", q.Get("end_date"))
+				}
+				if q.Get("granularity") != tt.wantGranularity {
+					t.Errorf("unexpected granularity: %s", q.Get("granularity"))
+				}
+
+				return mockResponse(http.StatusOK, map[string]interface{}{
+					"data": []map[string]interface{}{
+						{"date": "2024-01-01", "subscriber_count": 100},
+						{"date": "2024-01-08", "subscriber_count": 120},
+					},
+				}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			points, err := client.GetSiteStatsRange(context.Background(), tt.statsRange)
+			if tt.expectError {
+				if !errors.Is(err, bento.ErrInvalidRequest) {
+					t.Errorf("expected ErrInvalidRequest, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(points) != 2 || points[1].SubscriberCount != 120 {
+				t.Errorf("unexpected points: %+v", points)
+			}
+		})
+	}
+}
+
+type recordingStatsSink struct {
+	mu      sync.Mutex
+	samples []bento.SiteStatsSample
+}
+
+func (s *recordingStatsSink) StoreSiteStats(_ context.Context, sample bento.SiteStatsSample) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples = append(s.samples, sample)
+	return nil
+}
+
+func (s *recordingStatsSink) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.samples)
+}
+
+func TestCollectSiteStats(t *testing.T) {
+	clock := newFakeClock()
+	var calls int32
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+	}, func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&calls, 1)
+		return mockResponse(http.StatusOK, map[string]interface{}{
+			"subscriber_count": 100 * int(n),
+		}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	sink := &recordingStatsSink{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- client.CollectSiteStats(ctx, sink, time.Hour)
+	}()
+
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(t, 1)
+		clock.Advance(time.Hour)
+	}
+	clock.BlockUntil(t, 1)
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if sink.Len() != 3 {
+		t.Fatalf("expected 3 samples, got %d", sink.Len())
+	}
+	for i, sample := range sink.samples {
+		if want := 100 * (i + 1); sample.Stats.SubscriberCount != want {
+			t.Errorf("sample %d: expected %d subscribers, got %d", i, want, sample.Stats.SubscriberCount)
+		}
+		if want := time.Date(2024, 1, 1, i, 0, 0, 0, time.UTC); !sample.At.Equal(want) {
+			t.Errorf("sample %d: expected time %v, got %v", i, want, sample.At)
+		}
+	}
+}
+
+func TestCollectSiteStatsValidation(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Error("unexpected request")
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if err := client.CollectSiteStats(context.Background(), nil, time.Minute); !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest for nil sink, got %v", err)
+	}
+	if err := client.CollectSiteStats(context.Background(), &recordingStatsSink{}, 0); !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest for zero interval, got %v", err)
+	}
+}
+
+// scriptedStatsHandler serves the scripted responses in order, repeating the last one
+type scriptedStatsHandler struct {
+	requests  atomic.Int32
+	responses []*bento.SiteStats
+}
+
+func (h *scriptedStatsHandler) handle(req *http.Request) (*http.Response, error) {
+	n := int(h.requests.Add(1))
+	if n > len(h.responses) {
+		n = len(h.responses)
+	}
+	stats := h.responses[n-1]
+	if stats == nil {
+		return mockResponse(http.StatusInternalServerError, nil), nil
+	}
+	return mockResponse(http.StatusOK, stats), nil
+}
+
+func receiveStats(t *testing.T, updates <-chan bento.SiteStats) bento.SiteStats {
+	t.Helper()
+	select {
+	case stats, ok := <-updates:
+		if !ok {
+			t.Fatal("updates channel closed unexpectedly")
+		}
+		return stats
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for stats update")
+	}
+	return bento.SiteStats{}
+}
+
+func waitClosed(t *testing.T, updates <-chan bento.SiteStats) {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-updates:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for updates channel to close")
+		}
+	}
+}
+
+func TestWatchSiteStats(t *testing.T) {
+	first := &bento.SiteStats{UserCount: 100, SubscriberCount: 90, UnsubscriberCount: 10}
+	second := &bento.SiteStats{UserCount: 101, SubscriberCount: 91, UnsubscriberCount: 10}
+	handler := &scriptedStatsHandler{responses: []*bento.SiteStats{first, first, second}}
+
+	clock := newFakeClock()
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+	}, handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interval := time.Minute
+	updates, err := client.WatchSiteStats(ctx, interval)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := receiveStats(t, updates); got != *first {
+		t.Errorf("unexpected first snapshot: %+v", got)
+	}
+
+	// Nothing is polled before the interval has elapsed
+	clock.BlockUntil(t, 1)
+	clock.Advance(interval - time.Nanosecond)
+	if got := handler.requests.Load(); got != 1 {
+		t.Errorf("expected 1 request before the interval elapsed, got %d", got)
+	}
+
+	// The unchanged snapshot is polled but not delivered
+	clock.Advance(interval/10 + time.Nanosecond)
+	clock.BlockUntil(t, 1)
+	if got := handler.requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests after one interval, got %d", got)
+	}
+
+	clock.Advance(interval + interval/10)
+	if got := receiveStats(t, updates); got != *second {
+		t.Errorf("unexpected second snapshot: %+v", got)
+	}
+
+	cancel()
+	waitClosed(t, updates)
+	if got := handler.requests.Load(); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestWatchSiteStatsErrorBackoff(t *testing.T) {
+	stats := &bento.SiteStats{UserCount: 100, SubscriberCount: 90, UnsubscriberCount: 10}
+	handler := &scriptedStatsHandler{responses: []*bento.SiteStats{nil, nil, stats}}
+
+	var mu sync.Mutex
+	var watchErrors []error
+	clock := newFakeClock()
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+		c.OnWatchError = func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			watchErrors = append(watchErrors, err)
+		}
+	}, handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interval := time.Minute
+	updates, err := client.WatchSiteStats(ctx, interval)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// After the first failure the watcher waits two intervals
+	clock.BlockUntil(t, 1)
+	clock.Advance(interval + interval/10)
+	if got := handler.requests.Load(); got != 1 {
+		t.Errorf("expected backoff after first failure, got %d requests", got)
+	}
+	clock.Advance(interval + interval/10)
+
+	// After the second failure it waits four intervals
+	clock.BlockUntil(t, 1)
+	if got := handler.requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+	clock.Advance(4*interval - time.Nanosecond)
+	if got := handler.requests.Load(); got != 2 {
+		t.Errorf("expected backoff after second failure, got %d requests", got)
+	}
+	clock.Advance(4*interval/10 + time.Nanosecond)
+
+	if got := receiveStats(t, updates); got != *stats {
+		t.Errorf("unexpected snapshot: %+v", got)
+	}
+
+	// A success resets the backoff to the plain interval
+	clock.BlockUntil(t, 1)
+	clock.Advance(interval + interval/10)
+	clock.BlockUntil(t, 1)
+	if got := handler.requests.Load(); got != 4 {
+		t.Errorf("expected 4 requests, got %d", got)
+	}
+
+	cancel()
+	waitClosed(t, updates)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(watchErrors) != 2 {
+		t.Fatalf("expected 2 reported errors, got %d", len(watchErrors))
+	}
+	for _, err := range watchErrors {
+		if bento.CodeOf(err) != bento.CodeServerError {
+			t.Errorf("expected server error, got %v", err)
+		}
+	}
+}
+
+func TestWatchSiteStatsValidation(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Error("unexpected request")
+		return mockResponse(http.StatusOK, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if _, err := client.WatchSiteStats(context.Background(), 0); !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+package bento
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StreamImportOptions controls ImportSubscribersStream
+type StreamImportOptions struct {
+	// ChunkSize is the number of subscribers sent per request. Defaults to
+	// BatchLimits.MaxSubscribersPerRequest and cannot exceed 1000.
+	ChunkSize int
+
+	// StopOnInvalidLine fails the import at the first line that is not a
+	// valid subscriber instead of skipping it. Chunks already sent are kept.
+	StopOnInvalidLine bool
+
+	// StopOnError stops at the first chunk that fails or has rejected
+	// subscribers instead of reading on
+	StopOnError bool
+
+	// Progress, when set, is called after each chunk completes with the
+	// number of lines read so far, the number of subscribers sent and the
+	// chunk's error, if any
+	Progress func(lines, submitted int, lastErr error)
+}
+
+// ImportLineError describes a line of a JSON Lines stream that could not be
+// read as a subscriber
+type ImportLineError struct {
+	// Line is the 1-based line number in the stream
+	Line int
+	Err  error
+}
+
+func (e *ImportLineError) Error() string { return fmt.Sprintf("line %d: %v", e.Line, e.Err) }
+
+func (e *ImportLineError) Unwrap() error { return e.Err }
+
+// Code classifies the error by its cause
+func (e *ImportLineError) Code() ErrorCode { return CodeOf(e.Err) }
+
+// ImportSubscribersStream imports subscribers from r, which holds one JSON
+// encoded SubscriberInput per line, without reading the whole stream into
+// memory. Each line is validated as it is read and subscribers are sent in
+// chunks as soon as a chunk is full. Blank lines are ignored.
+//
+// Malformed and invalid lines are skipped and listed in the result's
+// InvalidLines, unless StopOnInvalidLine is set. The error joins an
+// *ImportLineError for each of them with any import failure, reported as by
+// ImportSubscribersWithOptions. The result is returned even when err is not nil.
+func (c *Client) ImportSubscribersStream(ctx context.Context, r io.Reader, opts StreamImportOptions, reqOpts ...RequestOption) (*ImportResult, error) {
+	ctx = withRequestOptions(ctx, reqOpts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	chunkSize, err := c.importChunkSize(opts.ChunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	reader := bufio.NewReader(r)
+	var (
+		lines   int
+		chunk   []*SubscriberInput
+		lineErr []error
+	)
+
+	// flush sends the buffered chunk, reporting whether the import should stop
+	flush := func() (bool, error) {
+		if len(chunk) == 0 {
+			return false, nil
+		}
+		if err := checkContext(ctx); err != nil {
+			return true, err
+		}
+
+		start := result.Submitted
+		subscribers := c.formatFieldTimes(dedupeSubscriberTags(chunk))
+		queued, failed, err := c.importChunk(ctx, subscribers)
+		result.Queued += queued
+		result.Failed += failed
+		result.Submitted += len(chunk)
+		result.Chunks++
+		chunk = nil
+
+		if opts.Progress != nil {
+			opts.Progress(lines, result.Submitted, err)
+		}
+		if err != nil {
+			chunkErr := &ImportChunkError{Start: start, Size: len(subscribers), Err: err}
+			result.Failures = append(result.Failures, chunkErr)
+			if opts.StopOnError || ctx.Err() != nil {
+				return true, chunkErr
+			}
+		}
+		return opts.StopOnError && failed > 0, nil
+	}
+
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if len(line) > 0 || readErr == nil {
+			lines++
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			sub, err := c.decodeStreamLine(line)
+			if err != nil {
+				invalid := &ImportLineError{Line: lines, Err: err}
+				result.InvalidLines = append(result.InvalidLines, invalid)
+				if opts.StopOnInvalidLine {
+					return result, invalid
+				}
+				lineErr = append(lineErr, invalid)
+			} else {
+				chunk = append(chunk, sub)
+			}
+		}
+
+		if len(chunk) == chunkSize || (errors.Is(readErr, io.EOF) && len(chunk) > 0) {
+			if stop, err := flush(); stop || err != nil {
+				if err == nil {
+					err = &PartialFailureError{Operation: "import", Succeeded: result.Queued, Failed: result.Failed}
+				}
+				return result, errors.Join(append(lineErr, err)...)
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return result, errors.Join(append(lineErr, withCode(CodeOf(readErr), fmt.Errorf("reading line %d: %w", lines, readErr)))...)
+		}
+	}
+
+	if result.Submitted == 0 && len(lineErr) == 0 {
+		return result, fmt.Errorf("%w: stream has no subscribers", ErrInvalidRequest)
+	}
+
+	errs := lineErr
+	for _, failure := range result.Failures {
+		errs = append(errs, failure)
+	}
+	if result.Failed > 0 {
+		errs = append(errs, &PartialFailureError{Operation: "import", Succeeded: result.Queued, Failed: result.Failed})
+	}
+	if len(errs) == 1 {
+		return result, errs[0]
+	}
+	return result, errors.Join(errs...)
+}
+
+// decodeStreamLine reads one subscriber from a JSON Lines stream, normalized
+// and validated like the subscribers of ImportSubscribersWithOptions
+func (c *Client) decodeStreamLine(line []byte) (*SubscriberInput, error) {
+	var sub SubscriberInput
+	if err := json.Unmarshal(line, &sub); err != nil {
+		return nil, fmt.Errorf("%w: malformed JSON: %v", ErrInvalidRequest, err)
+	}
+	normalized := c.normalizeSubscribers([]*SubscriberInput{&sub})[0]
+	if err := validateSubscriberInput(normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
@@ -0,0 +1,150 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// streamFile builds a JSON Lines stream of n subscribers with a malformed
+// line after the third
+func streamFile(n int) string {
+	var file strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&file, `{"email":"user%d@example.com","first_name":"User %d"}`+"\n", i, i)
+		if i == 2 {
+			file.WriteString(`{"email": "broken@example.com"` + "\n")
+		}
+	}
+	return file.String()
+}
+
+// batchRecorder records the emails sent in each import request
+func batchRecorder(t *testing.T, chunks *[][]string, status int) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/batch/subscribers") {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		var body struct {
+			Subscribers []bento.SubscriberInput `json:"subscribers"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		var emails []string
+		for _, sub := range body.Subscribers {
+			emails = append(emails, sub.Email)
+		}
+		*chunks = append(*chunks, emails)
+		if status != http.StatusOK {
+			return mockResponse(status, map[string]string{"error": "boom"}), nil
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": len(emails), "failed": 0}), nil
+	}
+}
+
+func TestImportSubscribersStream(t *testing.T) {
+	var chunks [][]string
+	client, err := setupTestClient(batchRecorder(t, &chunks, http.StatusOK))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	var progress [][2]int
+	result, err := client.ImportSubscribersStream(context.Background(), strings.NewReader(streamFile(5)), bento.StreamImportOptions{
+		ChunkSize: 2,
+		Progress: func(lines, submitted int, lastErr error) {
+			if lastErr != nil {
+				t.Errorf("unexpected chunk error: %v", lastErr)
+			}
+			progress = append(progress, [2]int{lines, submitted})
+		},
+	})
+
+	wantChunks := [][]string{
+		{"user0@example.com", "user1@example.com"},
+		{"user2@example.com", "user3@example.com"},
+		{"user4@example.com"},
+	}
+	if !reflect.DeepEqual(chunks, wantChunks) {
+		t.Errorf("expected chunks %v, got %v", wantChunks, chunks)
+	}
+	if want := [][2]int{{2, 2}, {5, 4}, {6, 5}}; !reflect.DeepEqual(progress, want) {
+		t.Errorf("expected progress %v, got %v", want, progress)
+	}
+	if result == nil || result.Queued != 5 || result.Submitted != 5 || result.Chunks != 3 {
+		t.Fatalf("expected 5 subscribers queued in 3 chunks, got %+v", result)
+	}
+	if len(result.InvalidLines) != 1 || result.InvalidLines[0].Line != 4 {
+		t.Fatalf("expected line 4 to be recorded, got %+v", result.InvalidLines)
+	}
+
+	var lineErr *bento.ImportLineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 4 || !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected line 4 to be reported as malformed, got %v", err)
+	}
+}
+
+func TestImportSubscribersStreamStopOnInvalidLine(t *testing.T) {
+	var chunks [][]string
+	client, err := setupTestClient(batchRecorder(t, &chunks, http.StatusOK))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	stream := streamFile(2) + `{"email":"not-an-email"}` + "\n" + `{"email":"late@example.com"}`
+	result, err := client.ImportSubscribersStream(context.Background(), strings.NewReader(stream), bento.StreamImportOptions{
+		ChunkSize:         1,
+		StopOnInvalidLine: true,
+	})
+
+	var lineErr *bento.ImportLineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 3 || !errors.Is(err, bento.ErrInvalidEmail) {
+		t.Fatalf("expected line 3 to stop the import, got %v", err)
+	}
+	if len(chunks) != 2 || result.Submitted != 2 {
+		t.Errorf("expected the two lines before it to be sent, got %v and %+v", chunks, result)
+	}
+}
+
+func TestImportSubscribersStreamChunkFailure(t *testing.T) {
+	var chunks [][]string
+	client, err := setupTestClient(batchRecorder(t, &chunks, http.StatusInternalServerError))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	result, err := client.ImportSubscribersStream(context.Background(), strings.NewReader(streamFile(4)), bento.StreamImportOptions{
+		ChunkSize:   2,
+		StopOnError: true,
+	})
+
+	var chunkErr *bento.ImportChunkError
+	if !errors.As(err, &chunkErr) || chunkErr.Start != 0 || chunkErr.Size != 2 {
+		t.Fatalf("expected the first chunk to fail, got %v", err)
+	}
+	if len(chunks) != 1 || result.Submitted != 2 || len(result.Failures) != 1 {
+		t.Errorf("expected the import to stop after one chunk, got %v and %+v", chunks, result)
+	}
+}
+
+func TestImportSubscribersStreamEmpty(t *testing.T) {
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request: %s", req.URL)
+		return mockResponse(http.StatusOK, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	if _, err := client.ImportSubscribersStream(context.Background(), strings.NewReader("\n\n"), bento.StreamImportOptions{}); !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package bento
+
+import "context"
+
+// SubscriberIterator walks every subscriber matched by ListSubscribers,
+// fetching one page at a time as the previous page is used up. It does no
+// background work, so it can be abandoned at any point.
+//
+//	it := client.Subscribers(ctx, nil)
+//	for it.Next() {
+//		subscriber := it.Subscriber()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type SubscriberIterator struct {
+	client  *Client
+	ctx     context.Context
+	opts    ListSubscribersOptions
+	reqOpts []RequestOption
+	// query, when set, fetches pages with SearchSubscribers instead
+	query *SubscriberQuery
+
+	page    *SubscriberPage
+	index   int
+	current *SubscriberData
+	info    PageInfo
+	err     error
+}
+
+// PageInfo reports the progress of a SubscriberIterator
+type PageInfo struct {
+	// Pages is the number of pages fetched so far
+	Pages int
+	// Subscribers is the number of subscribers returned by Next so far
+	Subscribers int
+	// NextPage and NextCursor locate the page that will be fetched next, and
+	// are both empty after the last page
+	NextPage   int
+	NextCursor string
+}
+
+// Subscribers returns an iterator over the site's subscribers, starting at
+// the page selected by opts, which may be nil. Every page is requested with
+// ctx and reqOpts.
+func (c *Client) Subscribers(ctx context.Context, opts *ListSubscribersOptions, reqOpts ...RequestOption) *SubscriberIterator {
+	it := &SubscriberIterator{client: c, ctx: ctx, reqOpts: reqOpts}
+	if opts != nil {
+		it.opts = *opts
+	}
+	return it
+}
+
+// Next advances to the next subscriber, fetching the following page when
+// needed. It returns false when every subscriber has been returned or a
+// request fails; check Err to tell the two apart.
+func (it *SubscriberIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.page == nil || it.index >= len(it.page.Subscribers) {
+		if it.page != nil {
+			if !it.page.HasMore() {
+				it.current = nil
+				return false
+			}
+			it.opts = *it.page.NextOptions(&it.opts)
+		}
+
+		page, err := it.fetch()
+		if err != nil {
+			it.err = err
+			it.current = nil
+			return false
+		}
+		it.page, it.index = page, 0
+		it.info.Pages++
+		it.info.NextPage, it.info.NextCursor = page.NextPage, page.NextCursor
+	}
+
+	it.current = &it.page.Subscribers[it.index]
+	it.index++
+	it.info.Subscribers++
+	return true
+}
+
+// fetch requests the page selected by it.opts
+func (it *SubscriberIterator) fetch() (*SubscriberPage, error) {
+	if it.query == nil {
+		return it.client.ListSubscribers(it.ctx, &it.opts, it.reqOpts...)
+	}
+	query := *it.query
+	query.ListSubscribersOptions = it.opts
+	return it.client.SearchSubscribers(it.ctx, query, it.reqOpts...)
+}
+
+// Subscriber returns the subscriber Next advanced to, or nil once Next has
+// returned false
+func (it *SubscriberIterator) Subscriber() *SubscriberData {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *SubscriberIterator) Err() error {
+	return it.err
+}
+
+// PageInfo reports how far the iteration has got
+func (it *SubscriberIterator) PageInfo() PageInfo {
+	return it.info
+}
@@ -0,0 +1,154 @@
+package bento_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// pagedSubscribers serves three pages of two subscribers each, failing the
+// page numbered failPage with a 500 when it is not zero
+func pagedSubscribers(t *testing.T, failPage int, requested *[]string) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		page := req.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		*requested = append(*requested, page)
+
+		var n int
+		_, _ = fmt.Sscan(page, &n)
+		if n == failPage {
+			return mockResponse(http.StatusInternalServerError, map[string]string{"error": "boom"}), nil
+		}
+		if n < 1 || n > 3 {
+			t.Errorf("unexpected page %s", page)
+		}
+
+		data := []interface{}{}
+		for i := 1; i <= 2; i++ {
+			data = append(data, map[string]interface{}{
+				"id":   fmt.Sprintf("sub_%d_%d", n, i),
+				"type": "subscriber",
+			})
+		}
+		meta := map[string]interface{}{"page": n, "total_pages": 3}
+		return mockResponse(http.StatusOK, map[string]interface{}{"data": data, "meta": meta}), nil
+	}
+}
+
+func TestSubscriberIterator(t *testing.T) {
+	var requested []string
+	client, err := setupTestClient(pagedSubscribers(t, 0, &requested))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	it := client.Subscribers(context.Background(), nil)
+	if len(requested) != 0 {
+		t.Errorf("expected no request before Next, got %v", requested)
+	}
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Subscriber().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"sub_1_1", "sub_1_2", "sub_2_1", "sub_2_2", "sub_3_1", "sub_3_2"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+	if !reflect.DeepEqual(requested, []string{"1", "2", "3"}) {
+		t.Errorf("expected each page to be fetched once, got %v", requested)
+	}
+	if info := it.PageInfo(); info.Pages != 3 || info.Subscribers != 6 || info.NextPage != 0 {
+		t.Errorf("expected 3 pages and 6 subscribers with no next page, got %+v", info)
+	}
+	if it.Next() || it.Subscriber() != nil {
+		t.Error("expected the iterator to stay exhausted")
+	}
+	if len(requested) != 3 {
+		t.Errorf("expected no request after the last page, got %v", requested)
+	}
+}
+
+func TestSubscriberIteratorEarlyTermination(t *testing.T) {
+	var requested []string
+	client, err := setupTestClient(pagedSubscribers(t, 0, &requested))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	it := client.Subscribers(context.Background(), nil)
+	for i := 0; i < 3; i++ {
+		if !it.Next() {
+			t.Fatalf("expected subscriber %d, got %v", i, it.Err())
+		}
+	}
+
+	if it.Subscriber().ID != "sub_2_1" {
+		t.Errorf("expected to stop at sub_2_1, got %s", it.Subscriber().ID)
+	}
+	if !reflect.DeepEqual(requested, []string{"1", "2"}) {
+		t.Errorf("expected only the pages that were used, got %v", requested)
+	}
+	if info := it.PageInfo(); info.Pages != 2 || info.NextPage != 3 {
+		t.Errorf("expected 2 pages fetched with page 3 next, got %+v", info)
+	}
+}
+
+func TestSubscriberIteratorError(t *testing.T) {
+	var requested []string
+	client, err := setupTestClient(pagedSubscribers(t, 2, &requested))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	it := client.Subscribers(context.Background(), nil)
+	var count int
+	for it.Next() {
+		count++
+	}
+
+	if count != 2 {
+		t.Errorf("expected the first page's 2 subscribers, got %d", count)
+	}
+	if status, ok := bento.HTTPStatus(it.Err()); !ok || status != http.StatusInternalServerError {
+		t.Errorf("expected the page two failure from Err, got %v", it.Err())
+	}
+	if it.Next() || len(requested) != 2 {
+		t.Errorf("expected no retry after the failure, requested %v", requested)
+	}
+}
+
+func TestSubscriberIteratorContext(t *testing.T) {
+	var requested []string
+	client, err := setupTestClient(pagedSubscribers(t, 0, &requested))
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := client.Subscribers(ctx, &bento.ListSubscribersOptions{PerPage: 2})
+	it.Next()
+	it.Next()
+	cancel()
+
+	if it.Next() {
+		t.Error("expected no subscribers after cancellation")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", it.Err())
+	}
+	if len(requested) != 1 {
+		t.Errorf("expected no request after cancellation, got %v", requested)
+	}
+}
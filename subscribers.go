@@ -4,9 +4,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/mail"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 )
 
 // SubscriberInput represents the data structure for creating/importing subscribers
@@ -19,12 +28,220 @@ type SubscriberInput struct {
 	Fields     map[string]interface{} `json:"fields,omitempty"`
 }
 
+// ImportOptions controls optional behaviour of ImportSubscribersWithOptions
+type ImportOptions struct {
+	// EnsureFields creates any custom field keys used by the batch that do not
+	// exist yet before the subscribers are sent
+	EnsureFields bool
+
+	// EnsureTags creates any tags used by the batch that do not exist yet
+	// before the subscribers are sent
+	EnsureTags bool
+
+	// StrictTags fails the import with ErrInvalidTags, listing every tag that
+	// does not exist yet, instead of creating them. It cannot be combined with EnsureTags.
+	StrictTags bool
+
+	// TagConcurrency bounds the number of concurrent tag creations. Defaults to 4.
+	TagConcurrency int
+
+	// ChunkSize is the number of subscribers sent per request. Larger
+	// imports are split and sent one chunk at a time, unless
+	// BatchLimits.DisableChunking is set. Defaults to
+	// BatchLimits.MaxSubscribersPerRequest and cannot exceed 1000.
+	ChunkSize int
+
+	// StopOnError stops a chunked import at the first chunk that fails or
+	// has rejected subscribers instead of sending the remaining chunks
+	StopOnError bool
+
+	// Concurrency is the number of chunks sent at once. Chunks are started in
+	// order but may complete in any order. Defaults to 1.
+	Concurrency int
+
+	// Progress, when set, is called after each chunk completes with the
+	// number of subscribers in completed chunks, the total being imported and
+	// the chunk's error, if any. Calls are never concurrent.
+	Progress func(done, total int, lastErr error)
+
+	// DryRun validates the subscribers and reports in ImportResult.Chunks
+	// how many requests the import would take, without sending anything.
+	// EnsureFields, EnsureTags and StrictTags are not checked.
+	DryRun bool
+}
+
+// ImportResult reports the outcome of a subscriber import
+type ImportResult struct {
+	// Queued and Failed total the subscribers the API accepted and rejected
+	// across all chunks
+	Queued int
+	Failed int
+	// Submitted is the number of subscribers, from the start of the slice,
+	// in chunks that were sent before the import stopped
+	Submitted int
+	// Chunks is the number of import requests sent, or with DryRun, the
+	// number a real import would send
+	Chunks        int
+	CreatedFields []string
+	CreatedTags   []string
+	// Failures lists every chunk whose request failed, ordered by Start
+	Failures []*ImportChunkError
+	// InvalidLines lists the lines ImportSubscribersStream skipped
+	InvalidLines []*ImportLineError
+}
+
+// ImportChunkError describes a chunk of a chunked import whose request failed
+type ImportChunkError struct {
+	// Start is the position in the input slice of the chunk's first subscriber
+	Start int
+	Size  int
+	Err   error
+}
+
+func (e *ImportChunkError) Error() string {
+	return fmt.Sprintf("import chunk %d-%d: %v", e.Start, e.Start+e.Size-1, e.Err)
+}
+
+func (e *ImportChunkError) Unwrap() error { return e.Err }
+
+// defaultTagConcurrency bounds concurrent tag creation during imports
+const defaultTagConcurrency = 4
+
+// defaultLookupConcurrency bounds concurrent lookups in FindSubscribers
+const defaultLookupConcurrency = 4
+
 // FindSubscriber retrieves a subscriber by email
-func (c *Client) FindSubscriber(ctx context.Context, email string) (*SubscriberData, error) {
+func (c *Client) FindSubscriber(ctx context.Context, email string, opts ...RequestOption) (*SubscriberData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	email = c.normalizeEmail(email)
 	if _, err := mail.ParseAddress(email); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrInvalidEmail, email)
 	}
 
+	return c.findSubscriber(ctx, "email", email)
+}
+
+// FindOptions controls FindSubscriberWithOptions
+type FindOptions struct {
+	// ExcludeUnsubscribed reports an unsubscribed subscriber as
+	// ErrSubscriberNotFound instead of returning it
+	ExcludeUnsubscribed bool
+}
+
+// FindSubscriberWithOptions retrieves a subscriber by email like
+// FindSubscriber, applying opts
+func (c *Client) FindSubscriberWithOptions(ctx context.Context, email string, opts FindOptions, reqOpts ...RequestOption) (*SubscriberData, error) {
+	subscriber, err := c.FindSubscriber(ctx, email, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ExcludeUnsubscribed && subscriber.Attributes.UnsubscribedAt != nil {
+		return nil, fmt.Errorf("%w: %s is unsubscribed", ErrSubscriberNotFound, email)
+	}
+	return subscriber, nil
+}
+
+// FindSubscriberByUUID retrieves a subscriber by the uuid Bento assigned it,
+// as referenced by webhooks and exports
+func (c *Client) FindSubscriberByUUID(ctx context.Context, uuid string, opts ...RequestOption) (*SubscriberData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(uuid) == "" {
+		return nil, fmt.Errorf("%w: subscriber UUID is required", ErrInvalidRequest)
+	}
+
+	return c.findSubscriber(ctx, "uuid", uuid)
+}
+
+// FindSubscribers looks up several subscribers by email, a few at a time,
+// and returns them keyed by the email as given. Duplicate emails are looked
+// up once, and emails with no subscriber are left out of the map. Any other
+// failure, such as ErrUnauthorized, cancels the remaining lookups and is
+// returned with the email it occurred for.
+func (c *Client) FindSubscribers(ctx context.Context, emails []string, opts ...RequestOption) (map[string]*SubscriberData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("%w: no emails provided", ErrInvalidRequest)
+	}
+	if err := validateEntries(emails, func(email string) error {
+		if _, err := mail.ParseAddress(c.normalizeEmail(email)); err != nil {
+			return invalidField(ErrInvalidEmail, "email", email, "invalid email")
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		found    = make(map[string]*SubscriberData, len(emails))
+		seen     = make(map[string]struct{}, len(emails))
+		firstErr error
+		sem      = make(chan struct{}, defaultLookupConcurrency)
+	)
+	for _, email := range emails {
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(email string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			subscriber, err := c.FindSubscriber(ctx, email)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case errors.Is(err, ErrSubscriberNotFound):
+			case err != nil:
+				if firstErr == nil {
+					firstErr = withCode(CodeOf(err), fmt.Errorf("finding subscriber %s: %w", email, err))
+					cancel()
+				}
+			default:
+				found[email] = subscriber
+			}
+		}(email)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// findSubscriber looks up a subscriber by the given query parameter,
+// reporting a missing subscriber as ErrSubscriberNotFound
+func (c *Client) findSubscriber(ctx context.Context, param, value string) (*SubscriberData, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
 		fmt.Sprintf("%s/fetch/subscribers", c.baseURL), nil)
 	if err != nil {
@@ -32,39 +249,365 @@ func (c *Client) FindSubscriber(ctx context.Context, email string) (*SubscriberD
 	}
 
 	q := req.URL.Query()
-	q.Add("email", email)
+	q.Add(param, value)
 	req.URL.RawQuery = q.Encode()
 
 	resp, err := c.do(req)
 	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s: %w", ErrSubscriberNotFound, value, err)
+		}
 		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var response struct {
 		Data SubscriberData `json:"data"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := c.decodeJSON(req, resp, &response, "data"); err != nil {
+		return nil, err
 	}
 
 	if response.Data.ID == "" {
-		return nil, fmt.Errorf("subscriber not found: %s", email)
+		return nil, fmt.Errorf("%w: %s", ErrSubscriberNotFound, value)
 	}
 
 	return &response.Data, nil
 }
 
+// RemoveSubscriber permanently deletes the subscriber with the given email,
+// for right-to-erasure requests. An unknown email fails with
+// ErrSubscriberNotFound. The email must be a complete address; wildcards are
+// refused with ErrInvalidEmail so a typo can never match other subscribers.
+func (c *Client) RemoveSubscriber(ctx context.Context, email string, opts ...RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	email = c.normalizeEmail(email)
+	if _, err := mail.ParseAddress(email); err != nil || strings.ContainsAny(email, "*%") {
+		return fmt.Errorf("%w: %q", ErrInvalidEmail, email)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete,
+		fmt.Sprintf("%s/fetch/subscribers", c.baseURL), nil)
+	if err != nil {
+		return err
+	}
+
+	q := req.URL.Query()
+	q.Add("email", email)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("%w: %s: %w", ErrSubscriberNotFound, email, err)
+		}
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return c.statusError(req, resp)
+	}
+
+	return nil
+}
+
+// ListSubscribersOptions selects a page of ListSubscribers results. A nil or
+// zero value fetches the first page.
+type ListSubscribersOptions struct {
+	// Page is the page number, starting at 1. It is ignored when Cursor is set.
+	Page int
+	// Cursor continues a listing from SubscriberPage.NextCursor
+	Cursor string
+	// PerPage is the number of subscribers per page. Zero uses the API default.
+	PerPage int
+}
+
+// SubscriberPage is one page of subscribers returned by ListSubscribers
+type SubscriberPage struct {
+	Subscribers []SubscriberData
+	// NextPage is the number of the following page, or 0 on the last page
+	NextPage int
+	// NextCursor continues after this page when the API paginates by
+	// cursor, or is empty on the last page
+	NextCursor string
+
+	// total counts every matching subscriber when the API reports it
+	total      int
+	totalKnown bool
+}
+
+// HasMore reports whether another page follows this one
+func (p *SubscriberPage) HasMore() bool {
+	return p.NextPage > 0 || p.NextCursor != ""
+}
+
+// NextOptions returns the options that fetch the following page, keeping PerPage
+func (p *SubscriberPage) NextOptions(opts *ListSubscribersOptions) *ListSubscribersOptions {
+	next := &ListSubscribersOptions{Page: p.NextPage, Cursor: p.NextCursor}
+	if opts != nil {
+		next.PerPage = opts.PerPage
+	}
+	return next
+}
+
+// ListSubscribers fetches one page of the site's subscribers. Follow
+// HasMore and NextOptions to walk every page.
+func (c *Client) ListSubscribers(ctx context.Context, opts *ListSubscribersOptions, reqOpts ...RequestOption) (*SubscriberPage, error) {
+	ctx = withRequestOptions(ctx, reqOpts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.fetchSubscriberPage(ctx, opts, nil)
+}
+
+// SubscriberQuery filters SearchSubscribers results on the server. At least
+// one filter is required, and every filter set must match. The embedded
+// ListSubscribersOptions selects the page.
+type SubscriberQuery struct {
+	// TagName or TagID matches subscribers with the tag; set at most one
+	TagName string
+	TagID   string
+
+	// FieldKey and FieldValue match subscribers whose custom field has the
+	// value. FieldValue requires FieldKey.
+	FieldKey   string
+	FieldValue string
+
+	// Unsubscribed matches only unsubscribed subscribers when true and only
+	// subscribed ones when false
+	Unsubscribed *bool
+
+	// CreatedAfter and CreatedBefore bound when subscribers were created
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	ListSubscribersOptions
+}
+
+// hasFilters reports whether the query sets any filter, as opposed to only
+// paging options
+func (q *SubscriberQuery) hasFilters() bool {
+	return q.TagName != "" || q.TagID != "" || q.FieldKey != "" || q.FieldValue != "" ||
+		q.Unsubscribed != nil || !q.CreatedAfter.IsZero() || !q.CreatedBefore.IsZero()
+}
+
+// values translates the query into URL parameters, rejecting combinations
+// the API cannot answer instead of letting them match every subscriber
+func (q *SubscriberQuery) values() (url.Values, error) {
+	v := url.Values{}
+	switch {
+	case q.TagName != "" && q.TagID != "":
+		return nil, fmt.Errorf("%w: TagName and TagID cannot be combined", ErrInvalidRequest)
+	case q.TagName != "":
+		v.Set("tag_name", q.TagName)
+	case q.TagID != "":
+		v.Set("tag_id", q.TagID)
+	}
+
+	if q.FieldValue != "" && q.FieldKey == "" {
+		return nil, fmt.Errorf("%w: FieldValue requires FieldKey", ErrInvalidRequest)
+	}
+	if q.FieldKey != "" {
+		v.Set("field_key", q.FieldKey)
+		v.Set("field_value", q.FieldValue)
+	}
+
+	if q.Unsubscribed != nil {
+		v.Set("unsubscribed", strconv.FormatBool(*q.Unsubscribed))
+	}
+
+	if !q.CreatedAfter.IsZero() && !q.CreatedBefore.IsZero() && !q.CreatedAfter.Before(q.CreatedBefore) {
+		return nil, fmt.Errorf("%w: CreatedAfter must be before CreatedBefore", ErrInvalidRequest)
+	}
+	if !q.CreatedAfter.IsZero() {
+		v.Set("created_after", q.CreatedAfter.UTC().Format(time.RFC3339))
+	}
+	if !q.CreatedBefore.IsZero() {
+		v.Set("created_before", q.CreatedBefore.UTC().Format(time.RFC3339))
+	}
+
+	if len(v) == 0 {
+		return nil, fmt.Errorf("%w: at least one filter is required; use ListSubscribers to list everyone", ErrInvalidRequest)
+	}
+	return v, nil
+}
+
+// SearchSubscribers fetches one page of the subscribers matching query,
+// filtered by the API. Pages are followed as with ListSubscribers.
+func (c *Client) SearchSubscribers(ctx context.Context, query SubscriberQuery, opts ...RequestOption) (*SubscriberPage, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	filters, err := query.values()
+	if err != nil {
+		return nil, err
+	}
+	return c.fetchSubscriberPage(ctx, &query.ListSubscribersOptions, filters)
+}
+
+// fetchSubscriberPage requests one page of subscribers matching filters
+func (c *Client) fetchSubscriberPage(ctx context.Context, opts *ListSubscribersOptions, filters url.Values) (*SubscriberPage, error) {
+	if opts == nil {
+		opts = &ListSubscribersOptions{}
+	}
+	if opts.Page < 0 || opts.PerPage < 0 {
+		return nil, fmt.Errorf("%w: page and per page must be non-negative", ErrInvalidRequest)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/fetch/subscribers", c.baseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	for key, values := range filters {
+		q[key] = values
+	}
+	switch {
+	case opts.Cursor != "":
+		q.Set("cursor", opts.Cursor)
+	case opts.Page > 0:
+		q.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.statusError(req, resp)
+	}
+
+	var response struct {
+		Data []SubscriberData           `json:"data"`
+		Meta map[string]json.RawMessage `json:"meta"`
+	}
+	if err := c.decodeJSON(req, resp, &response, "data"); err != nil {
+		return nil, err
+	}
+
+	page := &SubscriberPage{Subscribers: response.Data}
+	if page.Subscribers == nil {
+		page.Subscribers = []SubscriberData{}
+	}
+	page.NextPage, page.NextCursor = nextSubscriberPage(response.Meta)
+	page.total, page.totalKnown = subscriberTotal(response.Meta)
+	return page, nil
+}
+
+// subscriberTotal reads the number of matching subscribers from a
+// response's meta block, from total_count or total
+func subscriberTotal(meta map[string]json.RawMessage) (int, bool) {
+	for _, key := range []string{"total_count", "total"} {
+		var total int
+		if raw, ok := meta[key]; ok && json.Unmarshal(raw, &total) == nil && total >= 0 {
+			return total, true
+		}
+	}
+	return 0, false
+}
+
+// CountSubscribers returns the number of subscribers matching query, or of
+// active subscribers when query is nil or sets no filters. It reads the total
+// from the first page when the API reports one and otherwise pages through
+// every match, which takes one request per page on large sites.
+func (c *Client) CountSubscribers(ctx context.Context, query *SubscriberQuery, opts ...RequestOption) (int, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	q := SubscriberQuery{Unsubscribed: new(bool)}
+	if query != nil && query.hasFilters() {
+		q = *query
+	} else if query != nil {
+		q.ListSubscribersOptions = query.ListSubscribersOptions
+	}
+
+	page, err := c.SearchSubscribers(ctx, q)
+	if err != nil {
+		return 0, err
+	}
+	if page.totalKnown {
+		return page.total, nil
+	}
+
+	count := len(page.Subscribers)
+	for page.HasMore() {
+		q.ListSubscribersOptions = *page.NextOptions(&q.ListSubscribersOptions)
+		if page, err = c.SearchSubscribers(ctx, q); err != nil {
+			return 0, err
+		}
+		count += len(page.Subscribers)
+	}
+	return count, nil
+}
+
+// nextSubscriberPage reads the following page from a response's meta block.
+// It accepts next_cursor, next_page, or page with total_pages, and ignores
+// any other keys so new metadata does not break decoding.
+func nextSubscriberPage(meta map[string]json.RawMessage) (int, string) {
+	var cursor string
+	if raw, ok := meta["next_cursor"]; ok && json.Unmarshal(raw, &cursor) == nil && cursor != "" {
+		return 0, cursor
+	}
+
+	var next int
+	if raw, ok := meta["next_page"]; ok && json.Unmarshal(raw, &next) == nil && next > 0 {
+		return next, ""
+	}
+
+	var page, total int
+	if json.Unmarshal(meta["page"], &page) == nil && json.Unmarshal(meta["total_pages"], &total) == nil && page < total {
+		return page + 1, ""
+	}
+	return 0, ""
+}
+
 // CreateSubscriber creates a new subscriber
-func (c *Client) CreateSubscriber(ctx context.Context, input *SubscriberInput) (*SubscriberData, error) {
+func (c *Client) CreateSubscriber(ctx context.Context, input *SubscriberInput, opts ...RequestOption) (*SubscriberData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if input == nil {
+		return nil, fmt.Errorf("%w: subscriber input is required", ErrInvalidRequest)
+	}
+	input = c.normalizeSubscribers([]*SubscriberInput{input})[0]
 	if _, err := mail.ParseAddress(input.Email); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrInvalidEmail, input.Email)
 	}
+	if err := validateTagNames("tags", input.Tags); err != nil {
+		return nil, err
+	}
+	if err := validateTagNames("remove_tags", input.RemoveTags); err != nil {
+		return nil, err
+	}
+	if err := validateFieldValues(input.Fields); err != nil {
+		return nil, err
+	}
+	input = c.formatFieldTimes(dedupeSubscriberTags([]*SubscriberInput{input}))[0]
 
 	body, err := json.Marshal(map[string]interface{}{
 		"subscriber": input,
@@ -86,67 +629,561 @@ func (c *Client) CreateSubscriber(ctx context.Context, input *SubscriberInput) (
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var response struct {
 		Data SubscriberData `json:"data"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := c.decodeJSON(req, resp, &response, "data"); err != nil {
+		return nil, err
 	}
 
 	return &response.Data, nil
 }
 
-// ImportSubscribers imports multiple subscribers in batch
-func (c *Client) ImportSubscribers(ctx context.Context, subscribers []*SubscriberInput) error {
+// UpdateSubscriber changes the name, tags or custom fields of the existing
+// subscriber with the given email and returns the updated record. Tags are
+// added, RemoveTags are removed and only the fields present in input are
+// set. It fails with ErrSubscriberNotFound instead of creating a subscriber.
+// To change the email itself, use CommandChangeEmail.
+func (c *Client) UpdateSubscriber(ctx context.Context, email string, input *SubscriberInput, opts ...RequestOption) (*SubscriberData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	email = c.normalizeEmail(email)
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEmail, email)
+	}
+	if input == nil {
+		return nil, fmt.Errorf("%w: subscriber input is required", ErrInvalidRequest)
+	}
+	if input.Email != "" && !strings.EqualFold(c.normalizeEmail(input.Email), email) {
+		return nil, fmt.Errorf("%w: use CommandChangeEmail to change a subscriber's email", ErrInvalidRequest)
+	}
+
+	update := *input
+	update.Email = email
+	if err := validateSubscriberInput(&update); err != nil {
+		return nil, err
+	}
+	return c.updateSubscriber(ctx, &update)
+}
+
+// updateSubscriber sends input, which has been validated, to the update
+// route of the subscriber with its email and returns the record the API
+// reports. Unlike an import, the route never creates a subscriber.
+func (c *Client) updateSubscriber(ctx context.Context, input *SubscriberInput) (*SubscriberData, error) {
+	input = c.formatFieldTimes(dedupeSubscriberTags([]*SubscriberInput{input}))[0]
+
+	body, err := json.Marshal(map[string]interface{}{
+		"subscriber": input,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch,
+		fmt.Sprintf("%s/fetch/subscribers", c.baseURL), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("email", input.Email)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s: %w", ErrSubscriberNotFound, input.Email, err)
+		}
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.statusError(req, resp)
+	}
+
+	var response struct {
+		Data SubscriberData `json:"data"`
+	}
+
+	if err := c.decodeJSON(req, resp, &response, "data"); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// UpsertSubscriber creates the subscriber described by input, or when one
+// with the same email exists, applies its name, Tags, RemoveTags and Fields
+// to it, and returns the resulting record. A subscriber created by someone
+// else between the lookup and the create is updated instead.
+func (c *Client) UpsertSubscriber(ctx context.Context, input *SubscriberInput, opts ...RequestOption) (*SubscriberData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if input == nil {
+		return nil, fmt.Errorf("%w: subscriber input is required", ErrInvalidRequest)
+	}
+	input = c.normalizeSubscribers([]*SubscriberInput{input})[0]
+	if err := validateSubscriberInput(input); err != nil {
+		return nil, err
+	}
+
+	_, err := c.FindSubscriber(ctx, input.Email)
+	if errors.Is(err, ErrSubscriberNotFound) {
+		created, err := c.CreateSubscriber(ctx, input)
+		if status, ok := HTTPStatus(err); !ok || status != http.StatusConflict {
+			return created, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return c.updateSubscriber(ctx, input)
+}
+
+// ImportSubscribers imports multiple subscribers in batch. It reports only
+// whether the import fully succeeded; to record how many subscribers were
+// queued, use ImportSubscribersWithOptions, which also returns an ImportResult.
+func (c *Client) ImportSubscribers(ctx context.Context, subscribers []*SubscriberInput, opts ...RequestOption) error {
+	_, err := c.ImportSubscribersWithOptions(ctx, subscribers, nil, opts...)
+	return err
+}
+
+// ImportSubscribersWithOptions imports multiple subscribers in batch, applying
+// opts, which may be nil. The result is returned even when err is not nil: on
+// a partial failure it holds the Queued and Failed counts alongside a
+// *PartialFailureError, so callers can decide whether to accept the import.
+func (c *Client) ImportSubscribersWithOptions(ctx context.Context, subscribers []*SubscriberInput, opts *ImportOptions, reqOpts ...RequestOption) (*ImportResult, error) {
+	ctx = withRequestOptions(ctx, reqOpts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	if len(subscribers) == 0 {
-		return ErrInvalidRequest
+		return nil, ErrInvalidRequest
+	}
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	if opts.EnsureTags && opts.StrictTags {
+		return nil, fmt.Errorf("%w: EnsureTags and StrictTags cannot be combined", ErrInvalidRequest)
+	}
+	chunkSize, err := c.importChunkSize(opts.ChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkBatchSize(len(subscribers), chunkSize, "subscribers"); err != nil {
+		return nil, err
 	}
 
 	// Validate all emails before sending
-	for _, sub := range subscribers {
-		if _, err := mail.ParseAddress(sub.Email); err != nil {
-			return fmt.Errorf("%w: %s", ErrInvalidEmail, sub.Email)
+	subscribers = c.normalizeSubscribers(subscribers)
+	if err := validateEntries(subscribers, validateSubscriberInput); err != nil {
+		return nil, err
+	}
+	subscribers = c.formatFieldTimes(dedupeSubscriberTags(subscribers))
+
+	importResult := &ImportResult{}
+
+	if opts.DryRun {
+		importResult.Chunks = (len(subscribers) + chunkSize - 1) / chunkSize
+		return importResult, nil
+	}
+
+	// Create missing fields up front so no values are dropped by the API
+	if opts.EnsureFields {
+		for _, key := range distinctFieldKeys(subscribers) {
+			created, err := c.EnsureField(ctx, key)
+			if err != nil {
+				return importResult, withCode(CodeOf(err), fmt.Errorf("ensuring field %q: %w", key, err))
+			}
+			if created {
+				importResult.CreatedFields = append(importResult.CreatedFields, key)
+			}
+		}
+	}
+
+	if opts.EnsureTags || opts.StrictTags {
+		created, err := c.ensureImportTags(ctx, distinctTags(subscribers), opts)
+		importResult.CreatedTags = created
+		if err != nil {
+			return importResult, err
+		}
+	}
+
+	concurrency := max(opts.Concurrency, 1)
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		sem     = make(chan struct{}, concurrency)
+		done    int
+		stopped bool
+		// singleErr is the error of an import that fits in one request,
+		// which fails as it always has
+		singleErr error
+		// stopErr ends the import early: a canceled context or, with
+		// StopOnError, the first failed chunk
+		stopErr error
+	)
+
+dispatch:
+	for start := 0; start < len(subscribers); start += chunkSize {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			stopErr = ctx.Err()
+			mu.Unlock()
+			break dispatch
+		}
+		mu.Lock()
+		if !stopped && ctx.Err() != nil {
+			stopped, stopErr = true, ctx.Err()
 		}
+		if stopped {
+			mu.Unlock()
+			<-sem
+			break
+		}
+		end := min(start+chunkSize, len(subscribers))
+		importResult.Submitted = end
+		importResult.Chunks++
+		mu.Unlock()
+
+		wg.Add(1)
+		go func(start int, chunk []*SubscriberInput) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			queued, failed, err := c.importChunk(ctx, chunk)
+
+			mu.Lock()
+			defer mu.Unlock()
+			importResult.Queued += queued
+			importResult.Failed += failed
+			done += len(chunk)
+			switch {
+			case err != nil && len(subscribers) <= chunkSize:
+				singleErr = err
+			case err != nil:
+				chunkErr := &ImportChunkError{Start: start, Size: len(chunk), Err: err}
+				importResult.Failures = append(importResult.Failures, chunkErr)
+				if (opts.StopOnError || ctx.Err() != nil) && stopErr == nil {
+					stopped, stopErr = true, chunkErr
+				}
+			case failed > 0 && opts.StopOnError:
+				stopped = true
+			}
+			if opts.Progress != nil {
+				opts.Progress(done, len(subscribers), err)
+			}
+		}(start, subscribers[start:end])
+	}
+	wg.Wait()
+
+	sort.Slice(importResult.Failures, func(i, j int) bool {
+		return importResult.Failures[i].Start < importResult.Failures[j].Start
+	})
+	if singleErr != nil {
+		return importResult, singleErr
+	}
+	if stopErr != nil {
+		return importResult, stopErr
+	}
+
+	var errs []error
+	for _, failure := range importResult.Failures {
+		errs = append(errs, failure)
+	}
+	if importResult.Failed > 0 {
+		errs = append(errs, &PartialFailureError{Operation: "import", Succeeded: importResult.Queued, Failed: importResult.Failed})
 	}
+	if len(errs) == 1 {
+		return importResult, errs[0]
+	}
+	return importResult, errors.Join(errs...)
+}
 
+// importChunk sends one request of an import, returning the number of
+// subscribers the API queued and rejected
+func (c *Client) importChunk(ctx context.Context, subscribers []*SubscriberInput) (int, int, error) {
 	body, err := json.Marshal(map[string]interface{}{
 		"subscribers": subscribers,
 	})
 	if err != nil {
-		return err
+		return 0, 0, err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
 		fmt.Sprintf("%s/batch/subscribers", c.baseURL), bytes.NewBuffer(body))
 	if err != nil {
-		return err
+		return 0, 0, err
 	}
 
 	resp, err := c.do(req)
 	if err != nil {
-		return err
+		return 0, 0, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return 0, 0, c.statusError(req, resp)
 	}
 
 	var result struct {
 		Results int `json:"results"`
 		Failed  int `json:"failed"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.decodeJSON(req, resp, &result, "results"); err != nil {
+		return 0, 0, err
+	}
+	return result.Results, result.Failed, nil
+}
+
+// validateSubscriberInput checks a single subscriber before it is imported
+func validateSubscriberInput(sub *SubscriberInput) error {
+	if _, err := mail.ParseAddress(sub.Email); err != nil {
+		return invalidField(ErrInvalidEmail, "email", sub.Email, "invalid email")
+	}
+	if err := validateTagNames("tags", sub.Tags); err != nil {
 		return err
 	}
+	if err := validateTagNames("remove_tags", sub.RemoveTags); err != nil {
+		return err
+	}
+	return validateFieldValues(sub.Fields)
+}
+
+// jsonMarshalerType matches values that encode themselves
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// validateFieldValues rejects custom field values that cannot be sent as a
+// flat field: values JSON cannot encode, such as channels and funcs, and
+// values that would encode as nested objects. Scalars, time.Time and lists of
+// scalars are accepted.
+func validateFieldValues(fields map[string]interface{}) error {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if reason := fieldValueProblem(reflect.ValueOf(fields[key]), true); reason != "" {
+			return invalidField(ErrInvalidRequest, "fields."+key, "", reason)
+		}
+	}
+	return nil
+}
+
+// fieldValueProblem describes why v cannot be a field value, or returns ""
+func fieldValueProblem(v reflect.Value, allowList bool) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Type() == timeType || v.Type().Implements(jsonMarshalerType) {
+		return ""
+	}
 
-	if result.Failed > 0 {
-		return fmt.Errorf("import partially failed: %d succeeded, %d failed", result.Results, result.Failed)
+	switch v.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return ""
+	case reflect.Slice, reflect.Array:
+		if !allowList {
+			return "nested lists are not supported"
+		}
+		for i := 0; i < v.Len(); i++ {
+			if reason := fieldValueProblem(v.Index(i), false); reason != "" {
+				return reason
+			}
+		}
+		return ""
+	case reflect.Map, reflect.Struct:
+		return fmt.Sprintf("%s values would be sent as nested objects", v.Type())
+	default:
+		return fmt.Sprintf("%s values cannot be encoded as JSON", v.Type())
 	}
+}
+
+// maxTagLength is the longest tag name accepted in a SubscriberInput
+const maxTagLength = 100
 
+// validateTagNames rejects blank tag names, names containing commas, which
+// separate tags in broadcast targeting, and names longer than maxTagLength
+func validateTagNames(field string, tags []string) error {
+	for _, tag := range tags {
+		switch {
+		case strings.TrimSpace(tag) == "":
+			return invalidField(ErrInvalidTags, field, tag, "tag name is required")
+		case strings.Contains(tag, ","):
+			return invalidField(ErrInvalidTags, field, tag, "tag name cannot contain a comma")
+		case utf8.RuneCountInString(tag) > maxTagLength:
+			return invalidField(ErrInvalidTags, field, tag, fmt.Sprintf("tag name exceeds %d characters", maxTagLength))
+		}
+	}
 	return nil
 }
+
+// dedupeSubscriberTags drops tags repeated within a subscriber's Tags or
+// RemoveTags, ignoring case and keeping the first spelling. Subscribers
+// without duplicates are passed through as they are; the others are copied
+// rather than modifying the caller's.
+func dedupeSubscriberTags(subscribers []*SubscriberInput) []*SubscriberInput {
+	var deduped []*SubscriberInput
+	for i, sub := range subscribers {
+		tags, removeTags := dedupeTags(sub.Tags), dedupeTags(sub.RemoveTags)
+		if len(tags) == len(sub.Tags) && len(removeTags) == len(sub.RemoveTags) {
+			continue
+		}
+		if deduped == nil {
+			deduped = append([]*SubscriberInput(nil), subscribers...)
+		}
+		copied := *sub
+		copied.Tags, copied.RemoveTags = tags, removeTags
+		deduped[i] = &copied
+	}
+	if deduped == nil {
+		return subscribers
+	}
+	return deduped
+}
+
+// dedupeTags returns tags without case-insensitive repeats, or tags itself
+// when there are none
+func dedupeTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	var unique []string
+	for i, tag := range tags {
+		key := strings.ToLower(tag)
+		if _, ok := seen[key]; ok {
+			if unique == nil {
+				unique = append([]string(nil), tags[:i]...)
+			}
+			continue
+		}
+		seen[key] = struct{}{}
+		if unique != nil {
+			unique = append(unique, tag)
+		}
+	}
+	if unique == nil {
+		return tags
+	}
+	return unique
+}
+
+// distinctFieldKeys returns the sorted set of custom field keys used across subscribers
+func distinctFieldKeys(subscribers []*SubscriberInput) []string {
+	seen := make(map[string]struct{})
+	var keys []string
+	for _, sub := range subscribers {
+		for key := range sub.Fields {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// distinctTags returns the sorted set of tag names used across subscribers
+func distinctTags(subscribers []*SubscriberInput) []string {
+	seen := make(map[string]struct{})
+	var tags []string
+	for _, sub := range subscribers {
+		for _, tag := range sub.Tags {
+			if _, ok := seen[tag]; ok {
+				continue
+			}
+			seen[tag] = struct{}{}
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// ensureImportTags creates missing tags with bounded concurrency, or in strict
+// mode reports them, returning the sorted names of the tags it created
+func (c *Client) ensureImportTags(ctx context.Context, tags []string, opts *ImportOptions) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	// Resolve existence sequentially so a stale cache is refreshed only once
+	var missing []string
+	for _, tag := range tags {
+		exists, err := c.tagExists(ctx, tag)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			missing = append(missing, tag)
+		}
+	}
+
+	if opts.StrictTags {
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("%w: unknown tags: %s", ErrInvalidTags, strings.Join(missing, ", "))
+		}
+		return nil, nil
+	}
+
+	concurrency := opts.TagConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultTagConcurrency
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		created  []string
+		firstErr error
+		sem      = make(chan struct{}, concurrency)
+	)
+	for _, tag := range missing {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(tag string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			ok, err := c.EnsureTag(ctx, tag)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = withCode(CodeOf(err), fmt.Errorf("ensuring tag %q: %w", tag, err))
+				}
+				return
+			}
+			if ok {
+				created = append(created, tag)
+			}
+		}(tag)
+	}
+	wg.Wait()
+
+	sort.Strings(created)
+	return created, firstErr
+}
@@ -3,9 +3,15 @@ package bento_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,6 +25,9 @@ func TestFindSubscriber(t *testing.T) {
 		response    interface{}
 		statusCode  int
 		expectError bool
+		wantErr     error
+		// unsubscribedAt is the expected UnsubscribedAt in RFC 3339
+		unsubscribedAt string
 	}{
 		{
 			name:  "successful find",
@@ -40,6 +49,23 @@ func TestFindSubscriber(t *testing.T) {
 			statusCode:  http.StatusOK,
 			expectError: false,
 		},
+		{
+			name:  "unsubscribed",
+			email: "test@example.com",
+			response: map[string]interface{}{
+				"data": map[string]interface{}{
+					"id":   "sub_123",
+					"type": "subscriber",
+					"attributes": map[string]interface{}{
+						"uuid":            "uuid_123",
+						"email":           "test@example.com",
+						"unsubscribed_at": "2024-03-15 10:30:45.123 UTC",
+					},
+				},
+			},
+			statusCode:     http.StatusOK,
+			unsubscribedAt: "2024-03-15T10:30:45.123Z",
+		},
 		{
 			name:        "invalid email",
 			email:       "invalid-email",
@@ -56,6 +82,14 @@ func TestFindSubscriber(t *testing.T) {
 			},
 			statusCode:  http.StatusOK,
 			expectError: true,
+			wantErr:     bento.ErrSubscriberNotFound,
+		},
+		{
+			name:        "subscriber not found status",
+			email:       "notfound@example.com",
+			statusCode:  http.StatusNotFound,
+			expectError: true,
+			wantErr:     bento.ErrSubscriberNotFound,
 		},
 		{
 			name:        "server error",
@@ -103,6 +137,12 @@ func TestFindSubscriber(t *testing.T) {
 				if err == nil {
 					t.Error("expected error, got nil")
 				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+				if tt.wantErr != nil && !strings.Contains(err.Error(), tt.email) {
+					t.Errorf("expected the email in the error, got %v", err)
+				}
 				return
 			}
 			if err != nil {
@@ -116,10 +156,656 @@ func TestFindSubscriber(t *testing.T) {
 			if subscriber.Attributes.Email != tt.email {
 				t.Errorf("got email %s, want %s", subscriber.Attributes.Email, tt.email)
 			}
+			switch at := subscriber.Attributes.UnsubscribedAt; {
+			case tt.unsubscribedAt == "" && at != nil:
+				t.Errorf("expected a subscribed subscriber, got UnsubscribedAt %v", at)
+			case tt.unsubscribedAt != "" && (at == nil || at.Format(time.RFC3339Nano) != tt.unsubscribedAt):
+				t.Errorf("expected UnsubscribedAt %s, got %v", tt.unsubscribedAt, at)
+			}
+		})
+	}
+}
+
+func TestFindSubscriberWithOptions(t *testing.T) {
+	subscriber := func(unsubscribedAt interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"data": map[string]interface{}{
+				"id":   "sub_123",
+				"type": "subscriber",
+				"attributes": map[string]interface{}{
+					"email":           "test@example.com",
+					"unsubscribed_at": unsubscribedAt,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		response     map[string]interface{}
+		opts         bento.FindOptions
+		wantNotFound bool
+	}{
+		{name: "unsubscribed included by default", response: subscriber("2024-03-15T10:30:45Z")},
+		{name: "unsubscribed excluded", response: subscriber("2024-03-15T10:30:45Z"), opts: bento.FindOptions{ExcludeUnsubscribed: true}, wantNotFound: true},
+		{name: "subscribed with exclusion", response: subscriber(nil), opts: bento.FindOptions{ExcludeUnsubscribed: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				return mockResponse(http.StatusOK, tt.response), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			got, err := client.FindSubscriberWithOptions(context.Background(), "test@example.com", tt.opts)
+			if tt.wantNotFound {
+				if !errors.Is(err, bento.ErrSubscriberNotFound) || !strings.Contains(err.Error(), "unsubscribed") {
+					t.Errorf("expected ErrSubscriberNotFound for an unsubscribed subscriber, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID != "sub_123" {
+				t.Errorf("expected the subscriber, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestFindSubscribers(t *testing.T) {
+	t.Run("found and missing", func(t *testing.T) {
+		var (
+			mu          sync.Mutex
+			lookups     = map[string]int{}
+			inFlight    int
+			maxInFlight int
+		)
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			email := req.URL.Query().Get("email")
+			mu.Lock()
+			lookups[email]++
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+			}()
+			time.Sleep(2 * time.Millisecond)
+
+			if strings.HasPrefix(email, "missing") {
+				return mockResponse(http.StatusNotFound, map[string]string{"error": "Not found"}), nil
+			}
+			return mockResponse(http.StatusOK, map[string]interface{}{
+				"data": map[string]interface{}{
+					"id":         "sub_" + email,
+					"type":       "subscriber",
+					"attributes": map[string]interface{}{"email": email},
+				},
+			}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		var emails []string
+		for i := 0; i < 10; i++ {
+			emails = append(emails, fmt.Sprintf("user%d@example.com", i), fmt.Sprintf("missing%d@example.com", i))
+		}
+		emails = append(emails, "user0@example.com")
+
+		found, err := client.FindSubscribers(context.Background(), emails)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(found) != 10 {
+			t.Errorf("expected 10 subscribers, got %d", len(found))
+		}
+		for i := 0; i < 10; i++ {
+			email := fmt.Sprintf("user%d@example.com", i)
+			if sub := found[email]; sub == nil || sub.Attributes.Email != email {
+				t.Errorf("expected %s to be found, got %+v", email, sub)
+			}
+			if _, ok := found[fmt.Sprintf("missing%d@example.com", i)]; ok {
+				t.Errorf("expected missing%d@example.com to be absent", i)
+			}
+		}
+		if lookups["user0@example.com"] != 1 {
+			t.Errorf("expected a duplicate email to be looked up once, got %d", lookups["user0@example.com"])
+		}
+		if maxInFlight > 4 {
+			t.Errorf("expected at most 4 lookups in flight, got %d", maxInFlight)
+		}
+	})
+
+	t.Run("hard error aborts", func(t *testing.T) {
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("email") == "user1@example.com" {
+				return mockResponse(http.StatusUnauthorized, map[string]string{"error": "Unauthorized"}), nil
+			}
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		emails := []string{"user0@example.com", "user1@example.com", "user2@example.com"}
+		found, err := client.FindSubscribers(context.Background(), emails)
+		if !errors.Is(err, bento.ErrUnauthorized) || !strings.Contains(err.Error(), "user1@example.com") {
+			t.Errorf("expected ErrUnauthorized for user1@example.com, got %v", err)
+		}
+		if found != nil {
+			t.Errorf("expected no results, got %v", found)
+		}
+	})
+
+	t.Run("invalid emails", func(t *testing.T) {
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request: %s", req.URL)
+			return mockResponse(http.StatusOK, nil), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		_, err = client.FindSubscribers(context.Background(), []string{"user@example.com", "invalid-email"})
+		var validationErr *bento.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Index != 1 || !errors.Is(err, bento.ErrInvalidEmail) {
+			t.Errorf("expected entry 1 to be rejected, got %v", err)
+		}
+		if _, err := client.FindSubscribers(context.Background(), nil); !errors.Is(err, bento.ErrInvalidRequest) {
+			t.Errorf("expected ErrInvalidRequest, got %v", err)
+		}
+	})
+}
+
+func TestFindSubscriberByUUID(t *testing.T) {
+	tests := []struct {
+		name       string
+		uuid       string
+		response   interface{}
+		statusCode int
+		wantErr    error
+	}{
+		{
+			name: "successful find",
+			uuid: "uuid_123",
+			response: map[string]interface{}{
+				"data": map[string]interface{}{
+					"id":   "sub_123",
+					"type": "subscriber",
+					"attributes": map[string]interface{}{
+						"uuid":  "uuid_123",
+						"email": "test@example.com",
+					},
+				},
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:    "empty uuid",
+			uuid:    " ",
+			wantErr: bento.ErrInvalidRequest,
+		},
+		{
+			name: "subscriber not found",
+			uuid: "uuid_missing",
+			response: map[string]interface{}{
+				"data": map[string]interface{}{"id": ""},
+			},
+			statusCode: http.StatusOK,
+			wantErr:    bento.ErrSubscriberNotFound,
+		},
+		{
+			name:       "subscriber not found status",
+			uuid:       "uuid_missing",
+			statusCode: http.StatusNotFound,
+			wantErr:    bento.ErrSubscriberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				if !strings.HasSuffix(req.URL.Path, "/fetch/subscribers") {
+					t.Errorf("unexpected path: %s", req.URL.Path)
+				}
+				query := req.URL.Query()
+				if query.Get("uuid") != tt.uuid {
+					t.Errorf("unexpected uuid in query: %s", query.Get("uuid"))
+				}
+				if query.Has("email") {
+					t.Errorf("expected no email in query, got %s", query.Get("email"))
+				}
+				return mockResponse(tt.statusCode, tt.response), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			subscriber, err := client.FindSubscriberByUUID(context.Background(), tt.uuid)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if subscriber.Attributes.UUID != tt.uuid {
+				t.Errorf("got uuid %s, want %s", subscriber.Attributes.UUID, tt.uuid)
+			}
+		})
+	}
+}
+
+func TestListSubscribers(t *testing.T) {
+	subscriber := func(n int) map[string]interface{} {
+		return map[string]interface{}{
+			"id":   fmt.Sprintf("sub_%d", n),
+			"type": "subscriber",
+			"attributes": map[string]interface{}{
+				"uuid":  fmt.Sprintf("uuid_%d", n),
+				"email": fmt.Sprintf("user%d@example.com", n),
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		// respond answers the request for a page, identified by its query
+		respond func(t *testing.T, query url.Values) interface{}
+		wantIDs []string
+		pages   int
+	}{
+		{
+			name: "page numbers",
+			respond: func(t *testing.T, query url.Values) interface{} {
+				if query.Get("per_page") != "2" {
+					t.Errorf("expected per_page 2, got %q", query.Get("per_page"))
+				}
+				switch query.Get("page") {
+				case "":
+					return map[string]interface{}{
+						"data": []interface{}{subscriber(1), subscriber(2)},
+						"meta": map[string]interface{}{"page": 1, "next_page": 2, "total": 3},
+					}
+				case "2":
+					return map[string]interface{}{
+						"data": []interface{}{subscriber(3)},
+						"meta": map[string]interface{}{"page": 2, "next_page": nil, "total": 3},
+					}
+				}
+				t.Errorf("unexpected page %q", query.Get("page"))
+				return nil
+			},
+			wantIDs: []string{"sub_1", "sub_2", "sub_3"},
+			pages:   2,
+		},
+		{
+			name: "cursors",
+			respond: func(t *testing.T, query url.Values) interface{} {
+				if query.Has("page") {
+					t.Errorf("expected no page with a cursor, got %q", query.Get("page"))
+				}
+				switch query.Get("cursor") {
+				case "":
+					return map[string]interface{}{
+						"data": []interface{}{subscriber(1), subscriber(2)},
+						"meta": map[string]interface{}{"next_cursor": "c2", "sort": map[string]string{"by": "created_at"}},
+					}
+				case "c2":
+					return map[string]interface{}{
+						"data": []interface{}{subscriber(3), subscriber(4)},
+						"meta": map[string]interface{}{"next_cursor": "c3"},
+					}
+				case "c3":
+					return map[string]interface{}{
+						"data": []interface{}{subscriber(5)},
+						"meta": map[string]interface{}{"next_cursor": ""},
+					}
+				}
+				t.Errorf("unexpected cursor %q", query.Get("cursor"))
+				return nil
+			},
+			wantIDs: []string{"sub_1", "sub_2", "sub_3", "sub_4", "sub_5"},
+			pages:   3,
+		},
+		{
+			name: "total pages",
+			respond: func(t *testing.T, query url.Values) interface{} {
+				page := query.Get("page")
+				if page == "" {
+					page = "1"
+				}
+				n, _ := strconv.Atoi(page)
+				return map[string]interface{}{
+					"data": []interface{}{subscriber(n)},
+					"meta": map[string]interface{}{"page": n, "total_pages": 2},
+				}
+			},
+			wantIDs: []string{"sub_1", "sub_2"},
+			pages:   2,
+		},
+		{
+			name: "empty site",
+			respond: func(t *testing.T, query url.Values) interface{} {
+				return map[string]interface{}{"data": []interface{}{}}
+			},
+			wantIDs: []string{},
+			pages:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				requests++
+				if req.Method != http.MethodGet || !strings.HasSuffix(req.URL.Path, "/fetch/subscribers") {
+					t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+				}
+				if req.URL.Query().Has("email") {
+					t.Errorf("expected no email in a listing, got %s", req.URL.Query().Get("email"))
+				}
+				return mockResponse(http.StatusOK, tt.respond(t, req.URL.Query())), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			ids := []string{}
+			opts := &bento.ListSubscribersOptions{PerPage: 2}
+			for {
+				page, err := client.ListSubscribers(context.Background(), opts)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				for _, sub := range page.Subscribers {
+					ids = append(ids, sub.ID)
+				}
+				if !page.HasMore() {
+					break
+				}
+				if requests > 10 {
+					t.Fatal("pagination did not stop")
+				}
+				opts = page.NextOptions(opts)
+			}
+
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("expected %v, got %v", tt.wantIDs, ids)
+			}
+			if requests != tt.pages {
+				t.Errorf("expected %d requests, got %d", tt.pages, requests)
+			}
+		})
+	}
+
+	t.Run("nil options", func(t *testing.T) {
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			if query := req.URL.Query(); query.Has("page") || query.Has("cursor") || query.Has("per_page") {
+				t.Errorf("expected the first page with API defaults, got %s", req.URL.RawQuery)
+			}
+			return mockResponse(http.StatusOK, map[string]interface{}{"data": []interface{}{subscriber(1)}}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		page, err := client.ListSubscribers(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(page.Subscribers) != 1 || page.HasMore() {
+			t.Errorf("expected a single last page, got %+v", page)
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			return mockResponse(http.StatusUnauthorized, map[string]string{"error": "Unauthorized"}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		page, err := client.ListSubscribers(context.Background(), nil)
+		if !errors.Is(err, bento.ErrUnauthorized) {
+			t.Errorf("expected ErrUnauthorized, got %v", err)
+		}
+		if status, _ := bento.HTTPStatus(err); status != http.StatusUnauthorized {
+			t.Errorf("expected status 401, got %d", status)
+		}
+		if page != nil {
+			t.Errorf("expected no page, got %+v", page)
+		}
+	})
+
+	t.Run("negative page", func(t *testing.T) {
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request: %s", req.URL)
+			return mockResponse(http.StatusOK, nil), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		if _, err := client.ListSubscribers(context.Background(), &bento.ListSubscribersOptions{Page: -1}); !errors.Is(err, bento.ErrInvalidRequest) {
+			t.Errorf("expected ErrInvalidRequest, got %v", err)
+		}
+	})
+}
+
+func TestSearchSubscribers(t *testing.T) {
+	yes, no := true, false
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 2, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name      string
+		query     bento.SubscriberQuery
+		wantQuery string
+		wantErr   error
+	}{
+		{
+			name:      "tag and subscribed",
+			query:     bento.SubscriberQuery{TagName: "onboarding-incomplete", Unsubscribed: &no},
+			wantQuery: "tag_name=onboarding-incomplete&unsubscribed=false",
+		},
+		{
+			name:      "tag id and unsubscribed",
+			query:     bento.SubscriberQuery{TagID: "tag_123", Unsubscribed: &yes},
+			wantQuery: "tag_id=tag_123&unsubscribed=true",
+		},
+		{
+			name:      "field value",
+			query:     bento.SubscriberQuery{FieldKey: "plan", FieldValue: "pro plus"},
+			wantQuery: "field_key=plan&field_value=pro+plus",
+		},
+		{
+			name:      "created range",
+			query:     bento.SubscriberQuery{CreatedAfter: after, CreatedBefore: before},
+			wantQuery: "created_after=2024-01-01T00%3A00%3A00Z&created_before=2024-02-01T11%3A30%3A00Z",
+		},
+		{
+			name: "filters with paging",
+			query: bento.SubscriberQuery{
+				TagName:                "vip",
+				ListSubscribersOptions: bento.ListSubscribersOptions{Page: 3, PerPage: 50},
+			},
+			wantQuery: "page=3&per_page=50&tag_name=vip",
+		},
+		{
+			name:    "no filters",
+			query:   bento.SubscriberQuery{ListSubscribersOptions: bento.ListSubscribersOptions{PerPage: 50}},
+			wantErr: bento.ErrInvalidRequest,
+		},
+		{
+			name:    "tag name and id",
+			query:   bento.SubscriberQuery{TagName: "vip", TagID: "tag_123"},
+			wantErr: bento.ErrInvalidRequest,
+		},
+		{
+			name:    "field value without key",
+			query:   bento.SubscriberQuery{FieldValue: "pro"},
+			wantErr: bento.ErrInvalidRequest,
+		},
+		{
+			name:    "inverted range",
+			query:   bento.SubscriberQuery{CreatedAfter: before, CreatedBefore: after},
+			wantErr: bento.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuery string
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				if !strings.HasSuffix(req.URL.Path, "/fetch/subscribers") {
+					t.Errorf("unexpected path: %s", req.URL.Path)
+				}
+				query := req.URL.Query()
+				query.Del("site_uuid")
+				gotQuery = query.Encode()
+				return mockResponse(http.StatusOK, map[string]interface{}{"data": []interface{}{}}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			page, err := client.SearchSubscribers(context.Background(), tt.query)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+				if gotQuery != "" {
+					t.Errorf("expected no request, got query %s", gotQuery)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("expected query %s, got %s", tt.wantQuery, gotQuery)
+			}
+			if page == nil || page.HasMore() {
+				t.Errorf("expected a single empty page, got %+v", page)
+			}
 		})
 	}
 }
 
+func TestCountSubscribers(t *testing.T) {
+	t.Run("total from meta", func(t *testing.T) {
+		var queries []url.Values
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			queries = append(queries, req.URL.Query())
+			return mockResponse(http.StatusOK, map[string]interface{}{
+				"data": []interface{}{map[string]interface{}{"id": "sub_1"}},
+				"meta": map[string]interface{}{"page": 1, "total_pages": 5000, "total_count": 123456},
+			}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		count, err := client.CountSubscribers(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 123456 {
+			t.Errorf("expected 123456, got %d", count)
+		}
+		if len(queries) != 1 || queries[0].Get("unsubscribed") != "false" {
+			t.Errorf("expected one request for active subscribers, got %v", queries)
+		}
+	})
+
+	t.Run("paging fallback", func(t *testing.T) {
+		var queries []url.Values
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			queries = append(queries, req.URL.Query())
+			page := len(queries)
+			data := []interface{}{map[string]interface{}{"id": "sub_1"}, map[string]interface{}{"id": "sub_2"}}
+			if page == 3 {
+				data = data[:1]
+			}
+			return mockResponse(http.StatusOK, map[string]interface{}{
+				"data": data,
+				"meta": map[string]interface{}{"page": page, "total_pages": 3},
+			}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		count, err := client.CountSubscribers(context.Background(), &bento.SubscriberQuery{TagName: "customer"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 5 {
+			t.Errorf("expected 5, got %d", count)
+		}
+		if len(queries) != 3 {
+			t.Fatalf("expected 3 pages, got %d", len(queries))
+		}
+		for i, q := range queries {
+			if q.Get("tag_name") != "customer" || q.Has("unsubscribed") {
+				t.Errorf("page %d: expected only the tag filter, got %s", i+1, q.Encode())
+			}
+		}
+	})
+
+	t.Run("query without filters", func(t *testing.T) {
+		var queries []url.Values
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			queries = append(queries, req.URL.Query())
+			return mockResponse(http.StatusOK, map[string]interface{}{
+				"data": []interface{}{map[string]interface{}{"id": "sub_1"}},
+				"meta": map[string]interface{}{"page": 1, "total_pages": 1, "total_count": 42},
+			}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		count, err := client.CountSubscribers(context.Background(), &bento.SubscriberQuery{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 42 {
+			t.Errorf("expected 42, got %d", count)
+		}
+		if len(queries) != 1 || queries[0].Get("unsubscribed") != "false" {
+			t.Errorf("expected the empty query to count active subscribers like nil, got %v", queries)
+		}
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request: %s", req.URL)
+			return mockResponse(http.StatusOK, nil), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		if _, err := client.CountSubscribers(context.Background(), &bento.SubscriberQuery{FieldValue: "pro"}); !errors.Is(err, bento.ErrInvalidRequest) {
+			t.Errorf("expected ErrInvalidRequest, got %v", err)
+		}
+	})
+}
+
 func TestCreateSubscriber(t *testing.T) {
 	validInput := &bento.SubscriberInput{
 		Email:     "test@example.com",
@@ -232,35 +918,322 @@ func TestCreateSubscriber(t *testing.T) {
 	}
 }
 
-func TestImportSubscribers(t *testing.T) {
-	validSubscribers := []*bento.SubscriberInput{
-		{
-			Email:     "test1@example.com",
-			FirstName: "John",
-			LastName:  "Doe",
-			Tags:      []string{"tag1"},
-			Fields: map[string]interface{}{
-				"company": "Test Co",
+func TestUpdateSubscriber(t *testing.T) {
+	subscriber := func(fields map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"data": map[string]interface{}{
+				"id":   "sub_123",
+				"type": "subscriber",
+				"attributes": map[string]interface{}{
+					"uuid":           "uuid_123",
+					"email":          "test@example.com",
+					"fields":         fields,
+					"cached_tag_ids": []string{"tag_vip"},
+				},
 			},
-		},
-		{
-			Email:     "test2@example.com",
-			FirstName: "Jane",
-			LastName:  "Doe",
-			Tags:      []string{"tag2"},
-		},
+		}
 	}
 
-	tests := []struct {
-		name        string
-		subscribers []*bento.SubscriberInput
-		response    interface{}
-		statusCode  int
-		expectError bool
-	}{
-		{
-			name:        "successful import",
-			subscribers: validSubscribers,
+	t.Run("updates fields and tags", func(t *testing.T) {
+		var calls []string
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			calls = append(calls, req.Method+" "+strings.TrimPrefix(req.URL.Path, "/api/v1"))
+			if got := req.URL.Query().Get("email"); got != "test@example.com" {
+				t.Errorf("unexpected email in query: %s", got)
+			}
+			var body map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			want := map[string]interface{}{
+				"subscriber": map[string]interface{}{
+					"email":       "test@example.com",
+					"first_name":  "Jesse",
+					"tags":        []interface{}{"vip"},
+					"remove_tags": []interface{}{"trial"},
+					"fields":      map[string]interface{}{"plan": "pro"},
+				},
+			}
+			if !reflect.DeepEqual(body, want) {
+				t.Errorf("unexpected request body:\n got %v\nwant %v", body, want)
+			}
+			return mockResponse(http.StatusOK, subscriber(map[string]interface{}{"first_name": "Jesse", "plan": "pro"})), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		updated, err := client.UpdateSubscriber(context.Background(), "test@example.com", &bento.SubscriberInput{
+			FirstName:  "Jesse",
+			Tags:       []string{"vip"},
+			RemoveTags: []string{"trial"},
+			Fields:     map[string]interface{}{"plan": "pro"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// The update route never creates a subscriber, so there is no lookup
+		if want := []string{"PATCH /fetch/subscribers"}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("expected calls %v, got %v", want, calls)
+		}
+		if updated.ID != "sub_123" || updated.Attributes.Fields["first_name"] != "Jesse" || updated.Attributes.Fields["plan"] != "pro" {
+			t.Errorf("expected the updated subscriber, got %+v", updated)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		var requests int
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return mockResponse(http.StatusNotFound, map[string]string{"error": "Not found"}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		_, err = client.UpdateSubscriber(context.Background(), "missing@example.com", &bento.SubscriberInput{FirstName: "Jesse"})
+		if !errors.Is(err, bento.ErrSubscriberNotFound) {
+			t.Errorf("expected ErrSubscriberNotFound, got %v", err)
+		}
+		if requests != 1 {
+			t.Errorf("expected only the update, got %d requests", requests)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+			return mockResponse(http.StatusOK, nil), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		tests := []struct {
+			name    string
+			email   string
+			input   *bento.SubscriberInput
+			wantErr error
+		}{
+			{name: "invalid email", email: "invalid-email", input: &bento.SubscriberInput{}, wantErr: bento.ErrInvalidEmail},
+			{name: "nil input", email: "test@example.com", wantErr: bento.ErrInvalidRequest},
+			{name: "different email", email: "test@example.com", input: &bento.SubscriberInput{Email: "new@example.com"}, wantErr: bento.ErrInvalidRequest},
+		}
+		for _, tt := range tests {
+			_, err := client.UpdateSubscriber(context.Background(), tt.email, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.wantErr, err)
+			}
+		}
+	})
+}
+
+func TestUpsertSubscriber(t *testing.T) {
+	subscriber := map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":   "sub_123",
+			"type": "subscriber",
+			"attributes": map[string]interface{}{
+				"email":  "test@example.com",
+				"fields": map[string]interface{}{"plan": "pro"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		// findStatus and createStatus answer the lookup and the create
+		findStatus   int
+		createStatus int
+		wantCalls    []string
+	}{
+		{
+			name:       "existing subscriber is updated",
+			findStatus: http.StatusOK,
+			wantCalls:  []string{"GET /fetch/subscribers", "PATCH /fetch/subscribers"},
+		},
+		{
+			name:         "missing subscriber is created",
+			findStatus:   http.StatusNotFound,
+			createStatus: http.StatusCreated,
+			wantCalls:    []string{"GET /fetch/subscribers", "POST /fetch/subscribers"},
+		},
+		{
+			name:         "subscriber created concurrently is updated",
+			findStatus:   http.StatusNotFound,
+			createStatus: http.StatusConflict,
+			wantCalls:    []string{"GET /fetch/subscribers", "POST /fetch/subscribers", "PATCH /fetch/subscribers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			var sent map[string]bento.SubscriberInput
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, req.Method+" "+strings.TrimPrefix(req.URL.Path, "/api/v1"))
+				switch req.Method {
+				case http.MethodGet:
+					if tt.findStatus != http.StatusOK {
+						return mockResponse(tt.findStatus, map[string]string{"error": "Not found"}), nil
+					}
+					return mockResponse(http.StatusOK, subscriber), nil
+				case http.MethodPost:
+					if tt.createStatus != http.StatusCreated {
+						return mockResponse(tt.createStatus, map[string]string{"error": "Email has already been taken"}), nil
+					}
+					return mockResponse(tt.createStatus, subscriber), nil
+				default:
+					if err := json.NewDecoder(req.Body).Decode(&sent); err != nil {
+						return nil, err
+					}
+					return mockResponse(http.StatusOK, subscriber), nil
+				}
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			input := &bento.SubscriberInput{
+				Email:      "test@example.com",
+				Tags:       []string{"vip"},
+				RemoveTags: []string{"trial"},
+				Fields:     map[string]interface{}{"plan": "pro"},
+			}
+			got, err := client.UpsertSubscriber(context.Background(), input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID != "sub_123" || got.Attributes.Fields["plan"] != "pro" {
+				t.Errorf("expected the final subscriber, got %+v", got)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("expected calls %v, got %v", tt.wantCalls, calls)
+			}
+			if sent != nil && !reflect.DeepEqual(sent["subscriber"], *input) {
+				t.Errorf("expected %+v to be sent as the update, got %+v", *input, sent)
+			}
+		})
+	}
+}
+
+func TestUpsertSubscriberErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   *bento.SubscriberInput
+		status  int
+		wantErr error
+	}{
+		{name: "nil input", wantErr: bento.ErrInvalidRequest},
+		{name: "invalid email", input: &bento.SubscriberInput{Email: "invalid-email"}, wantErr: bento.ErrInvalidEmail},
+		{name: "invalid tags", input: &bento.SubscriberInput{Email: "test@example.com", Tags: []string{""}}, wantErr: bento.ErrInvalidTags},
+		{name: "lookup fails", input: &bento.SubscriberInput{Email: "test@example.com"}, status: http.StatusUnauthorized, wantErr: bento.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				requests++
+				return mockResponse(tt.status, map[string]string{"error": "Unauthorized"}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			if _, err := client.UpsertSubscriber(context.Background(), tt.input); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.status == 0 && requests != 0 {
+				t.Errorf("expected no requests, got %d", requests)
+			}
+			if tt.status != 0 && requests != 1 {
+				t.Errorf("expected only the lookup, got %d requests", requests)
+			}
+		})
+	}
+}
+
+func TestRemoveSubscriber(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		statusCode int
+		wantErr    error
+		noRequest  bool
+	}{
+		{name: "removed", email: "test@example.com", statusCode: http.StatusOK},
+		{name: "removed without content", email: "test@example.com", statusCode: http.StatusNoContent},
+		{name: "not found", email: "missing@example.com", statusCode: http.StatusNotFound, wantErr: bento.ErrSubscriberNotFound},
+		{name: "unauthorized", email: "test@example.com", statusCode: http.StatusUnauthorized, wantErr: bento.ErrUnauthorized},
+		{name: "empty email", email: "", wantErr: bento.ErrInvalidEmail, noRequest: true},
+		{name: "wildcard", email: "*@example.com", wantErr: bento.ErrInvalidEmail, noRequest: true},
+		{name: "invalid email", email: "test", wantErr: bento.ErrInvalidEmail, noRequest: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				requests++
+				if req.Method != http.MethodDelete || !strings.HasSuffix(req.URL.Path, "/fetch/subscribers") {
+					t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+				}
+				if got := req.URL.Query().Get("email"); got != tt.email {
+					t.Errorf("unexpected email in query: %s", got)
+				}
+				if tt.statusCode == http.StatusNoContent {
+					return &http.Response{StatusCode: tt.statusCode, Header: make(http.Header), Body: http.NoBody}, nil
+				}
+				return mockResponse(tt.statusCode, map[string]interface{}{}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			err = client.RemoveSubscriber(context.Background(), tt.email)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.noRequest && requests != 0 {
+				t.Errorf("expected no request, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestImportSubscribers(t *testing.T) {
+	validSubscribers := []*bento.SubscriberInput{
+		{
+			Email:     "test1@example.com",
+			FirstName: "John",
+			LastName:  "Doe",
+			Tags:      []string{"tag1"},
+			Fields: map[string]interface{}{
+				"company": "Test Co",
+			},
+		},
+		{
+			Email:     "test2@example.com",
+			FirstName: "Jane",
+			LastName:  "Doe",
+			Tags:      []string{"tag2"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		subscribers []*bento.SubscriberInput
+		response    interface{}
+		statusCode  int
+		expectError bool
+	}{
+		{
+			name:        "successful import",
+			subscribers: validSubscribers,
 			response: map[string]interface{}{
 				"results": 2,
 				"failed":  0,
@@ -360,6 +1333,661 @@ func TestImportSubscribers(t *testing.T) {
 	}
 }
 
+func TestImportSubscribersTagValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input bento.SubscriberInput
+		field string
+	}{
+		{name: "empty tag", input: bento.SubscriberInput{Tags: []string{"customer", ""}}, field: "tags"},
+		{name: "blank tag", input: bento.SubscriberInput{Tags: []string{"  "}}, field: "tags"},
+		{name: "comma", input: bento.SubscriberInput{Tags: []string{"customer,vip"}}, field: "tags"},
+		{name: "too long", input: bento.SubscriberInput{Tags: []string{strings.Repeat("a", 101)}}, field: "tags"},
+		{name: "empty remove tag", input: bento.SubscriberInput{RemoveTags: []string{""}}, field: "remove_tags"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				requests++
+				return mockResponse(http.StatusOK, map[string]interface{}{"results": 2, "failed": 0}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			invalid := tt.input
+			invalid.Email = "invalid@example.com"
+			err = client.ImportSubscribers(context.Background(), []*bento.SubscriberInput{{Email: "valid@example.com"}, &invalid})
+			if !errors.Is(err, bento.ErrInvalidTags) {
+				t.Fatalf("expected ErrInvalidTags, got %v", err)
+			}
+			var validationErr *bento.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Index != 1 || validationErr.Field != tt.field {
+				t.Errorf("expected %s of entry 1 to be rejected, got %v", tt.field, err)
+			}
+
+			_, err = client.CreateSubscriber(context.Background(), &invalid)
+			if !errors.Is(err, bento.ErrInvalidTags) {
+				t.Errorf("expected CreateSubscriber to fail with ErrInvalidTags, got %v", err)
+			}
+			if requests != 0 {
+				t.Errorf("expected no requests, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestImportSubscribersFieldValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+		field  string
+	}{
+		{name: "channel", fields: map[string]interface{}{"plan": "pro", "updates": make(chan int)}, field: "fields.updates"},
+		{name: "func", fields: map[string]interface{}{"callback": func() {}}, field: "fields.callback"},
+		{name: "nested map", fields: map[string]interface{}{"address": map[string]interface{}{"city": "Berlin"}}, field: "fields.address"},
+		{name: "struct", fields: map[string]interface{}{"owner": struct{ Name string }{"Jesse"}}, field: "fields.owner"},
+		{name: "list of maps", fields: map[string]interface{}{"orders": []map[string]int{{"id": 1}}}, field: "fields.orders"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				t.Errorf("unexpected request: %s", req.URL)
+				return mockResponse(http.StatusOK, nil), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			subs := []*bento.SubscriberInput{
+				{Email: "valid@example.com"},
+				{Email: "invalid@example.com", Fields: tt.fields},
+			}
+			err = client.ImportSubscribers(context.Background(), subs)
+			var validationErr *bento.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Index != 1 || validationErr.Field != tt.field {
+				t.Fatalf("expected %s of entry 1 to be rejected, got %v", tt.field, err)
+			}
+			if !errors.Is(err, bento.ErrInvalidRequest) {
+				t.Errorf("expected ErrInvalidRequest, got %v", err)
+			}
+
+			if _, err := client.CreateSubscriber(context.Background(), subs[1]); !errors.Is(err, bento.ErrInvalidRequest) {
+				t.Errorf("expected CreateSubscriber to reject the fields, got %v", err)
+			}
+		})
+	}
+}
+
+func TestImportSubscribersFieldValues(t *testing.T) {
+	var sent []map[string]interface{}
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Subscribers []map[string]interface{} `json:"subscribers"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		sent = body.Subscribers
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": len(sent), "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	signedUp := time.Date(2024, 3, 15, 10, 30, 45, 123456789, time.FixedZone("CET", 3600))
+	fields := map[string]interface{}{
+		"plan":      "pro",
+		"seats":     5,
+		"active":    true,
+		"score":     9.5,
+		"cancelled": nil,
+		"skills":    []string{"go", "sql"},
+		"signed_up": signedUp,
+	}
+	sub := &bento.SubscriberInput{Email: "test@example.com", Fields: fields}
+	if err := client.ImportSubscribers(context.Background(), []*bento.SubscriberInput{sub}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"plan":      "pro",
+		"seats":     float64(5),
+		"active":    true,
+		"score":     9.5,
+		"cancelled": nil,
+		"skills":    []interface{}{"go", "sql"},
+		"signed_up": "2024-03-15T09:30:45Z",
+	}
+	if len(sent) != 1 || !reflect.DeepEqual(sent[0]["fields"], want) {
+		t.Errorf("expected fields %v, got %v", want, sent)
+	}
+	if _, ok := fields["signed_up"].(time.Time); !ok {
+		t.Errorf("expected the caller's fields to be left alone, got %v", fields["signed_up"])
+	}
+}
+
+func TestImportSubscribersTagDedup(t *testing.T) {
+	var sent []bento.SubscriberInput
+	client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Subscribers []bento.SubscriberInput `json:"subscribers"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		sent = body.Subscribers
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": len(sent), "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	duplicated := &bento.SubscriberInput{
+		Email:      "dup@example.com",
+		Tags:       []string{"Customer", "vip", "customer", "VIP", "beta"},
+		RemoveTags: []string{"trial", "Trial"},
+	}
+	clean := &bento.SubscriberInput{Email: "clean@example.com", Tags: []string{"Customer", "vip"}}
+	if err := client.ImportSubscribers(context.Background(), []*bento.SubscriberInput{duplicated, clean}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 subscribers, got %d", len(sent))
+	}
+	if want := []string{"Customer", "vip", "beta"}; !reflect.DeepEqual(sent[0].Tags, want) {
+		t.Errorf("expected tags %v, got %v", want, sent[0].Tags)
+	}
+	if want := []string{"trial"}; !reflect.DeepEqual(sent[0].RemoveTags, want) {
+		t.Errorf("expected remove tags %v, got %v", want, sent[0].RemoveTags)
+	}
+	if !reflect.DeepEqual(sent[1].Tags, clean.Tags) {
+		t.Errorf("expected a valid list to pass through untouched, got %v", sent[1].Tags)
+	}
+	if len(duplicated.Tags) != 5 {
+		t.Errorf("expected the caller's input to be left alone, got %v", duplicated.Tags)
+	}
+}
+
+func TestImportSubscribersResult(t *testing.T) {
+	subscribers := []*bento.SubscriberInput{
+		{Email: "test1@example.com"},
+		{Email: "test2@example.com"},
+		{Email: "test3@example.com"},
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		wantQueued  int
+		wantFailed  int
+		wantPartial bool
+		wantCode    bento.ErrorCode
+	}{
+		{
+			name:       "full success",
+			body:       `{"results":3,"failed":0}`,
+			wantQueued: 3,
+		},
+		{
+			name:        "partial failure",
+			body:        `{"results":2,"failed":1}`,
+			wantQueued:  2,
+			wantFailed:  1,
+			wantPartial: true,
+			wantCode:    bento.CodePartialFailure,
+		},
+		{
+			name:     "malformed body",
+			body:     `{"results":"three"`,
+			wantCode: bento.CodeDecode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     make(http.Header),
+					Body:       io.NopCloser(strings.NewReader(tt.body)),
+				}, nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			result, err := client.ImportSubscribersWithOptions(context.Background(), subscribers, nil)
+			if result == nil {
+				t.Fatalf("expected a result alongside error %v", err)
+			}
+			if result.Queued != tt.wantQueued || result.Failed != tt.wantFailed {
+				t.Errorf("expected %d queued and %d failed, got %+v", tt.wantQueued, tt.wantFailed, result)
+			}
+			if bento.CodeOf(err) != tt.wantCode {
+				t.Errorf("expected code %q, got %v", tt.wantCode, err)
+			}
+
+			var partial *bento.PartialFailureError
+			if errors.As(err, &partial) != tt.wantPartial {
+				t.Fatalf("expected PartialFailureError %v, got %v", tt.wantPartial, err)
+			}
+			if tt.wantPartial && (partial.Succeeded != tt.wantQueued || partial.Failed != tt.wantFailed) {
+				t.Errorf("expected the error to carry the counts, got %+v", partial)
+			}
+
+			// ImportSubscribers reports the same outcome without the counts
+			if err := client.ImportSubscribers(context.Background(), subscribers); bento.CodeOf(err) != tt.wantCode {
+				t.Errorf("expected ImportSubscribers to fail with %q, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestImportSubscribersChunked(t *testing.T) {
+	subscribers := func(n int) []*bento.SubscriberInput {
+		subs := make([]*bento.SubscriberInput, n)
+		for i := range subs {
+			subs[i] = &bento.SubscriberInput{Email: fmt.Sprintf("user%d@example.com", i)}
+		}
+		return subs
+	}
+
+	// importHandler records the emails of every chunk and answers each chunk,
+	// by its position, with the given status and failed count
+	type chunkReply struct {
+		status int
+		failed int
+	}
+	importHandler := func(t *testing.T, chunks *[][]string, replies map[int]chunkReply, onChunk func(int)) func(*http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Subscribers []bento.SubscriberInput `json:"subscribers"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			var emails []string
+			for _, sub := range body.Subscribers {
+				emails = append(emails, sub.Email)
+			}
+			n := len(*chunks)
+			*chunks = append(*chunks, emails)
+			if onChunk != nil {
+				onChunk(n)
+			}
+
+			reply, ok := replies[n]
+			if !ok {
+				reply = chunkReply{status: http.StatusOK}
+			}
+			if reply.status != http.StatusOK {
+				return mockResponse(reply.status, map[string]string{"error": "boom"}), nil
+			}
+			return mockResponse(http.StatusOK, map[string]interface{}{
+				"results": len(emails) - reply.failed,
+				"failed":  reply.failed,
+			}), nil
+		}
+	}
+
+	t.Run("chunk boundaries", func(t *testing.T) {
+		var chunks [][]string
+		client, err := setupTestClient(importHandler(t, &chunks, nil, nil))
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		result, err := client.ImportSubscribersWithOptions(context.Background(), subscribers(7), &bento.ImportOptions{ChunkSize: 3})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := [][]string{
+			{"user0@example.com", "user1@example.com", "user2@example.com"},
+			{"user3@example.com", "user4@example.com", "user5@example.com"},
+			{"user6@example.com"},
+		}
+		if !reflect.DeepEqual(chunks, want) {
+			t.Errorf("expected chunks %v, got %v", want, chunks)
+		}
+		if result.Queued != 7 || result.Failed != 0 || result.Submitted != 7 {
+			t.Errorf("expected 7 queued and submitted, got %+v", result)
+		}
+	})
+
+	t.Run("default chunk size", func(t *testing.T) {
+		var chunks [][]string
+		client, err := setupTestClient(importHandler(t, &chunks, nil, nil))
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		if err := client.ImportSubscribers(context.Background(), subscribers(1001)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(chunks) != 3 || len(chunks[0]) != 500 || len(chunks[1]) != 500 || len(chunks[2]) != 1 {
+			sizes := make([]int, len(chunks))
+			for i, chunk := range chunks {
+				sizes[i] = len(chunk)
+			}
+			t.Errorf("expected chunks of 500, 500 and 1, got %v", sizes)
+		}
+	})
+
+	t.Run("failures are aggregated", func(t *testing.T) {
+		var chunks [][]string
+		replies := map[int]chunkReply{
+			1: {status: http.StatusOK, failed: 2},
+			2: {status: http.StatusInternalServerError},
+		}
+		client, err := setupTestClient(importHandler(t, &chunks, replies, nil))
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		result, err := client.ImportSubscribersWithOptions(context.Background(), subscribers(11), &bento.ImportOptions{ChunkSize: 3})
+		if len(chunks) != 4 {
+			t.Errorf("expected every chunk to be sent, got %d", len(chunks))
+		}
+		// Chunks 0 and 3 fully queued, chunk 1 with 2 rejected, chunk 2 failed
+		if result.Queued != 3+1+2 || result.Failed != 2 || result.Submitted != 11 {
+			t.Errorf("expected 6 queued, 2 failed and 11 submitted, got %+v", result)
+		}
+		if len(result.Failures) != 1 || result.Failures[0].Start != 6 || result.Failures[0].Size != 3 {
+			t.Fatalf("expected chunk 6-8 to fail, got %v", result.Failures)
+		}
+
+		var partial *bento.PartialFailureError
+		if !errors.As(err, &partial) || partial.Succeeded != 6 || partial.Failed != 2 {
+			t.Errorf("expected a partial failure of 6 and 2, got %v", err)
+		}
+		var chunkErr *bento.ImportChunkError
+		if !errors.As(err, &chunkErr) || !errors.Is(err, bento.ErrAPIResponse) {
+			t.Errorf("expected the failed chunk in the error, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "import chunk 6-8") {
+			t.Errorf("expected the chunk range in the message, got %v", err)
+		}
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		var chunks [][]string
+		replies := map[int]chunkReply{1: {status: http.StatusOK, failed: 1}}
+		client, err := setupTestClient(importHandler(t, &chunks, replies, nil))
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		result, err := client.ImportSubscribersWithOptions(context.Background(), subscribers(9),
+			&bento.ImportOptions{ChunkSize: 3, StopOnError: true})
+		if len(chunks) != 2 {
+			t.Errorf("expected to stop after the second chunk, sent %d", len(chunks))
+		}
+		if result.Submitted != 6 || result.Queued != 5 || result.Failed != 1 {
+			t.Errorf("expected 6 submitted, 5 queued and 1 failed, got %+v", result)
+		}
+		if !errors.Is(err, bento.ErrPartialFailure) {
+			t.Errorf("expected ErrPartialFailure, got %v", err)
+		}
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var chunks [][]string
+		client, err := setupTestClient(importHandler(t, &chunks, nil, func(n int) {
+			if n == 1 {
+				cancel()
+			}
+		}))
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		result, err := client.ImportSubscribersWithOptions(ctx, subscribers(12), &bento.ImportOptions{ChunkSize: 3})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if len(chunks) != 2 {
+			t.Errorf("expected no chunks after cancellation, sent %d", len(chunks))
+		}
+		if result.Submitted != 6 || result.Queued != 6 {
+			t.Errorf("expected 6 submitted and queued, got %+v", result)
+		}
+	})
+}
+
+func TestImportSubscribersConcurrent(t *testing.T) {
+	subs := make([]*bento.SubscriberInput, 100)
+	for i := range subs {
+		subs[i] = &bento.SubscriberInput{Email: fmt.Sprintf("user%d@example.com", i)}
+	}
+
+	// concurrencyHandler tracks how many requests are in flight and rejects
+	// one subscriber of every chunk starting at a multiple of 30
+	type concurrencyHandler struct {
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+	}
+	handle := func(h *concurrencyHandler) func(*http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Subscribers []bento.SubscriberInput `json:"subscribers"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			h.mu.Lock()
+			h.inFlight++
+			h.maxInFlight = max(h.maxInFlight, h.inFlight)
+			h.mu.Unlock()
+			defer func() {
+				h.mu.Lock()
+				h.inFlight--
+				h.mu.Unlock()
+			}()
+
+			select {
+			case <-time.After(5 * time.Millisecond):
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			}
+
+			var start int
+			_, _ = fmt.Sscanf(body.Subscribers[0].Email, "user%d@", &start)
+			failed := 0
+			if start%30 == 0 {
+				failed = 1
+			}
+			return mockResponse(http.StatusOK, map[string]interface{}{
+				"results": len(body.Subscribers) - failed,
+				"failed":  failed,
+			}), nil
+		}
+	}
+
+	t.Run("bounded and exact", func(t *testing.T) {
+		h := &concurrencyHandler{}
+		client, err := setupTestClient(handle(h))
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		var calls []int
+		progress := func(done, total int, lastErr error) {
+			if total != 100 || lastErr != nil {
+				t.Errorf("unexpected progress: %d/%d %v", done, total, lastErr)
+			}
+			calls = append(calls, done)
+		}
+		result, err := client.ImportSubscribersWithOptions(context.Background(), subs, &bento.ImportOptions{
+			ChunkSize:   10,
+			Concurrency: 3,
+			Progress:    progress,
+		})
+
+		// Chunks starting at 0, 30, 60 and 90 each reject one subscriber
+		var partial *bento.PartialFailureError
+		if !errors.As(err, &partial) || partial.Succeeded != 96 || partial.Failed != 4 {
+			t.Errorf("expected 96 queued and 4 failed, got %v", err)
+		}
+		if result.Queued != 96 || result.Failed != 4 || result.Submitted != 100 {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if h.maxInFlight > 3 {
+			t.Errorf("expected at most 3 requests in flight, got %d", h.maxInFlight)
+		}
+		if h.maxInFlight < 2 {
+			t.Errorf("expected chunks to be sent concurrently, got %d in flight", h.maxInFlight)
+		}
+		want := []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+		if !reflect.DeepEqual(calls, want) {
+			t.Errorf("expected progress %v, got %v", want, calls)
+		}
+	})
+
+	t.Run("cancellation drains in-flight chunks", func(t *testing.T) {
+		// Requests only end when the context is canceled
+		var mu sync.Mutex
+		var requests int
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			requests++
+			mu.Unlock()
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		began := time.Now()
+		result, err := client.ImportSubscribersWithOptions(ctx, subs, &bento.ImportOptions{ChunkSize: 10, Concurrency: 4})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the context error, got %v", err)
+		}
+		if elapsed := time.Since(began); elapsed > time.Second {
+			t.Errorf("expected a prompt return, took %v", elapsed)
+		}
+		if result.Submitted != 40 || len(result.Failures) != 4 || requests != 4 {
+			t.Errorf("expected the 4 in-flight chunks to fail and no more to be sent, got %+v after %d requests", result, requests)
+		}
+		for i, failure := range result.Failures {
+			if failure.Start != i*10 {
+				t.Errorf("expected failures ordered by Start, got %d at %d", failure.Start, i)
+			}
+		}
+	})
+}
+
+func TestImportSubscribersDryRun(t *testing.T) {
+	subscribers := func(n int) []*bento.SubscriberInput {
+		subs := make([]*bento.SubscriberInput, n)
+		for i := range subs {
+			subs[i] = &bento.SubscriberInput{Email: fmt.Sprintf("user%d@example.com", i), Tags: []string{"imported"}}
+		}
+		return subs
+	}
+
+	tests := []struct {
+		name       string
+		count      int
+		chunkSize  int
+		wantChunks int
+	}{
+		{name: "single chunk", count: 3, wantChunks: 1},
+		{name: "default chunk size", count: 1001, wantChunks: 3},
+		{name: "exact multiple", count: 9, chunkSize: 3, wantChunks: 3},
+		{name: "partial last chunk", count: 10, chunkSize: 3, wantChunks: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				requests++
+				return mockResponse(http.StatusOK, map[string]interface{}{"results": 0, "failed": 0}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			result, err := client.ImportSubscribersWithOptions(context.Background(), subscribers(tt.count), &bento.ImportOptions{
+				ChunkSize:  tt.chunkSize,
+				DryRun:     true,
+				EnsureTags: true,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Chunks != tt.wantChunks {
+				t.Errorf("expected %d chunks, got %d", tt.wantChunks, result.Chunks)
+			}
+			if result.Submitted != 0 || result.Queued != 0 {
+				t.Errorf("expected nothing submitted, got %+v", result)
+			}
+			if requests != 0 {
+				t.Errorf("expected no requests, got %d", requests)
+			}
+
+			// A real import sends as many chunks as the dry run reported
+			sent, err := client.ImportSubscribersWithOptions(context.Background(), subscribers(tt.count), &bento.ImportOptions{ChunkSize: tt.chunkSize})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sent.Chunks != tt.wantChunks || requests != tt.wantChunks {
+				t.Errorf("expected %d requests, got %d (result %d)", tt.wantChunks, requests, sent.Chunks)
+			}
+		})
+	}
+
+	t.Run("validation errors", func(t *testing.T) {
+		client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request: %s", req.URL)
+			return mockResponse(http.StatusOK, nil), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		subs := []*bento.SubscriberInput{
+			{Email: "valid@example.com"},
+			{Email: "invalid-email"},
+			{Email: "tags@example.com", Tags: []string{"a,b"}},
+			{Email: "fields@example.com", Fields: map[string]interface{}{"callback": func() {}}},
+		}
+		result, err := client.ImportSubscribersWithOptions(context.Background(), subs, &bento.ImportOptions{DryRun: true})
+		if result != nil {
+			t.Errorf("expected no result, got %+v", result)
+		}
+
+		joined, ok := err.(interface{ Unwrap() []error })
+		if !ok {
+			t.Fatalf("expected joined validation errors, got %v", err)
+		}
+		var indexes []int
+		for _, e := range joined.Unwrap() {
+			var validationErr *bento.ValidationError
+			if errors.As(e, &validationErr) {
+				indexes = append(indexes, validationErr.Index)
+			}
+		}
+		if want := []int{1, 2, 3}; !reflect.DeepEqual(indexes, want) {
+			t.Errorf("expected errors for entries %v, got %v", want, err)
+		}
+	})
+}
+
 func TestSubscriberWithContext(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -435,3 +2063,261 @@ func TestSubscriberWithContext(t *testing.T) {
 		})
 	}
 }
+
+// fieldCatalogHandler mocks the fields endpoints and the subscriber batch endpoint
+type fieldCatalogHandler struct {
+	existing     []string
+	failKey      string
+	fieldFetches int
+	createdKeys  []string
+	importCalls  int
+}
+
+func (h *fieldCatalogHandler) handle(req *http.Request) (*http.Response, error) {
+	switch {
+	case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/fetch/fields"):
+		h.fieldFetches++
+		var data []bento.FieldData
+		for _, key := range h.existing {
+			data = append(data, bento.FieldData{Attributes: bento.FieldAttributes{Key: key}})
+		}
+		return mockResponse(http.StatusOK, bento.FieldsResponse{Data: data}), nil
+	case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/fetch/fields"):
+		var body struct {
+			Field struct {
+				Key string `json:"key"`
+			} `json:"field"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		if body.Field.Key == h.failKey {
+			return mockResponse(http.StatusBadRequest, nil), nil
+		}
+		h.createdKeys = append(h.createdKeys, body.Field.Key)
+		return mockResponse(http.StatusCreated, map[string]interface{}{
+			"data": bento.FieldData{Attributes: bento.FieldAttributes{Key: body.Field.Key}},
+		}), nil
+	case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/batch/subscribers"):
+		h.importCalls++
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 2, "failed": 0}), nil
+	}
+	return mockResponse(http.StatusNotFound, nil), nil
+}
+
+func TestImportSubscribersEnsureFields(t *testing.T) {
+	subscribers := []*bento.SubscriberInput{
+		{Email: "test1@example.com", Fields: map[string]interface{}{"company": "Acme", "plan": "pro"}},
+		{Email: "test2@example.com", Fields: map[string]interface{}{"trial_ends_at": "2024-06-01", "plan": "free"}},
+	}
+
+	tests := []struct {
+		name            string
+		existing        []string
+		failKey         string
+		wantCreated     []string
+		wantImportCalls int
+		expectError     bool
+	}{
+		{
+			name:            "two new keys",
+			existing:        []string{"company"},
+			wantCreated:     []string{"plan", "trial_ends_at"},
+			wantImportCalls: 1,
+		},
+		{
+			name:            "all keys exist",
+			existing:        []string{"company", "plan", "trial_ends_at"},
+			wantImportCalls: 1,
+		},
+		{
+			name:            "field creation failure",
+			existing:        []string{"company"},
+			failKey:         "trial_ends_at",
+			wantCreated:     []string{"plan"},
+			wantImportCalls: 0,
+			expectError:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &fieldCatalogHandler{existing: tt.existing, failKey: tt.failKey}
+			client, err := setupTestClient(handler.handle)
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			result, err := client.ImportSubscribersWithOptions(context.Background(), subscribers, &bento.ImportOptions{
+				EnsureFields: true,
+			})
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				if !strings.Contains(err.Error(), tt.failKey) {
+					t.Errorf("expected error to name the failing key, got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if handler.importCalls != tt.wantImportCalls {
+				t.Errorf("expected %d import calls, got %d", tt.wantImportCalls, handler.importCalls)
+			}
+			if !reflect.DeepEqual(result.CreatedFields, tt.wantCreated) {
+				t.Errorf("expected created fields %v, got %v", tt.wantCreated, result.CreatedFields)
+			}
+			if handler.fieldFetches != 1 {
+				t.Errorf("expected fields to be fetched once, got %d", handler.fieldFetches)
+			}
+		})
+	}
+}
+
+func TestImportSubscribersWithoutEnsureFields(t *testing.T) {
+	handler := &fieldCatalogHandler{}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	result, err := client.ImportSubscribersWithOptions(context.Background(), []*bento.SubscriberInput{
+		{Email: "test1@example.com", Fields: map[string]interface{}{"plan": "pro"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler.fieldFetches != 0 || len(handler.createdKeys) != 0 {
+		t.Errorf("expected no field requests, got %d fetches and %v created", handler.fieldFetches, handler.createdKeys)
+	}
+	if result.Queued != 2 {
+		t.Errorf("expected 2 queued, got %d", result.Queued)
+	}
+}
+
+// tagCatalogHandler mocks the tags endpoints and the subscriber batch endpoint
+type tagCatalogHandler struct {
+	mu          sync.Mutex
+	existing    []string
+	tagFetches  int
+	createdTags []string
+	importCalls int
+	inFlight    int
+	maxInFlight int
+}
+
+func (h *tagCatalogHandler) handle(req *http.Request) (*http.Response, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch {
+	case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/fetch/tags"):
+		h.tagFetches++
+		data := make([]bento.TagData, len(h.existing))
+		for i, name := range h.existing {
+			data[i].Attributes.Name = name
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"data": data}), nil
+	case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/fetch/tags"):
+		h.inFlight++
+		if h.inFlight > h.maxInFlight {
+			h.maxInFlight = h.inFlight
+		}
+		var body struct {
+			Tag struct {
+				Name string `json:"name"`
+			} `json:"tag"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+
+		// Let other creations overlap before completing
+		h.mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		h.mu.Lock()
+
+		h.inFlight--
+		h.createdTags = append(h.createdTags, body.Tag.Name)
+		return mockResponse(http.StatusCreated, map[string]interface{}{"data": bento.TagData{}}), nil
+	case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/batch/subscribers"):
+		h.importCalls++
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	}
+	return mockResponse(http.StatusNotFound, nil), nil
+}
+
+func TestImportSubscribersEnsureTags(t *testing.T) {
+	handler := &tagCatalogHandler{existing: []string{"customer"}}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	subscribers := []*bento.SubscriberInput{
+		{Email: "test1@example.com", Tags: []string{"customer", "beta", "eu"}},
+		{Email: "test2@example.com", Tags: []string{"beta", "newsletter", "vip"}},
+	}
+
+	result, err := client.ImportSubscribersWithOptions(context.Background(), subscribers, &bento.ImportOptions{
+		EnsureTags:     true,
+		TagConcurrency: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"beta", "eu", "newsletter", "vip"}
+	if !reflect.DeepEqual(result.CreatedTags, want) {
+		t.Errorf("expected created tags %v, got %v", want, result.CreatedTags)
+	}
+	if handler.maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent tag creations, got %d", handler.maxInFlight)
+	}
+	if handler.importCalls != 1 {
+		t.Errorf("expected 1 import call, got %d", handler.importCalls)
+	}
+
+	// The second import is served from the tag cache, including the tags just created
+	result, err = client.ImportSubscribersWithOptions(context.Background(), subscribers, &bento.ImportOptions{
+		EnsureTags: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.CreatedTags) != 0 {
+		t.Errorf("expected no tags to be created, got %v", result.CreatedTags)
+	}
+	if handler.tagFetches != 1 {
+		t.Errorf("expected tags to be fetched once, got %d", handler.tagFetches)
+	}
+	if len(handler.createdTags) != 4 {
+		t.Errorf("expected 4 tag creations in total, got %v", handler.createdTags)
+	}
+}
+
+func TestImportSubscribersStrictTags(t *testing.T) {
+	handler := &tagCatalogHandler{existing: []string{"customer"}}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	_, err = client.ImportSubscribersWithOptions(context.Background(), []*bento.SubscriberInput{
+		{Email: "test1@example.com", Tags: []string{"customer", "vip", "beta"}},
+	}, &bento.ImportOptions{StrictTags: true})
+
+	if !errors.Is(err, bento.ErrInvalidTags) {
+		t.Fatalf("expected ErrInvalidTags, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "beta, vip") {
+		t.Errorf("expected unknown tags to be listed, got %v", err)
+	}
+	if handler.importCalls != 0 || len(handler.createdTags) != 0 {
+		t.Errorf("expected no tags created and no import, got %v and %d", handler.createdTags, handler.importCalls)
+	}
+
+	_, err = client.ImportSubscribersWithOptions(context.Background(), []*bento.SubscriberInput{
+		{Email: "test1@example.com", Tags: []string{"customer"}},
+	}, &bento.ImportOptions{StrictTags: true, EnsureTags: true})
+	if !errors.Is(err, bento.ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest for conflicting options, got %v", err)
+	}
+}
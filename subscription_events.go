@@ -0,0 +1,87 @@
+package bento
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Detail keys of $subscribe and $unsubscribe events that Bento shows as the
+// attribution of the change. When present they must be non-blank strings of
+// at most 255 characters.
+const (
+	SubscriptionSourceKey = "source"
+	SubscriptionReasonKey = "reason"
+)
+
+// maxSubscriptionDetailLength is the longest source or reason accepted
+const maxSubscriptionDetailLength = 255
+
+// TrackSubscribe records a $subscribe event for email with the given details,
+// such as SubscriptionSourceKey. Unlike a CommandSubscribe sent with
+// SubscriberCommand, it records the change with its attribution.
+func (c *Client) TrackSubscribe(ctx context.Context, email string, details map[string]interface{}, opts ...RequestOption) error {
+	return c.trackSubscription(ctx, EventSubscribe, email, details, opts)
+}
+
+// TrackUnsubscribe records an $unsubscribe event for email with the given
+// details, such as SubscriptionReasonKey
+func (c *Client) TrackUnsubscribe(ctx context.Context, email string, details map[string]interface{}, opts ...RequestOption) error {
+	return c.trackSubscription(ctx, EventUnsubscribe, email, details, opts)
+}
+
+func (c *Client) trackSubscription(ctx context.Context, eventType, email string, details map[string]interface{}, opts []RequestOption) error {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	event, err := subscriptionEvent(eventType, email, details)
+	if err != nil {
+		return err
+	}
+	return c.TrackEvent(ctx, []EventData{event})
+}
+
+// SubscribeEvent returns the $subscribe event TrackSubscribe sends, for use
+// with an EventBuffer, a Batcher or a DedupeKey
+func SubscribeEvent(email string, details map[string]interface{}) (EventData, error) {
+	return subscriptionEvent(EventSubscribe, email, details)
+}
+
+// UnsubscribeEvent returns the $unsubscribe event TrackUnsubscribe sends, for
+// use with an EventBuffer, a Batcher or a DedupeKey
+func UnsubscribeEvent(email string, details map[string]interface{}) (EventData, error) {
+	return subscriptionEvent(EventUnsubscribe, email, details)
+}
+
+// subscriptionEvent builds an event of eventType after validating the
+// attribution details, which are copied
+func subscriptionEvent(eventType, email string, details map[string]interface{}) (EventData, error) {
+	for _, key := range []string{SubscriptionSourceKey, SubscriptionReasonKey} {
+		value, ok := details[key]
+		if !ok {
+			continue
+		}
+		field := "details." + key
+		s, isString := value.(string)
+		switch {
+		case !isString:
+			return EventData{}, invalidField(ErrInvalidRequest, field, fmt.Sprint(value), key+" must be a string")
+		case strings.TrimSpace(s) == "":
+			return EventData{}, invalidField(ErrInvalidRequest, field, s, key+" cannot be blank")
+		case utf8.RuneCountInString(s) > maxSubscriptionDetailLength:
+			return EventData{}, invalidField(ErrInvalidRequest, field, s, fmt.Sprintf("%s exceeds %d characters", key, maxSubscriptionDetailLength))
+		}
+	}
+
+	event := EventData{Type: eventType, Email: email}
+	if len(details) > 0 {
+		event.Details = make(map[string]interface{}, len(details))
+		for key, value := range details {
+			event.Details[key] = value
+		}
+	}
+	return event, nil
+}
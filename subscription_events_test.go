@@ -0,0 +1,180 @@
+package bento_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+func TestTrackSubscription(t *testing.T) {
+	tests := []struct {
+		name     string
+		track    func(*bento.Client, map[string]interface{}) error
+		wantType string
+	}{
+		{
+			name: "subscribe",
+			track: func(c *bento.Client, details map[string]interface{}) error {
+				return c.TrackSubscribe(context.Background(), "user@example.com", details)
+			},
+			wantType: "$subscribe",
+		},
+		{
+			name: "unsubscribe",
+			track: func(c *bento.Client, details map[string]interface{}) error {
+				return c.TrackUnsubscribe(context.Background(), "user@example.com", details)
+			},
+			wantType: "$unsubscribe",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body struct {
+				Events []map[string]interface{} `json:"events"`
+			}
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			details := map[string]interface{}{
+				bento.SubscriptionSourceKey: "footer_form",
+				bento.SubscriptionReasonKey: "newsletter",
+				"campaign":                  "spring",
+			}
+			if err := tt.track(client, details); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := map[string]interface{}{
+				"type":  tt.wantType,
+				"email": "user@example.com",
+				"details": map[string]interface{}{
+					"source":   "footer_form",
+					"reason":   "newsletter",
+					"campaign": "spring",
+				},
+			}
+			if len(body.Events) != 1 || !reflect.DeepEqual(body.Events[0], want) {
+				t.Errorf("unexpected events:\n got %v\nwant %v", body.Events, want)
+			}
+		})
+	}
+
+	if bento.EventSubscribe != "$subscribe" || bento.EventUnsubscribe != "$unsubscribe" {
+		t.Errorf("unexpected event types %q and %q", bento.EventSubscribe, bento.EventUnsubscribe)
+	}
+}
+
+func TestTrackSubscriptionValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		details   map[string]interface{}
+		wantField string
+	}{
+		{name: "source not a string", details: map[string]interface{}{"source": 42}, wantField: "details.source"},
+		{name: "blank reason", details: map[string]interface{}{"reason": "  "}, wantField: "details.reason"},
+		{name: "reason too long", details: map[string]interface{}{"reason": strings.Repeat("a", 256)}, wantField: "details.reason"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				t.Errorf("unexpected request: %s", req.URL)
+				return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			err = client.TrackUnsubscribe(context.Background(), "user@example.com", tt.details)
+			var validationErr *bento.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.wantField || !errors.Is(err, bento.ErrInvalidRequest) {
+				t.Errorf("expected a %s validation error, got %v", tt.wantField, err)
+			}
+		})
+	}
+
+	t.Run("invalid email", func(t *testing.T) {
+		client, err := setupTestClient(nil)
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+		if err := client.TrackSubscribe(context.Background(), "invalid", nil); !errors.Is(err, bento.ErrInvalidEmail) {
+			t.Errorf("expected ErrInvalidEmail, got %v", err)
+		}
+	})
+}
+
+func TestSubscriptionEventsCompose(t *testing.T) {
+	t.Run("dedupe", func(t *testing.T) {
+		requests := 0
+		client, err := setupTestClientWithConfig(func(c *bento.Config) {
+			c.EventDedupe = &bento.EventDedupeConfig{}
+		}, func(req *http.Request) (*http.Response, error) {
+			requests++
+			return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+		})
+		if err != nil {
+			t.Fatalf("failed to setup test client: %v", err)
+		}
+
+		event, err := bento.UnsubscribeEvent("user@example.com", map[string]interface{}{bento.SubscriptionReasonKey: "too_many_emails"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		event.DedupeKey = "unsubscribe-user@example.com"
+		for i := 0; i < 2; i++ {
+			if err := client.TrackEvent(context.Background(), []bento.EventData{event}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if requests != 1 {
+			t.Errorf("expected the repeated unsubscribe to be suppressed, got %d requests", requests)
+		}
+	})
+
+	t.Run("buffer", func(t *testing.T) {
+		batches, handler := bufferedBatches(t, http.StatusOK)
+		buf := setupEventBuffer(t, newFakeClock(), handler, bento.EventBufferOptions{FlushSize: 100, FlushInterval: time.Hour})
+
+		event, err := bento.SubscribeEvent("user@example.com", map[string]interface{}{bento.SubscriptionSourceKey: "checkout"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := buf.Add(context.Background(), event); err != nil {
+			t.Fatalf("unexpected error adding event: %v", err)
+		}
+		if err := buf.Flush(context.Background()); err != nil {
+			t.Fatalf("unexpected error flushing: %v", err)
+		}
+		if got := receiveBatch(t, batches); !reflect.DeepEqual(got, []string{"user@example.com"}) {
+			t.Errorf("expected the subscribe event, got %v", got)
+		}
+	})
+
+	t.Run("details are copied", func(t *testing.T) {
+		details := map[string]interface{}{bento.SubscriptionSourceKey: "checkout"}
+		event, err := bento.SubscribeEvent("user@example.com", details)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		event.Details["extra"] = true
+		if _, ok := details["extra"]; ok {
+			t.Error("expected the caller's details to be left unchanged")
+		}
+	})
+}
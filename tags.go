@@ -9,7 +9,12 @@ import (
 )
 
 // GetTags retrieves all tags
-func (c *Client) GetTags(ctx context.Context) ([]TagData, error) {
+func (c *Client) GetTags(ctx context.Context, opts ...RequestOption) ([]TagData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
 		fmt.Sprintf("%s/fetch/tags", c.baseURL), nil)
 	if err != nil {
@@ -23,21 +28,68 @@ func (c *Client) GetTags(ctx context.Context) ([]TagData, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result struct {
 		Data []TagData `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := c.decodeJSON(req, resp, &result, "data"); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(result.Data))
+	for _, tag := range result.Data {
+		names = append(names, tag.Attributes.Name)
 	}
+	c.tagCache.replace(names, c.clock().Now())
 
 	return result.Data, nil
 }
 
+// GetSubscriberTags returns the tags of the subscriber with the given email,
+// in the order of its cached tag IDs, using one lookup of the subscriber and
+// one of the tags. IDs that match no tag, such as a tag deleted since the
+// subscriber was cached, are skipped.
+func (c *Client) GetSubscriberTags(ctx context.Context, email string, opts ...RequestOption) ([]TagData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	subscriber, err := c.FindSubscriber(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if len(subscriber.Attributes.CachedTagIDs) == 0 {
+		return nil, nil
+	}
+
+	tags, err := c.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]TagData, len(tags))
+	for _, tag := range tags {
+		byID[tag.ID] = tag
+	}
+
+	var matched []TagData
+	for _, id := range subscriber.Attributes.CachedTagIDs {
+		if tag, ok := byID[id]; ok {
+			matched = append(matched, tag)
+		}
+	}
+	return matched, nil
+}
+
 // CreateTag creates a new tag
-func (c *Client) CreateTag(ctx context.Context, tagName string) (*TagData, error) {
+func (c *Client) CreateTag(ctx context.Context, tagName string, opts ...RequestOption) (*TagData, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	if tagName == "" {
 		return nil, fmt.Errorf("%w: tag name is required", ErrInvalidRequest)
 	}
@@ -64,15 +116,53 @@ func (c *Client) CreateTag(ctx context.Context, tagName string) (*TagData, error
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("%w: %d", ErrAPIResponse, resp.StatusCode)
+		return nil, c.statusError(req, resp)
 	}
 
 	var result struct {
 		Data TagData `json:"data"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := c.decodeJSON(req, resp, &result, "data"); err != nil {
+		return nil, err
 	}
 
+	c.tagCache.add(tagName)
+
 	return &result.Data, nil
 }
+
+// EnsureTag creates the tag unless it already exists, reporting whether it was
+// created. Existing tags are looked up through a cache of the last GetTags result.
+func (c *Client) EnsureTag(ctx context.Context, tagName string, opts ...RequestOption) (bool, error) {
+	ctx = withRequestOptions(ctx, opts)
+	if err := checkContext(ctx); err != nil {
+		return false, err
+	}
+
+	if tagName == "" {
+		return false, fmt.Errorf("%w: tag name is required", ErrInvalidRequest)
+	}
+
+	exists, err := c.tagExists(ctx, tagName)
+	if err != nil || exists {
+		return false, err
+	}
+
+	if _, err := c.CreateTag(ctx, tagName); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// tagExists checks the tag cache, refreshing it with GetTags when it is stale
+func (c *Client) tagExists(ctx context.Context, tagName string) (bool, error) {
+	found, fresh := c.tagCache.lookup(tagName, c.clock().Now(), c.catalogTTL())
+	if found || fresh {
+		return found, nil
+	}
+	if _, err := c.GetTags(ctx); err != nil {
+		return false, err
+	}
+	found, _ = c.tagCache.lookup(tagName, c.clock().Now(), c.catalogTTL())
+	return found, nil
+}
@@ -3,8 +3,10 @@ package bento_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -331,3 +333,92 @@ func TestCreateTagContextCancellation(t *testing.T) {
 		t.Errorf("expected context.Canceled error, got %v", err)
 	}
 }
+
+func TestEnsureTag(t *testing.T) {
+	handler := &tagCatalogHandler{existing: []string{"customer"}}
+	client, err := setupTestClient(handler.handle)
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	ctx := context.Background()
+
+	if created, err := client.EnsureTag(ctx, "customer"); err != nil || created {
+		t.Errorf("expected existing tag, got created=%v err=%v", created, err)
+	}
+	if created, err := client.EnsureTag(ctx, "vip"); err != nil || !created {
+		t.Errorf("expected new tag, got created=%v err=%v", created, err)
+	}
+	if created, err := client.EnsureTag(ctx, "vip"); err != nil || created {
+		t.Errorf("expected cached tag, got created=%v err=%v", created, err)
+	}
+	if handler.tagFetches != 1 {
+		t.Errorf("expected tags to be fetched once, got %d", handler.tagFetches)
+	}
+	if _, err := client.EnsureTag(ctx, ""); err == nil {
+		t.Error("expected error for empty tag name, got nil")
+	}
+}
+
+func TestGetSubscriberTags(t *testing.T) {
+	tags := map[string]interface{}{
+		"data": []map[string]interface{}{
+			{"id": "tag_1", "type": "tags", "attributes": map[string]interface{}{"name": "customer"}},
+			{"id": "tag_2", "type": "tags", "attributes": map[string]interface{}{"name": "vip"}},
+			{"id": "tag_3", "type": "tags", "attributes": map[string]interface{}{"name": "beta"}},
+		},
+	}
+	subscriber := func(tagIDs ...string) map[string]interface{} {
+		return map[string]interface{}{
+			"data": map[string]interface{}{
+				"id":         "sub_123",
+				"type":       "subscriber",
+				"attributes": map[string]interface{}{"email": "test@example.com", "cached_tag_ids": tagIDs},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		subscriber interface{}
+		status     int
+		want       []string
+		wantCalls  int
+		wantErr    error
+	}{
+		{name: "all tags match", subscriber: subscriber("tag_3", "tag_1"), status: http.StatusOK, want: []string{"beta", "customer"}, wantCalls: 2},
+		{name: "unknown ID is skipped", subscriber: subscriber("tag_2", "tag_deleted"), status: http.StatusOK, want: []string{"vip"}, wantCalls: 2},
+		{name: "no tags", subscriber: subscriber(), status: http.StatusOK, wantCalls: 1},
+		{name: "missing subscriber", subscriber: map[string]string{"error": "Not found"}, status: http.StatusNotFound, wantCalls: 1, wantErr: bento.ErrSubscriberNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			client, err := setupTestClient(func(req *http.Request) (*http.Response, error) {
+				calls++
+				if strings.HasSuffix(req.URL.Path, "/fetch/tags") {
+					return mockResponse(http.StatusOK, tags), nil
+				}
+				return mockResponse(tt.status, tt.subscriber), nil
+			})
+			if err != nil {
+				t.Fatalf("failed to setup test client: %v", err)
+			}
+
+			got, err := client.GetSubscriberTags(context.Background(), "test@example.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			var names []string
+			for _, tag := range got {
+				names = append(names, tag.Attributes.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("expected tags %v, got %v", tt.want, names)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d API calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
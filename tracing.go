@@ -0,0 +1,30 @@
+package bento
+
+import (
+	"net/http"
+)
+
+// Tracer wraps every API call in a span covering all of its retries. The
+// otelbento module provides one backed by OpenTelemetry.
+type Tracer interface {
+	// StartSpan begins a span for a call to endpoint, the API path relative
+	// to the base URL, e.g. "/batch/events". It returns the request to send,
+	// which may carry trace propagation headers.
+	StartSpan(req *http.Request, endpoint string) (*http.Request, Span)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	// End finishes the span; status is zero when no response was received
+	End(attempts, status int, err error)
+}
+
+// traced executes a prepared request inside a span started by the
+// configured tracer. The span covers every retry.
+func (c *Client) traced(req *http.Request, opts *requestOptions) (*http.Response, error) {
+	req, span := c.tracer.StartSpan(req, c.endpoint(req))
+
+	resp, attempts, err := c.execute(req, opts)
+	span.End(attempts, responseStatus(resp, err), err)
+	return resp, err
+}
@@ -0,0 +1,107 @@
+package bento_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// recordingTracer records every span and tags requests with a header
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	endpoint string
+	ended    bool
+	attempts int
+	status   int
+	err      error
+}
+
+func (t *recordingTracer) StartSpan(req *http.Request, endpoint string) (*http.Request, bento.Span) {
+	span := &recordedSpan{endpoint: endpoint}
+	t.spans = append(t.spans, span)
+	req = req.Clone(req.Context())
+	req.Header.Set("Traceparent", "traced")
+	return req, span
+}
+
+func (s *recordedSpan) End(attempts, status int, err error) {
+	s.ended = true
+	s.attempts = attempts
+	s.status = status
+	s.err = err
+}
+
+func TestTracingTrackEvent(t *testing.T) {
+	tracer := &recordingTracer{}
+	var traceparent string
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Tracer = tracer
+	}, func(req *http.Request) (*http.Response, error) {
+		traceparent = req.Header.Get("Traceparent")
+		return mockResponse(http.StatusOK, map[string]interface{}{"results": 1, "failed": 0}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.TrackEvent(context.Background(), []bento.EventData{
+		{Type: "$pageview", Email: "test@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.endpoint != "/batch/events" {
+		t.Errorf("unexpected endpoint: %s", span.endpoint)
+	}
+	if !span.ended || span.attempts != 1 || span.status != http.StatusOK || span.err != nil {
+		t.Errorf("unexpected span result: %+v", span)
+	}
+	if traceparent != "traced" {
+		t.Errorf("expected the request returned by the tracer to be sent, got header %q", traceparent)
+	}
+}
+
+func TestTracingRecordsErrorsAndAttempts(t *testing.T) {
+	tracer := &recordingTracer{}
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Tracer = tracer
+		c.Retry = &bento.RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, RetryNonIdempotent: true}
+	}, func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusServiceUnavailable, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+
+	err = client.TrackEvent(context.Background(), []bento.EventData{
+		{Type: "$pageview", Email: "test@example.com"},
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected a single span covering all attempts, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", span.attempts)
+	}
+	if span.status != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", span.status)
+	}
+	if span.err == nil {
+		t.Errorf("expected the span to record the error, got %v", span.err)
+	}
+}
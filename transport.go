@@ -0,0 +1,81 @@
+package bento
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransportConfig tunes the connection pool of the HTTP client built by
+// NewClient. Zero fields keep the http.DefaultTransport values. It has no
+// effect on a client set with Config.HTTPClient or SetHTTPClient.
+type TransportConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+	TLSHandshakeTimeout time.Duration
+}
+
+// newTransport returns a copy of http.DefaultTransport with the configured
+// overrides. Either argument may be nil.
+func newTransport(config *TransportConfig, proxy *url.URL) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if config == nil {
+		return transport
+	}
+	if config.MaxIdleConns > 0 {
+		transport.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	if config.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = config.MaxConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+	if config.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = config.TLSHandshakeTimeout
+	}
+	return transport
+}
+
+// parseProxyURL validates Config.ProxyURL, returning nil when it is unset.
+// The URL is left out of errors since it may carry proxy credentials.
+func parseProxyURL(raw string) (*url.URL, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") || u.Host == "" {
+		return nil, fmt.Errorf("%w: ProxyURL must be an absolute http(s) or socks5 URL", ErrInvalidConfig)
+	}
+	return u, nil
+}
+
+// HTTPClient returns the HTTP client requests are sent with, before middlewares
+func (c *Client) HTTPClient() HTTPDoer {
+	return c.doers.Load().client
+}
+
+// idleConnectionCloser is implemented by HTTP clients that pool connections,
+// such as *http.Client
+type idleConnectionCloser interface {
+	CloseIdleConnections()
+}
+
+// Close releases idle keep-alive connections held by the HTTP client. It is
+// not a shutdown: the Client stays usable and later calls open fresh
+// connections. Close is a no-op for an HTTPDoer without CloseIdleConnections.
+func (c *Client) Close() error {
+	if closer, ok := c.HTTPClient().(idleConnectionCloser); ok {
+		closer.CloseIdleConnections()
+	}
+	return nil
+}
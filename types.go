@@ -2,6 +2,7 @@ package bento
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -25,32 +26,118 @@ const (
 	CommandSubscribe      CommandType = "subscribe"
 	CommandUnsubscribe    CommandType = "unsubscribe"
 	CommandChangeEmail    CommandType = "change_email"
+	// CommandChangeState sets the broadcast state of a subscriber to the
+	// SubscriberState in Query
+	CommandChangeState CommandType = "change_state"
+)
+
+// SubscriberState controls whether a subscriber receives broadcasts. A paused
+// subscriber still receives transactional emails and stays subscribed.
+type SubscriberState string
+
+const (
+	SubscriberActive SubscriberState = "active"
+	SubscriberPaused SubscriberState = "paused"
+)
+
+// Event types Bento reserves. Custom events may use any other name; with
+// Config.StrictEventTypes, names starting with "$" must be one of these.
+const (
+	EventSubscribe   = "$subscribe"
+	EventUnsubscribe = "$unsubscribe"
+	// EventPurchase is used for lifetime value tracking, see TrackPurchase
+	EventPurchase = "$purchase"
+	EventTag      = "$tag"
 )
 
 // EventData represents a tracking event
 type EventData struct {
 	Type    string                 `json:"type"`
-	Email   string                 `json:"email"`
+	Email   string                 `json:"email,omitempty"`
 	Fields  map[string]interface{} `json:"fields,omitempty"`
 	Details map[string]interface{} `json:"details,omitempty"`
+	// VisitorUUID attributes the event to an anonymous visitor tracked by
+	// Bento.js when there is no email. Email is preferred when both are set.
+	VisitorUUID string `json:"visitor_uuid,omitempty"`
+	// Date backdates the event to when it happened. It is sent in UTC and
+	// defaults to the time Bento receives the event.
+	Date *time.Time `json:"date,omitempty"`
+	// DedupeKey identifies the event for WithEventDedupe and
+	// Config.EventDedupe. It is not sent to Bento.
+	DedupeKey string `json:"-"`
 }
 
 // SubscriberData represents subscriber information from the API
 type SubscriberData struct {
-	ID         string `json:"id"`
-	Type       string `json:"type"`
-	Attributes struct {
-		UUID           string                 `json:"uuid"`
-		Email          string                 `json:"email"`
-		Fields         map[string]interface{} `json:"fields"`
-		CachedTagIDs   []string               `json:"cached_tag_ids"`
-		UnsubscribedAt *string                `json:"unsubscribed_at"`
-		NavigationURL  string                 `json:"navigation_url"`
-	} `json:"attributes"`
+	ID         string               `json:"id"`
+	Type       string               `json:"type"`
+	Attributes SubscriberAttributes `json:"attributes"`
+}
+
+// SubscriberAttributes holds the details of a subscriber
+type SubscriberAttributes struct {
+	UUID         string                 `json:"uuid"`
+	Email        string                 `json:"email"`
+	Fields       map[string]interface{} `json:"fields"`
+	CachedTagIDs []string               `json:"cached_tag_ids"`
+	// UnsubscribedAt is nil while the subscriber is subscribed
+	UnsubscribedAt *time.Time `json:"unsubscribed_at"`
+	NavigationURL  string     `json:"navigation_url"`
+}
+
+// FieldTime returns the custom field key parsed as a time, accepting RFC 3339
+// timestamps and dates such as those sent for time.Time field values
+func (a *SubscriberAttributes) FieldTime(key string) (time.Time, bool) {
+	s, ok := a.Fields[key].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// subscriberTimeLayouts are the timestamp formats accepted for
+// unsubscribed_at: RFC 3339 and the Rails default, with optional fractional
+// seconds
+var subscriberTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999 MST",
+	"2006-01-02 15:04:05.999999999 -0700",
+}
+
+// UnmarshalJSON parses unsubscribed_at in any of the formats the API has
+// used. It is marshaled back as RFC 3339.
+func (a *SubscriberAttributes) UnmarshalJSON(data []byte) error {
+	type attributes SubscriberAttributes
+	aux := struct {
+		*attributes
+		UnsubscribedAt *string `json:"unsubscribed_at"`
+	}{attributes: (*attributes)(a)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	a.UnsubscribedAt = nil
+	if aux.UnsubscribedAt == nil || *aux.UnsubscribedAt == "" {
+		return nil
+	}
+	for _, layout := range subscriberTimeLayouts {
+		if t, err := time.Parse(layout, *aux.UnsubscribedAt); err == nil {
+			a.UnsubscribedAt = &t
+			return nil
+		}
+	}
+	return withCode(CodeDecode, fmt.Errorf("unsubscribed_at: unrecognized time %q", *aux.UnsubscribedAt))
 }
 
 // BroadcastData represents a broadcast message
 type BroadcastData struct {
+	ID               string        `json:"id,omitempty"`
+	Status           string        `json:"status,omitempty"`
 	Name             string        `json:"name"`
 	Subject          string        `json:"subject"`
 	Content          string        `json:"content"`
@@ -60,6 +147,7 @@ type BroadcastData struct {
 	ExclusiveTags    string        `json:"exclusive_tags,omitempty"`
 	SegmentID        string        `json:"segment_id,omitempty"`
 	BatchSizePerHour int           `json:"batch_size_per_hour"`
+	SendAt           *time.Time    `json:"send_at,omitempty"`
 }
 
 // ContactData represents contact information
@@ -116,6 +204,9 @@ type ValidationData struct {
 	FullName     string `json:"name,omitempty"`
 	UserAgent    string `json:"user_agent,omitempty"`
 	IPAddress    string `json:"ip,omitempty"`
+
+	// SkipCache bypasses the validation cache for this call
+	SkipCache bool `json:"-"`
 }
 
 type ValidationResponse struct {
@@ -173,6 +264,50 @@ type ReportResponse struct {
 	ReportType string            `json:"report_type"`
 }
 
+// SiteStats represents the subscriber counts reported for a site
+type SiteStats struct {
+	UserCount         int `json:"user_count"`
+	SubscriberCount   int `json:"subscriber_count"`
+	UnsubscriberCount int `json:"unsubscriber_count"`
+}
+
+// StatsGranularity is the bucket size of a historical stats series
+type StatsGranularity string
+
+const (
+	StatsGranularityDay   StatsGranularity = "day"
+	StatsGranularityWeek  StatsGranularity = "week"
+	StatsGranularityMonth StatsGranularity = "month"
+)
+
+func (g StatsGranularity) IsValid() bool {
+	switch g {
+	case StatsGranularityDay, StatsGranularityWeek, StatsGranularityMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// SiteStatsRange selects a historical window of site stats
+type SiteStatsRange struct {
+	Start       time.Time
+	End         time.Time
+	Granularity StatsGranularity
+}
+
+// SiteStatsPoint is a single bucket of a historical site stats series
+type SiteStatsPoint struct {
+	Date string `json:"date"`
+	SiteStats
+}
+
+// SiteStatsSample is a site stats snapshot taken at a point in time
+type SiteStatsSample struct {
+	At    time.Time `json:"at"`
+	Stats SiteStats `json:"stats"`
+}
+
 // EmailData represents the structure for creating an email
 type EmailData struct {
 	To               string                 `json:"to"`
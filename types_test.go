@@ -59,14 +59,7 @@ func TestSubscriberDataJSONMarshaling(t *testing.T) {
 	subscriber := bento.SubscriberData{
 		ID:   "test_id",
 		Type: "subscriber",
-		Attributes: struct {
-			UUID           string                 `json:"uuid"`
-			Email          string                 `json:"email"`
-			Fields         map[string]interface{} `json:"fields"`
-			CachedTagIDs   []string               `json:"cached_tag_ids"`
-			UnsubscribedAt *string                `json:"unsubscribed_at"`
-			NavigationURL  string                 `json:"navigation_url"`
-		}{
+		Attributes: bento.SubscriberAttributes{
 			UUID:  "test_uuid",
 			Email: "test@example.com",
 			Fields: map[string]interface{}{
@@ -99,6 +92,73 @@ func TestSubscriberDataJSONMarshaling(t *testing.T) {
 	}
 }
 
+func TestSubscriberAttributesUnsubscribedAt(t *testing.T) {
+	want := time.Date(2024, 3, 15, 10, 30, 45, 123000000, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  *time.Time
+	}{
+		{name: "null", value: `null`},
+		{name: "missing", value: ``},
+		{name: "empty", value: `""`},
+		{name: "RFC3339", value: `"2024-03-15T10:30:45Z"`, want: timePtr(want.Truncate(time.Second))},
+		{name: "RFC3339 with fraction", value: `"2024-03-15T10:30:45.123Z"`, want: &want},
+		{name: "RFC3339 with offset", value: `"2024-03-15T12:30:45.123+02:00"`, want: &want},
+		{name: "Rails", value: `"2024-03-15 10:30:45 UTC"`, want: timePtr(want.Truncate(time.Second))},
+		{name: "Rails with fraction", value: `"2024-03-15 10:30:45.123 UTC"`, want: &want},
+		{name: "Rails with offset", value: `"2024-03-15 12:30:45.123 +0200"`, want: &want},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"email":"test@example.com"}`
+			if tt.value != "" {
+				data = `{"email":"test@example.com","unsubscribed_at":` + tt.value + `}`
+			}
+
+			var attrs bento.SubscriberAttributes
+			if err := json.Unmarshal([]byte(data), &attrs); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			if attrs.Email != "test@example.com" {
+				t.Errorf("expected the other attributes to decode, got email %q", attrs.Email)
+			}
+			assertTime(t, attrs.UnsubscribedAt, tt.want)
+
+			// Round-trip through RFC 3339
+			encoded, err := json.Marshal(attrs)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			var decoded bento.SubscriberAttributes
+			if err := json.Unmarshal(encoded, &decoded); err != nil {
+				t.Fatalf("failed to unmarshal %s: %v", encoded, err)
+			}
+			assertTime(t, decoded.UnsubscribedAt, tt.want)
+		})
+	}
+
+	var attrs bento.SubscriberAttributes
+	err := json.Unmarshal([]byte(`{"unsubscribed_at":"last tuesday"}`), &attrs)
+	if bento.CodeOf(err) != bento.CodeDecode {
+		t.Errorf("expected a decode error for an unknown format, got %v", err)
+	}
+}
+
+func timePtr(t time.Time) *time.Time { return &t }
+
+func assertTime(t *testing.T, got, want *time.Time) {
+	t.Helper()
+	switch {
+	case want == nil && got != nil:
+		t.Errorf("expected nil, got %v", got)
+	case want != nil && (got == nil || !got.Equal(*want)):
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
 func TestReportDataPointJSONMarshaling(t *testing.T) {
 	dataPoint := bento.ReportDataPoint{
 		Group: "test_group",
@@ -0,0 +1,161 @@
+package bento
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ValidationCacheConfig enables caching of ValidateEmail results.
+// Entries are keyed by the normalized email address only, so the optional
+// name, user agent and IP hints of later calls do not affect a cached result.
+type ValidationCacheConfig struct {
+	// TTL is how long a valid result is cached. Defaults to one hour.
+	TTL time.Duration
+	// NegativeTTL is how long an invalid result is cached. Zero disables
+	// caching of invalid results.
+	NegativeTTL time.Duration
+	// MaxEntries bounds the cache size; the least recently used entry is
+	// evicted when it is full. Defaults to 1000.
+	MaxEntries int
+}
+
+// ValidationCacheStats reports the effectiveness of the validation cache
+type ValidationCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+}
+
+// HitRate returns the fraction of lookups served from the cache
+func (s ValidationCacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+const (
+	defaultValidationCacheTTL        = time.Hour
+	defaultValidationCacheMaxEntries = 1000
+)
+
+// validationCache is an LRU cache of validation results with per-entry expiry
+type validationCache struct {
+	mu          sync.Mutex
+	ttl         time.Duration
+	negativeTTL time.Duration
+	maxEntries  int
+	clock       Clock
+	order       *list.List
+	entries     map[string]*list.Element
+	stats       ValidationCacheStats
+}
+
+type validationCacheEntry struct {
+	key       string
+	result    ValidationResponse
+	expiresAt time.Time
+}
+
+func newValidationCache(config *ValidationCacheConfig, clock Clock) *validationCache {
+	cache := &validationCache{
+		ttl:         config.TTL,
+		negativeTTL: config.NegativeTTL,
+		maxEntries:  config.MaxEntries,
+		clock:       clock,
+		order:       list.New(),
+		entries:     make(map[string]*list.Element),
+	}
+	if cache.ttl <= 0 {
+		cache.ttl = defaultValidationCacheTTL
+	}
+	if cache.maxEntries <= 0 {
+		cache.maxEntries = defaultValidationCacheMaxEntries
+	}
+	return cache
+}
+
+// validationCacheKey normalizes an email address for use as a cache key
+func validationCacheKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (vc *validationCache) get(email string) (*ValidationResponse, bool) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	elem, ok := vc.entries[validationCacheKey(email)]
+	if !ok {
+		vc.stats.Misses++
+		return nil, false
+	}
+
+	entry := elem.Value.(*validationCacheEntry)
+	if !vc.clock.Now().Before(entry.expiresAt) {
+		vc.order.Remove(elem)
+		delete(vc.entries, entry.key)
+		vc.stats.Misses++
+		return nil, false
+	}
+
+	vc.order.MoveToFront(elem)
+	vc.stats.Hits++
+	result := entry.result
+	return &result, true
+}
+
+func (vc *validationCache) set(email string, result *ValidationResponse) {
+	ttl := vc.ttl
+	if !result.Valid {
+		ttl = vc.negativeTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	key := validationCacheKey(email)
+	expiresAt := vc.clock.Now().Add(ttl)
+	if elem, ok := vc.entries[key]; ok {
+		entry := elem.Value.(*validationCacheEntry)
+		entry.result = *result
+		entry.expiresAt = expiresAt
+		vc.order.MoveToFront(elem)
+		return
+	}
+
+	vc.entries[key] = vc.order.PushFront(&validationCacheEntry{
+		key:       key,
+		result:    *result,
+		expiresAt: expiresAt,
+	})
+	for vc.order.Len() > vc.maxEntries {
+		oldest := vc.order.Back()
+		vc.order.Remove(oldest)
+		delete(vc.entries, oldest.Value.(*validationCacheEntry).key)
+		vc.stats.Evictions++
+	}
+}
+
+func (vc *validationCache) snapshot() ValidationCacheStats {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	stats := vc.stats
+	stats.Entries = vc.order.Len()
+	return stats
+}
+
+// ValidationCacheStats returns hit/miss counters for the validation cache.
+// It returns zero values when Config.ValidationCache is not set.
+func (c *Client) ValidationCacheStats() ValidationCacheStats {
+	if c.validationCache == nil {
+		return ValidationCacheStats{}
+	}
+	return c.validationCache.snapshot()
+}
@@ -0,0 +1,176 @@
+package bento_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	bento "github.com/bentonow/bento-golang-sdk"
+)
+
+// setupValidationCacheClient returns a client with the validation cache enabled and a request counter
+func setupValidationCacheClient(t *testing.T, clock *fakeClock, cacheConfig *bento.ValidationCacheConfig) (*bento.Client, *int) {
+	t.Helper()
+	calls := 0
+	client, err := setupTestClientWithConfig(func(c *bento.Config) {
+		c.Clock = clock
+		c.ValidationCache = cacheConfig
+	}, func(req *http.Request) (*http.Response, error) {
+		calls++
+		valid := req.URL.Query().Get("email") != "bad@example.com"
+		return mockResponse(http.StatusOK, map[string]interface{}{"valid": valid}), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to setup test client: %v", err)
+	}
+	return client, &calls
+}
+
+func validate(t *testing.T, client *bento.Client, data *bento.ValidationData) *bento.ValidationResponse {
+	t.Helper()
+	result, err := client.ValidateEmail(context.Background(), data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return result
+}
+
+func TestValidationCacheHits(t *testing.T) {
+	client, calls := setupValidationCacheClient(t, newFakeClock(), &bento.ValidationCacheConfig{})
+
+	validate(t, client, &bento.ValidationData{EmailAddress: "user@example.com"})
+	result := validate(t, client, &bento.ValidationData{EmailAddress: " User@Example.com "})
+
+	if !result.Valid {
+		t.Error("expected cached result to be valid")
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 request, got %d", *calls)
+	}
+
+	stats := client.ValidationCacheStats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if stats.HitRate() != 0.5 {
+		t.Errorf("expected hit rate 0.5, got %f", stats.HitRate())
+	}
+}
+
+func TestValidationCacheExpiry(t *testing.T) {
+	clock := newFakeClock()
+	client, calls := setupValidationCacheClient(t, clock, &bento.ValidationCacheConfig{
+		TTL:         time.Hour,
+		NegativeTTL: time.Minute,
+	})
+
+	validate(t, client, &bento.ValidationData{EmailAddress: "user@example.com"})
+	validate(t, client, &bento.ValidationData{EmailAddress: "bad@example.com"})
+
+	clock.Advance(2 * time.Minute)
+	validate(t, client, &bento.ValidationData{EmailAddress: "user@example.com"})
+	if *calls != 2 {
+		t.Errorf("expected valid result to still be cached, got %d requests", *calls)
+	}
+
+	result := validate(t, client, &bento.ValidationData{EmailAddress: "bad@example.com"})
+	if result.Valid {
+		t.Error("expected invalid result")
+	}
+	if *calls != 3 {
+		t.Errorf("expected negative result to expire, got %d requests", *calls)
+	}
+
+	clock.Advance(time.Hour)
+	validate(t, client, &bento.ValidationData{EmailAddress: "user@example.com"})
+	if *calls != 4 {
+		t.Errorf("expected valid result to expire, got %d requests", *calls)
+	}
+}
+
+func TestValidationCacheNegativeDisabled(t *testing.T) {
+	client, calls := setupValidationCacheClient(t, newFakeClock(), &bento.ValidationCacheConfig{})
+
+	validate(t, client, &bento.ValidationData{EmailAddress: "bad@example.com"})
+	validate(t, client, &bento.ValidationData{EmailAddress: "bad@example.com"})
+
+	if *calls != 2 {
+		t.Errorf("expected invalid results not to be cached, got %d requests", *calls)
+	}
+}
+
+func TestValidationCacheEviction(t *testing.T) {
+	client, calls := setupValidationCacheClient(t, newFakeClock(), &bento.ValidationCacheConfig{MaxEntries: 2})
+
+	validate(t, client, &bento.ValidationData{EmailAddress: "a@example.com"})
+	validate(t, client, &bento.ValidationData{EmailAddress: "b@example.com"})
+	validate(t, client, &bento.ValidationData{EmailAddress: "a@example.com"}) // a is now most recent
+	validate(t, client, &bento.ValidationData{EmailAddress: "c@example.com"}) // evicts b
+
+	if *calls != 3 {
+		t.Fatalf("expected 3 requests, got %d", *calls)
+	}
+
+	validate(t, client, &bento.ValidationData{EmailAddress: "a@example.com"})
+	if *calls != 3 {
+		t.Errorf("expected a to remain cached, got %d requests", *calls)
+	}
+
+	validate(t, client, &bento.ValidationData{EmailAddress: "b@example.com"})
+	if *calls != 4 {
+		t.Errorf("expected b to have been evicted, got %d requests", *calls)
+	}
+
+	if stats := client.ValidationCacheStats(); stats.Evictions != 2 || stats.Entries != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestValidationCacheBypass(t *testing.T) {
+	client, calls := setupValidationCacheClient(t, newFakeClock(), &bento.ValidationCacheConfig{})
+
+	validate(t, client, &bento.ValidationData{EmailAddress: "user@example.com"})
+	validate(t, client, &bento.ValidationData{EmailAddress: "user@example.com", SkipCache: true})
+
+	if *calls != 2 {
+		t.Errorf("expected bypass to issue a request, got %d requests", *calls)
+	}
+}
+
+func TestValidateEmails(t *testing.T) {
+	client, calls := setupValidationCacheClient(t, newFakeClock(), &bento.ValidationCacheConfig{})
+
+	results, err := client.ValidateEmails(context.Background(), []*bento.ValidationData{
+		{EmailAddress: "user@example.com"},
+		{EmailAddress: "bad@example.com"},
+		{EmailAddress: "user@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 3 || !results[0].Valid || results[1].Valid || !results[2].Valid {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if *calls != 2 {
+		t.Errorf("expected 2 requests, got %d", *calls)
+	}
+
+	if _, err := client.ValidateEmails(context.Background(), nil); err == nil {
+		t.Error("expected error for empty input, got nil")
+	}
+}
+
+func TestValidationCacheDisabled(t *testing.T) {
+	client, calls := setupValidationCacheClient(t, newFakeClock(), nil)
+
+	validate(t, client, &bento.ValidationData{EmailAddress: "user@example.com"})
+	validate(t, client, &bento.ValidationData{EmailAddress: "user@example.com"})
+
+	if *calls != 2 {
+		t.Errorf("expected no caching, got %d requests", *calls)
+	}
+	if stats := client.ValidationCacheStats(); stats != (bento.ValidationCacheStats{}) {
+		t.Errorf("expected zero stats, got %+v", stats)
+	}
+}
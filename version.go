@@ -0,0 +1,12 @@
+package bento
+
+// Version is the SDK release, reported in the User-Agent header
+const Version = "0.1.0"
+
+// userAgent identifies the SDK to the API, followed by the application's suffix if configured
+func (c *Client) userAgent() string {
+	if c.config.UserAgentSuffix == "" {
+		return "bento-go-sdk/" + Version
+	}
+	return "bento-go-sdk/" + Version + " " + c.config.UserAgentSuffix
+}